@@ -79,7 +79,7 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.NodePlacement = (*v1beta1.ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
 	dst.Spec.Notifications = *ConvertAlphaToBetaNotifications(&src.Spec.Notifications)
 	dst.Spec.Prometheus = *ConvertAlphaToBetaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = v1beta1.ArgoCDRBACSpec(src.Spec.RBAC)
+	dst.Spec.RBAC = ConvertAlphaToBetaRBAC(src.Spec.RBAC)
 	dst.Spec.Redis = *ConvertAlphaToBetaRedis(&src.Spec.Redis)
 	dst.Spec.Repo = *ConvertAlphaToBetaRepo(&src.Spec.Repo)
 	//lint:ignore SA1019 known to be deprecated
@@ -105,7 +105,7 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.NetworkPolicy = v1beta1.ArgoCDNetworkPolicySpec(src.Spec.NetworkPolicy)
 
 	// Status conversion
-	dst.Status = v1beta1.ArgoCDStatus(src.Status)
+	dst.Status = ConvertAlphaToBetaStatus(src.Status)
 
 	return nil
 }
@@ -156,7 +156,7 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.NodePlacement = (*ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
 	dst.Spec.Notifications = *ConvertBetaToAlphaNotifications(&src.Spec.Notifications)
 	dst.Spec.Prometheus = *ConvertBetaToAlphaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = ArgoCDRBACSpec(src.Spec.RBAC)
+	dst.Spec.RBAC = ConvertBetaToAlphaRBAC(src.Spec.RBAC)
 	dst.Spec.Redis = *ConvertBetaToAlphaRedis(&src.Spec.Redis)
 	dst.Spec.Repo = *ConvertBetaToAlphaRepo(&src.Spec.Repo)
 	//lint:ignore SA1019 known to be deprecated
@@ -181,7 +181,7 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.WebhookSecrets = ConvertBetaToAlphaWebhookSecrets(src.Spec.WebhookSecrets)
 
 	// Status conversion
-	dst.Status = ArgoCDStatus(src.Status)
+	dst.Status = ConvertBetaToAlphaStatus(src.Status)
 
 	return nil
 }
@@ -1205,3 +1205,59 @@ func ConvertAlphaToBetaNamespaceManagement(src []ManagedNamespaces) []v1beta1.Ma
 	}
 	return dst
 }
+
+// ConvertAlphaToBetaStatus converts the status from v1alpha1 to v1beta1.
+func ConvertAlphaToBetaStatus(src ArgoCDStatus) v1beta1.ArgoCDStatus {
+	return v1beta1.ArgoCDStatus{
+		ApplicationController:    src.ApplicationController,
+		ApplicationSetController: src.ApplicationSetController,
+		SSO:                      src.SSO,
+		NotificationsController:  src.NotificationsController,
+		Phase:                    src.Phase,
+		Redis:                    src.Redis,
+		Repo:                     src.Repo,
+		Server:                   src.Server,
+		RepoTLSChecksum:          src.RepoTLSChecksum,
+		RedisTLSChecksum:         src.RedisTLSChecksum,
+		Host:                     src.Host,
+		Conditions:               src.Conditions,
+	}
+}
+
+// ConvertBetaToAlphaStatus converts the status from v1beta1 to v1alpha1.
+// Status fields that only exist in v1beta1 are dropped.
+func ConvertBetaToAlphaStatus(src v1beta1.ArgoCDStatus) ArgoCDStatus {
+	return ArgoCDStatus{
+		ApplicationController:    src.ApplicationController,
+		ApplicationSetController: src.ApplicationSetController,
+		SSO:                      src.SSO,
+		NotificationsController:  src.NotificationsController,
+		Phase:                    src.Phase,
+		Redis:                    src.Redis,
+		Repo:                     src.Repo,
+		Server:                   src.Server,
+		RepoTLSChecksum:          src.RepoTLSChecksum,
+		RedisTLSChecksum:         src.RedisTLSChecksum,
+		Host:                     src.Host,
+		Conditions:               src.Conditions,
+	}
+}
+
+func ConvertAlphaToBetaRBAC(src ArgoCDRBACSpec) v1beta1.ArgoCDRBACSpec {
+	return v1beta1.ArgoCDRBACSpec{
+		DefaultPolicy:     src.DefaultPolicy,
+		Policy:            src.Policy,
+		Scopes:            src.Scopes,
+		PolicyMatcherMode: src.PolicyMatcherMode,
+	}
+}
+
+// ConvertBetaToAlphaRBAC drops Roles and PolicyOverlays, which only exist in v1beta1.
+func ConvertBetaToAlphaRBAC(src v1beta1.ArgoCDRBACSpec) ArgoCDRBACSpec {
+	return ArgoCDRBACSpec{
+		DefaultPolicy:     src.DefaultPolicy,
+		Policy:            src.Policy,
+		Scopes:            src.Scopes,
+		PolicyMatcherMode: src.PolicyMatcherMode,
+	}
+}
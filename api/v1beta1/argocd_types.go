@@ -96,6 +96,9 @@ type ArgoCDApplicationControllerSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Controller","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// VPA defines the VerticalPodAutoscaler options for the Argo CD Application Controller.
+	VPA *ArgoCDVPASpec `json:"vpa,omitempty"`
+
 	// ParallelismLimit defines the limit for parallel kubectl operations
 	ParallelismLimit int32 `json:"parallelismLimit,omitempty"`
 
@@ -196,6 +199,9 @@ type ArgoCDApplicationSet struct {
 	// Resources defines the Compute Resources required by the container for ApplicationSet.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// VPA defines the VerticalPodAutoscaler options for the ApplicationSet controller.
+	VPA *ArgoCDVPASpec `json:"vpa,omitempty"`
+
 	// LogLevel describes the log level that should be used by the ApplicationSet controller. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
 
@@ -231,6 +237,11 @@ type ArgoCDApplicationSet struct {
 	// LogFormat refers to the log format used by the ApplicationSet component. Defaults to ArgoCDDefaultLogFormat if not configured. Valid options are text or json.
 	// +kubebuilder:validation:Enum=text;json
 	LogFormat string `json:"logFormat,omitempty"`
+
+	// Autoscale defines the autoscale options for the ApplicationSet controller. Leader election is enabled when
+	// autoscaling is enabled, so that a single replica reconciles the ApplicationSets. The other replicas only stand
+	// by to take over, autoscaling provides failover rather than more throughput.
+	Autoscale *ArgoCDAutoscaleSpec `json:"autoscale,omitempty"`
 }
 
 func (a *ArgoCDApplicationSet) IsEnabled() bool {
@@ -263,6 +274,12 @@ type ArgoCDDexSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Configuration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:text"}
 	Config string `json:"config,omitempty"`
 
+	// Connectors are typed Dex connectors, appended to the connectors of Config. Their sensitive fields are
+	// read from Secrets and referenced from the Dex configuration through argocd-secret.
+	// +listType=map
+	// +listMapKey=id
+	Connectors []ArgoCDDexConnector `json:"connectors,omitempty"`
+
 	// Optional list of required groups a user must be a member of
 	Groups []string `json:"groups,omitempty"`
 
@@ -298,6 +315,345 @@ type ArgoCDDexSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// ArgoCDOIDCSpec defines an external OIDC provider used by Argo CD for SSO.
+// +kubebuilder:validation:XValidation:rule="has(self.clientSecretRef) || (has(self.enablePKCEAuthentication) && self.enablePKCEAuthentication)",message="clientSecretRef must be set unless enablePKCEAuthentication is true"
+type ArgoCDOIDCSpec struct {
+	// Name of the provider, displayed on the login page.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Issuer URL of the provider.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https://`
+	Issuer string `json:"issuer"`
+
+	// ClientID of the Argo CD client registered with the provider.
+	// +kubebuilder:validation:Required
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef selects the Secret key holding the client secret.
+	ClientSecretRef *corev1.SecretKeySelector `json:"clientSecretRef,omitempty"`
+
+	// CLIClientID is the client ID used by the Argo CD CLI, when it differs from ClientID.
+	CLIClientID string `json:"cliClientID,omitempty"`
+
+	// RequestedScopes are the scopes requested from the provider. Argo CD requests openid, profile, email and groups by default.
+	RequestedScopes []string `json:"requestedScopes,omitempty"`
+
+	// RequestedIDTokenClaims are the claims requested in the ID token, keyed by claim name.
+	RequestedIDTokenClaims map[string]ArgoCDOIDCClaim `json:"requestedIDTokenClaims,omitempty"`
+
+	// RootCA is the PEM encoded certificate of the CA that signed the certificate of the provider.
+	RootCA *ArgoCDOIDCRootCASource `json:"rootCA,omitempty"`
+
+	// LogoutURL is the URL Argo CD redirects to on logout, to end the session with the provider.
+	LogoutURL string `json:"logoutURL,omitempty"`
+
+	// EnablePKCEAuthentication enables PKCE for the authorization code flow.
+	EnablePKCEAuthentication bool `json:"enablePKCEAuthentication,omitempty"`
+}
+
+// ArgoCDOIDCClaim is a claim requested in the ID token.
+type ArgoCDOIDCClaim struct {
+	// Essential marks the claim as required.
+	Essential bool `json:"essential,omitempty"`
+
+	// Value is the requested value of the claim.
+	Value string `json:"value,omitempty"`
+
+	// Values are the requested values of the claim.
+	Values []string `json:"values,omitempty"`
+}
+
+// ArgoCDOIDCRootCASource selects the key of a ConfigMap or a Secret holding a CA certificate.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef and secretKeyRef must be set"
+type ArgoCDOIDCRootCASource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap.
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret.
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// ArgoCDDexConnectorType is the type of a typed Dex connector.
+// +kubebuilder:validation:Enum=github;gitlab;ldap;saml;oidc;microsoft
+type ArgoCDDexConnectorType string
+
+const (
+	ArgoCDDexConnectorTypeGitHub    ArgoCDDexConnectorType = "github"
+	ArgoCDDexConnectorTypeGitLab    ArgoCDDexConnectorType = "gitlab"
+	ArgoCDDexConnectorTypeLDAP      ArgoCDDexConnectorType = "ldap"
+	ArgoCDDexConnectorTypeSAML      ArgoCDDexConnectorType = "saml"
+	ArgoCDDexConnectorTypeOIDC      ArgoCDDexConnectorType = "oidc"
+	ArgoCDDexConnectorTypeMicrosoft ArgoCDDexConnectorType = "microsoft"
+)
+
+// ArgoCDDexConnector is a Dex connector. The configuration block matching Type must be set.
+// +kubebuilder:validation:XValidation:rule="self.type != 'github' || has(self.github)",message="github must be set when type is github"
+// +kubebuilder:validation:XValidation:rule="self.type != 'gitlab' || has(self.gitlab)",message="gitlab must be set when type is gitlab"
+// +kubebuilder:validation:XValidation:rule="self.type != 'ldap' || has(self.ldap)",message="ldap must be set when type is ldap"
+// +kubebuilder:validation:XValidation:rule="self.type != 'saml' || has(self.saml)",message="saml must be set when type is saml"
+// +kubebuilder:validation:XValidation:rule="self.type != 'oidc' || has(self.oidc)",message="oidc must be set when type is oidc"
+// +kubebuilder:validation:XValidation:rule="self.type != 'microsoft' || has(self.microsoft)",message="microsoft must be set when type is microsoft"
+type ArgoCDDexConnector struct {
+	// ID of the connector, unique among the connectors of the Argo CD instance.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	ID string `json:"id"`
+
+	// Name of the connector, displayed on the login page.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Type of the connector.
+	// +kubebuilder:validation:Required
+	Type ArgoCDDexConnectorType `json:"type"`
+
+	// GitHub is the configuration of a github connector.
+	GitHub *ArgoCDDexGitHubConnector `json:"github,omitempty"`
+
+	// GitLab is the configuration of a gitlab connector.
+	GitLab *ArgoCDDexGitLabConnector `json:"gitlab,omitempty"`
+
+	// LDAP is the configuration of an ldap connector.
+	LDAP *ArgoCDDexLDAPConnector `json:"ldap,omitempty"`
+
+	// SAML is the configuration of a saml connector.
+	SAML *ArgoCDDexSAMLConnector `json:"saml,omitempty"`
+
+	// OIDC is the configuration of an oidc connector.
+	OIDC *ArgoCDDexOIDCConnector `json:"oidc,omitempty"`
+
+	// Microsoft is the configuration of a microsoft connector.
+	Microsoft *ArgoCDDexMicrosoftConnector `json:"microsoft,omitempty"`
+}
+
+// ArgoCDDexGitHubConnector is the configuration of a Dex github connector.
+type ArgoCDDexGitHubConnector struct {
+	// ClientID of the GitHub OAuth application.
+	// +kubebuilder:validation:Required
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef selects the Secret key holding the client secret of the GitHub OAuth application.
+	// +kubebuilder:validation:Required
+	ClientSecretRef corev1.SecretKeySelector `json:"clientSecretRef"`
+
+	// Orgs restricts login to members of these organizations, optionally of some of their teams.
+	Orgs []ArgoCDDexGitHubOrg `json:"orgs,omitempty"`
+
+	// HostName of a GitHub Enterprise instance.
+	HostName string `json:"hostName,omitempty"`
+
+	// LoadAllGroups loads all the organizations and teams of the user as groups.
+	LoadAllGroups bool `json:"loadAllGroups,omitempty"`
+}
+
+// ArgoCDDexGitHubOrg is a GitHub organization, optionally restricted to some of its teams.
+type ArgoCDDexGitHubOrg struct {
+	// Name of the organization.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Teams of the organization.
+	Teams []string `json:"teams,omitempty"`
+}
+
+// ArgoCDDexGitLabConnector is the configuration of a Dex gitlab connector.
+type ArgoCDDexGitLabConnector struct {
+	// BaseURL of the GitLab instance. Defaults to https://gitlab.com.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// ClientID of the GitLab application.
+	// +kubebuilder:validation:Required
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef selects the Secret key holding the secret of the GitLab application.
+	// +kubebuilder:validation:Required
+	ClientSecretRef corev1.SecretKeySelector `json:"clientSecretRef"`
+
+	// Groups restricts login to members of these groups.
+	Groups []string `json:"groups,omitempty"`
+
+	// UseLoginAsID uses the username instead of the numeric user ID as the user ID.
+	UseLoginAsID bool `json:"useLoginAsID,omitempty"`
+}
+
+// ArgoCDDexLDAPConnector is the configuration of a Dex ldap connector.
+type ArgoCDDexLDAPConnector struct {
+	// Host and optional port of the LDAP server.
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+
+	// InsecureNoSSL connects without TLS.
+	InsecureNoSSL bool `json:"insecureNoSSL,omitempty"`
+
+	// InsecureSkipVerify skips the verification of the server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// StartTLS connects without TLS and upgrades the connection with StartTLS.
+	StartTLS bool `json:"startTLS,omitempty"`
+
+	// RootCARef selects the Secret key holding the PEM encoded CA certificate of the LDAP server.
+	RootCARef *corev1.SecretKeySelector `json:"rootCARef,omitempty"`
+
+	// BindDN of the service account used to search users and groups.
+	BindDN string `json:"bindDN,omitempty"`
+
+	// BindPWRef selects the Secret key holding the password of the service account.
+	BindPWRef *corev1.SecretKeySelector `json:"bindPWRef,omitempty"`
+
+	// UsernamePrompt is displayed on the login page instead of "Username".
+	UsernamePrompt string `json:"usernamePrompt,omitempty"`
+
+	// UserSearch configures how users are searched.
+	// +kubebuilder:validation:Required
+	UserSearch ArgoCDDexLDAPUserSearch `json:"userSearch"`
+
+	// GroupSearch configures how the groups of a user are searched.
+	GroupSearch *ArgoCDDexLDAPGroupSearch `json:"groupSearch,omitempty"`
+}
+
+// ArgoCDDexLDAPUserSearch configures the search of LDAP users.
+type ArgoCDDexLDAPUserSearch struct {
+	// BaseDN to start the search from.
+	// +kubebuilder:validation:Required
+	BaseDN string `json:"baseDN"`
+
+	// Filter applied to the search.
+	Filter string `json:"filter,omitempty"`
+
+	// Username is the attribute matched against the username entered on the login page.
+	// +kubebuilder:validation:Required
+	Username string `json:"username"`
+
+	// IDAttr is the attribute used as the user ID.
+	// +kubebuilder:validation:Required
+	IDAttr string `json:"idAttr"`
+
+	// EmailAttr is the attribute used as the email of the user.
+	// +kubebuilder:validation:Required
+	EmailAttr string `json:"emailAttr"`
+
+	// NameAttr is the attribute used as the display name of the user.
+	NameAttr string `json:"nameAttr,omitempty"`
+
+	// PreferredUsernameAttr is the attribute used as the preferred username of the user.
+	PreferredUsernameAttr string `json:"preferredUsernameAttr,omitempty"`
+}
+
+// ArgoCDDexLDAPGroupSearch configures the search of the LDAP groups of a user.
+type ArgoCDDexLDAPGroupSearch struct {
+	// BaseDN to start the search from.
+	// +kubebuilder:validation:Required
+	BaseDN string `json:"baseDN"`
+
+	// Filter applied to the search.
+	Filter string `json:"filter,omitempty"`
+
+	// UserMatchers match the attributes of a user against the attributes of a group.
+	UserMatchers []ArgoCDDexLDAPUserMatcher `json:"userMatchers,omitempty"`
+
+	// NameAttr is the attribute used as the group name.
+	// +kubebuilder:validation:Required
+	NameAttr string `json:"nameAttr"`
+}
+
+// ArgoCDDexLDAPUserMatcher matches a user attribute against a group attribute.
+type ArgoCDDexLDAPUserMatcher struct {
+	// UserAttr is the attribute of the user.
+	// +kubebuilder:validation:Required
+	UserAttr string `json:"userAttr"`
+
+	// GroupAttr is the attribute of the group.
+	// +kubebuilder:validation:Required
+	GroupAttr string `json:"groupAttr"`
+}
+
+// ArgoCDDexSAMLConnector is the configuration of a Dex saml connector.
+type ArgoCDDexSAMLConnector struct {
+	// SSOURL of the identity provider.
+	// +kubebuilder:validation:Required
+	SSOURL string `json:"ssoURL"`
+
+	// CARef selects the Secret key holding the PEM encoded CA certificate used to validate the signature of
+	// the SAML responses.
+	// +kubebuilder:validation:Required
+	CARef corev1.SecretKeySelector `json:"caRef"`
+
+	// EntityIssuer is the issuer of the SAML requests.
+	EntityIssuer string `json:"entityIssuer,omitempty"`
+
+	// SSOIssuer is the expected issuer of the SAML responses.
+	SSOIssuer string `json:"ssoIssuer,omitempty"`
+
+	// UsernameAttr is the attribute used as the username.
+	// +kubebuilder:validation:Required
+	UsernameAttr string `json:"usernameAttr"`
+
+	// EmailAttr is the attribute used as the email.
+	// +kubebuilder:validation:Required
+	EmailAttr string `json:"emailAttr"`
+
+	// GroupsAttr is the attribute used as the groups.
+	GroupsAttr string `json:"groupsAttr,omitempty"`
+
+	// NameIDPolicyFormat is the format of the NameID requested from the identity provider.
+	NameIDPolicyFormat string `json:"nameIDPolicyFormat,omitempty"`
+}
+
+// ArgoCDDexOIDCConnector is the configuration of a Dex oidc connector.
+type ArgoCDDexOIDCConnector struct {
+	// Issuer URL of the OIDC provider.
+	// +kubebuilder:validation:Required
+	Issuer string `json:"issuer"`
+
+	// ClientID of the OIDC client.
+	// +kubebuilder:validation:Required
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef selects the Secret key holding the secret of the OIDC client.
+	// +kubebuilder:validation:Required
+	ClientSecretRef corev1.SecretKeySelector `json:"clientSecretRef"`
+
+	// Scopes requested in addition to openid.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// GetUserInfo queries the userinfo endpoint for additional claims.
+	GetUserInfo bool `json:"getUserInfo,omitempty"`
+
+	// InsecureSkipEmailVerified accepts users whose email is not verified.
+	InsecureSkipEmailVerified bool `json:"insecureSkipEmailVerified,omitempty"`
+
+	// InsecureEnableGroups reads the groups of the user from the groups claim.
+	InsecureEnableGroups bool `json:"insecureEnableGroups,omitempty"`
+
+	// UserIDKey is the claim used as the user ID.
+	UserIDKey string `json:"userIDKey,omitempty"`
+
+	// UserNameKey is the claim used as the username.
+	UserNameKey string `json:"userNameKey,omitempty"`
+}
+
+// ArgoCDDexMicrosoftConnector is the configuration of a Dex microsoft connector.
+type ArgoCDDexMicrosoftConnector struct {
+	// ClientID of the Microsoft Entra application.
+	// +kubebuilder:validation:Required
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef selects the Secret key holding the secret of the Microsoft Entra application.
+	// +kubebuilder:validation:Required
+	ClientSecretRef corev1.SecretKeySelector `json:"clientSecretRef"`
+
+	// Tenant restricts login to a tenant, either its ID or one of common, consumers or organizations.
+	Tenant string `json:"tenant,omitempty"`
+
+	// Groups restricts login to members of these groups.
+	Groups []string `json:"groups,omitempty"`
+
+	// OnlySecurityGroups only loads the security groups of the user.
+	OnlySecurityGroups bool `json:"onlySecurityGroups,omitempty"`
+}
+
 // ArgoCDGrafanaSpec defines the desired state for the Grafana component.
 type ArgoCDGrafanaSpec struct {
 	// Enabled will toggle Grafana support globally for ArgoCD.
@@ -525,9 +881,76 @@ type ArgoCDRBACSpec struct {
 	// PolicyMatcherMode configures the matchers function mode for casbin.
 	// There are two options for this, 'glob' for glob matcher or 'regex' for regex matcher.
 	PolicyMatcherMode *string `json:"policyMatcherMode,omitempty"`
+
+	// Roles is a structured alternative to Policy. Each role is rendered into policy lines for the role and
+	// group bindings, and appended to Policy in the policy.csv key.
+	// +listType=map
+	// +listMapKey=name
+	Roles []ArgoCDRBACRole `json:"roles,omitempty"`
+
+	// PolicyOverlays adds policy.<name>.csv keys to argocd-rbac-cm, sourced from ConfigMaps in the
+	// namespace of the Argo CD instance. This allows teams to own parts of the RBAC policy.
+	// +listType=map
+	// +listMapKey=name
+	PolicyOverlays []ArgoCDRBACPolicyOverlay `json:"policyOverlays,omitempty"`
+}
+
+// ArgoCDRBACRole is a role rendered into the Argo CD RBAC policy.
+type ArgoCDRBACRole struct {
+	// Name of the role. The role is referenced as role:<name> in the policy.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([-_.a-zA-Z0-9]*[a-zA-Z0-9])?$`
+	Name string `json:"name"`
+
+	// Permissions granted to or denied for the role.
+	Permissions []ArgoCDRBACPermission `json:"permissions,omitempty"`
+
+	// Groups are the SSO groups, or users, bound to the role.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ArgoCDRBACPermission is a single policy line of a role.
+type ArgoCDRBACPermission struct {
+	// Resource is the Argo CD resource, for example applications, clusters or repositories.
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+
+	// Action is the action on the resource, for example get, sync or *.
+	// +kubebuilder:validation:Required
+	Action string `json:"action"`
+
+	// Object is the object the permission applies to, for example <project>/<application>. Defaults to *.
+	Object string `json:"object,omitempty"`
+
+	// Effect is either allow or deny. Defaults to allow.
+	// +kubebuilder:validation:Enum=allow;deny
+	Effect string `json:"effect,omitempty"`
+}
+
+// ArgoCDRBACPolicyOverlay is an additional policy CSV sourced from a ConfigMap.
+type ArgoCDRBACPolicyOverlay struct {
+	// Name of the overlay. The policy is written to the policy.<name>.csv key of argocd-rbac-cm.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([-_a-zA-Z0-9]*[a-zA-Z0-9])?$`
+	Name string `json:"name"`
+
+	// ConfigMapRef references the ConfigMap key holding the policy CSV.
+	// +kubebuilder:validation:Required
+	ConfigMapRef ArgoCDRBACPolicyConfigMapRef `json:"configMapRef"`
+}
+
+// ArgoCDRBACPolicyConfigMapRef references a key of a ConfigMap in the namespace of the Argo CD instance.
+type ArgoCDRBACPolicyConfigMapRef struct {
+	// Name of the ConfigMap.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key of the ConfigMap holding the policy CSV. Defaults to policy.csv.
+	Key string `json:"key,omitempty"`
 }
 
 // ArgoCDRedisSpec defines the desired state for the Redis server component.
+// +kubebuilder:validation:XValidation:rule="!(has(self.remote) && has(self.external))",message="remote and external cannot both be set"
 type ArgoCDRedisSpec struct {
 	// Image is the Redis container image.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Redis","urn:alm:descriptor:com.tectonic.ui:text"}
@@ -537,6 +960,9 @@ type ArgoCDRedisSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Redis","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// VPA defines the VerticalPodAutoscaler options for the Redis.
+	VPA *ArgoCDVPASpec `json:"vpa,omitempty"`
+
 	// Version is the Redis container image tag.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Redis","urn:alm:descriptor:com.tectonic.ui:text"}
 	Version string `json:"version,omitempty"`
@@ -555,6 +981,13 @@ type ArgoCDRedisSpec struct {
 	// Remote specifies the remote URL of the Redis container. (optional, by default, a local instance managed by the operator is used.)
 	Remote *string `json:"remote,omitempty"`
 
+	// External configures the connection to a Redis instance that is not managed by the operator, such as a
+	// managed cloud Redis. It is an alternative to Remote supporting authentication and TLS.
+	External *ArgoCDRedisExternalSpec `json:"external,omitempty"`
+
+	// Auth configures the password of the Redis instance managed by the operator.
+	Auth *ArgoCDRedisAuthSpec `json:"auth,omitempty"`
+
 	// Custom annotations to pods deployed by the operator
 	Annotations map[string]string `json:"annotations,omitempty"`
 
@@ -562,12 +995,71 @@ type ArgoCDRedisSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// ArgoCDRedisAuthSpec defines the password management of the Redis instance managed by the operator.
+type ArgoCDRedisAuthSpec struct {
+	// RotationInterval is the interval at which the Redis password is rotated, e.g. 720h. The password is not
+	// rotated periodically when unset. A rotation can also be requested with the
+	// argocds.argoproj.io/rotate-redis-password annotation on the ArgoCD.
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+}
+
+// ArgoCDRedisExternalSpec defines the connection to a Redis instance that is not managed by the operator.
+// +kubebuilder:validation:XValidation:rule="has(self.address) != has(self.sentinel)",message="exactly one of address and sentinel must be set"
+type ArgoCDRedisExternalSpec struct {
+	// Address is the host:port of the Redis server.
+	Address string `json:"address,omitempty"`
+
+	// Sentinel configures the discovery of the Redis server through Redis Sentinel.
+	Sentinel *ArgoCDRedisSentinelSpec `json:"sentinel,omitempty"`
+
+	// Username is the Redis ACL user used to authenticate. The default user is used when not set.
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references the Secret key holding the Redis password.
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// TLS configures TLS for the connection to Redis. TLS is not used when not set.
+	TLS *ArgoCDRedisExternalTLSSpec `json:"tls,omitempty"`
+}
+
+// ArgoCDRedisSentinelSpec defines the Redis Sentinel endpoints used to discover the Redis server.
+type ArgoCDRedisSentinelSpec struct {
+	// Addresses is the list of host:port of the Sentinel endpoints.
+	// +kubebuilder:validation:MinItems=1
+	Addresses []string `json:"addresses"`
+
+	// MasterName is the name of the Sentinel master group. Defaults to `master`.
+	MasterName string `json:"masterName,omitempty"`
+
+	// Username is the ACL user used to authenticate with Sentinel.
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references the Secret key holding the Sentinel password.
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// ArgoCDRedisExternalTLSSpec defines the TLS configuration of the connection to an external Redis.
+type ArgoCDRedisExternalTLSSpec struct {
+	// CASecretRef references the Secret key holding the CA bundle used to verify the Redis server certificate.
+	// The system trusted CAs are used when not set.
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+
+	// ClientCertificateSecretName is the name of a kubernetes.io/tls Secret holding the client certificate
+	// and key presented to Redis.
+	ClientCertificateSecretName string `json:"clientCertificateSecretName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the Redis server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
 func (a *ArgoCDRedisSpec) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
 
+// IsRemote returns true when Redis is not managed by the operator, either through Remote or External.
 func (a *ArgoCDRedisSpec) IsRemote() bool {
-	return a.Remote != nil && *a.Remote != ""
+	return (a.Remote != nil && *a.Remote != "") || a.External != nil
 }
 
 // ArgoCDRepoSpec defines the desired state for the Argo CD repo server component.
@@ -587,13 +1079,19 @@ type ArgoCDRepoSpec struct {
 	// MountSAToken describes whether you would like to have the Repo server mount the service account token
 	MountSAToken bool `json:"mountsatoken,omitempty"`
 
-	// Replicas defines the number of replicas for argocd-repo-server. Value should be greater than or equal to 0. Default is nil.
+	// Replicas defines the number of replicas for argocd-repo-server. Value should be greater than or equal to 0. Default is nil. Value will be ignored if Autoscaler is enabled.
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Autoscale defines the autoscale options for the Argo CD Repo Server component.
+	Autoscale *ArgoCDAutoscaleSpec `json:"autoscale,omitempty"`
+
 	// Resources defines the Compute Resources required by the container for Redis.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Repo","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// VPA defines the VerticalPodAutoscaler options for the Argo CD Repo Server.
+	VPA *ArgoCDVPASpec `json:"vpa,omitempty"`
+
 	// ServiceAccount defines the ServiceAccount user that you would like the Repo server to use
 	ServiceAccount string `json:"serviceaccount,omitempty"`
 
@@ -631,6 +1129,11 @@ type ArgoCDRepoSpec struct {
 	// the image for the repo server will be used.
 	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 
+	// Plugins defines the Config Management Plugins run as sidecar containers of the repo server.
+	// +listType=map
+	// +listMapKey=name
+	Plugins []ArgoCDRepoPlugin `json:"plugins,omitempty"`
+
 	// Enabled is the flag to enable Repo Server during ArgoCD installation. (optional, default `true`)
 	Enabled *bool `json:"enabled,omitempty"`
 
@@ -650,6 +1153,30 @@ type ArgoCDRepoSpec struct {
 	Metrics *ArgoCDMetricsSpec `json:"metrics,omitempty"`
 }
 
+// ArgoCDRepoPlugin defines a Config Management Plugin run as a sidecar container of the repo server.
+// +kubebuilder:validation:XValidation:rule="has(self.configuration) != has(self.configMapRef)",message="exactly one of configuration and configMapRef must be set"
+type ArgoCDRepoPlugin struct {
+	// Name of the plugin, used as the name of the sidecar container and in the names of its volumes.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=52
+	Name string `json:"name"`
+
+	// Image of the plugin sidecar container. Defaults to the repo server image.
+	Image string `json:"image,omitempty"`
+
+	// Configuration is the content of the plugin.yaml file of the plugin.
+	Configuration string `json:"configuration,omitempty"`
+
+	// ConfigMapRef references the ConfigMap key holding the plugin.yaml file of the plugin.
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+
+	// Resources defines the Compute Resources required by the plugin sidecar container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env lets you specify environment variables for the plugin sidecar container.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
 func (a *ArgoCDRepoSpec) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
@@ -702,6 +1229,89 @@ type ArgoCDServerAutoscaleSpec struct {
 	HPA *autoscaling.HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
 }
 
+// ArgoCDAutoscaleSpec defines the desired state for autoscaling an Argo CD component.
+type ArgoCDAutoscaleSpec struct {
+	// Enabled will toggle autoscaling support for the component.
+	Enabled bool `json:"enabled"`
+
+	// HPA defines the HorizontalPodAutoscaler options for the component. The scale target is always the
+	// Deployment of the component.
+	HPA *autoscaling.HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
+
+	// KEDA defines a KEDA ScaledObject to generate instead of the HorizontalPodAutoscaler. It is only used when the
+	// KEDA API is available in the cluster, the HorizontalPodAutoscaler is used otherwise.
+	KEDA *ArgoCDKEDASpec `json:"keda,omitempty"`
+}
+
+// IsEnabled returns whether autoscaling is enabled.
+func (a *ArgoCDAutoscaleSpec) IsEnabled() bool {
+	return a != nil && a.Enabled
+}
+
+// ArgoCDKEDASpec defines the options of a KEDA ScaledObject.
+type ArgoCDKEDASpec struct {
+	// MinReplicaCount is the minimum number of replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+
+	// MaxReplicaCount is the maximum number of replicas. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+
+	// PollingInterval is the interval in seconds at which the triggers are checked.
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// CooldownPeriod is the period in seconds to wait after the last trigger was active before scaling to
+	// minReplicaCount.
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+
+	// Triggers are the KEDA triggers activating the scaling.
+	// +kubebuilder:validation:MinItems=1
+	Triggers []ArgoCDKEDATrigger `json:"triggers"`
+}
+
+// ArgoCDKEDATrigger defines a trigger of a KEDA ScaledObject.
+type ArgoCDKEDATrigger struct {
+	// Type is the type of the KEDA scaler, e.g. cpu or prometheus.
+	Type string `json:"type"`
+
+	// Name is an optional name of the trigger.
+	Name string `json:"name,omitempty"`
+
+	// MetricType is the metric target type, one of AverageValue, Value and Utilization.
+	// +kubebuilder:validation:Enum=AverageValue;Value;Utilization
+	MetricType string `json:"metricType,omitempty"`
+
+	// Metadata holds the scaler specific configuration.
+	Metadata map[string]string `json:"metadata"`
+
+	// AuthenticationRef is the name of a TriggerAuthentication in the namespace of the ArgoCD.
+	AuthenticationRef string `json:"authenticationRef,omitempty"`
+}
+
+// ArgoCDVPASpec defines the VerticalPodAutoscaler options for an Argo CD component.
+type ArgoCDVPASpec struct {
+	// Enabled will create a VerticalPodAutoscaler for the component when the VerticalPodAutoscaler API is available.
+	Enabled bool `json:"enabled"`
+
+	// UpdateMode is the update mode of the VerticalPodAutoscaler. In the Off mode, the resources are only
+	// recommended and reported in the ArgoCD status. In the other modes, the recommendations are applied to the pods.
+	// +kubebuilder:validation:Enum=Off;Initial;Recreate;InPlaceOrRecreate;Auto
+	// +kubebuilder:default=Off
+	UpdateMode string `json:"updateMode,omitempty"`
+
+	// MinAllowed is the lower limit of the resources recommended for each container of the component.
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// MaxAllowed is the upper limit of the resources recommended for each container of the component.
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// IsEnabled returns whether a VerticalPodAutoscaler is requested.
+func (v *ArgoCDVPASpec) IsEnabled() bool {
+	return v != nil && v.Enabled
+}
+
 // ArgoCDServerGRPCSpec defines the desired state for the Argo CD Server GRPC options.
 type ArgoCDServerGRPCSpec struct {
 	// Host is the hostname to use for Ingress/Route resources.
@@ -751,6 +1361,9 @@ type ArgoCDServerSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// VPA defines the VerticalPodAutoscaler options for the Argo CD Server.
+	VPA *ArgoCDVPASpec `json:"vpa,omitempty"`
+
 	// Route defines the desired state for an OpenShift Route for the Argo CD Server component.
 	Route ArgoCDRouteSpec `json:"route,omitempty"`
 
@@ -928,6 +1541,13 @@ func (a *ArgoCDNetworkPolicySpec) IsEnabled() bool {
 // ArgoCDSpec defines the desired state of ArgoCD
 // +k8s:openapi-gen=true
 // +kubebuilder:validation:XValidation:rule="!(has(self.sso) && has(self.oidcConfig))",message="spec.sso and spec.oidcConfig cannot both be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.sso) && has(self.oidc))",message="spec.sso and spec.oidc cannot both be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.oidc) && has(self.oidcConfig))",message="spec.oidc and spec.oidcConfig cannot both be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.redis) && has(self.redis.remote) && has(self.redis.external))",message="spec.redis.remote and spec.redis.external cannot both be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.argoCDAgent) && has(self.argoCDAgent.agent) && has(self.argoCDAgent.agent.installProfile) && self.argoCDAgent.agent.installProfile == 'agent-only' && has(self.controller) && has(self.controller.enabled) && !self.controller.enabled)",message="spec.controller cannot be disabled with the agent-only install profile"
+// +kubebuilder:validation:XValidation:rule="!(has(self.argoCDAgent) && has(self.argoCDAgent.agent) && has(self.argoCDAgent.agent.installProfile) && self.argoCDAgent.agent.installProfile == 'agent-only' && has(self.repo) && has(self.repo.enabled) && !self.repo.enabled && !has(self.repo.remote))",message="spec.repo cannot be disabled without spec.repo.remote with the agent-only install profile"
+// +kubebuilder:validation:XValidation:rule="!(has(self.argoCDAgent) && has(self.argoCDAgent.agent) && has(self.argoCDAgent.agent.installProfile) && self.argoCDAgent.agent.installProfile == 'agent-only' && has(self.redis) && has(self.redis.enabled) && !self.redis.enabled && !has(self.redis.remote) && !has(self.redis.external))",message="spec.redis cannot be disabled without spec.redis.remote or spec.redis.external with the agent-only install profile"
+// +kubebuilder:validation:XValidation:rule="!(has(self.argoCDAgent) && has(self.argoCDAgent.agent) && has(self.argoCDAgent.agent.installProfile) && self.argoCDAgent.agent.installProfile == 'agent-only' && (!has(self.argoCDAgent.agent.client) || !has(self.argoCDAgent.agent.client.mode) || self.argoCDAgent.agent.client.mode == 'managed') && has(self.applicationSet) && (!has(self.applicationSet.enabled) || self.applicationSet.enabled))",message="spec.applicationSet cannot be enabled with the agent-only install profile in managed mode, ApplicationSets are reconciled by the principal"
 type ArgoCDSpec struct {
 
 	// ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
@@ -1018,9 +1638,20 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Config",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	OIDCConfig string `json:"oidcConfig,omitempty"`
 
+	// OIDC is a typed OIDC configuration, as an alternative to OIDCConfig. It is validated by the operator and
+	// rendered into the oidc.config property of argocd-cm, with its client secret and root CA wired through argocd-secret.
+	OIDC *ArgoCDOIDCSpec `json:"oidc,omitempty"`
+
 	// Monitoring defines whether workload status monitoring configuration for this instance.
 	Monitoring ArgoCDMonitoringSpec `json:"monitoring,omitempty"`
 
+	// MutationWebhooks are HTTP(S) endpoints called, in order, with the workloads of the components and the Dex Service.
+	// Each returns a JSON patch applied to the resource before it is created or updated. RBAC resources and resources
+	// outside of the namespace of the ArgoCD are never sent.
+	// +listType=map
+	// +listMapKey=name
+	MutationWebhooks []ArgoCDMutationWebhookSpec `json:"mutationWebhooks,omitempty"`
+
 	// NetworkPolicy controls whether the operator should create NetworkPolicy resources for this Argo CD instance.
 	NetworkPolicy ArgoCDNetworkPolicySpec `json:"networkPolicy,omitempty"`
 
@@ -1030,6 +1661,21 @@ type ArgoCDSpec struct {
 	// Notifications defines whether the Argo CD Notifications controller should be installed.
 	Notifications ArgoCDNotifications `json:"notifications,omitempty"`
 
+	// Profile is the sizing profile of the Argo CD instance. The small, medium and large profiles apply
+	// operator-shipped defaults to the tuning fields that are not set explicitly. The custom profile applies no defaults.
+	// +kubebuilder:validation:Enum=small;medium;large;custom
+	Profile string `json:"profile,omitempty"`
+
+	// ProfileOverrides turns off the features the sizing profile enables. The booleans of the spec cannot tell false
+	// apart from unset, so they can only turn these features on.
+	ProfileOverrides *ArgoCDProfileOverridesSpec `json:"profileOverrides,omitempty"`
+
+	// Projects is a listing of AppProjects to be created and kept up to date by the operator in the
+	// namespace of the Argo CD instance.
+	// +listType=map
+	// +listMapKey=name
+	Projects []ArgoCDProjectSpec `json:"projects,omitempty"`
+
 	// Prometheus defines the Prometheus server options for ArgoCD.
 	Prometheus ArgoCDPrometheusSpec `json:"prometheus,omitempty"`
 
@@ -1042,9 +1688,20 @@ type ArgoCDSpec struct {
 	// Repo defines the repo server options for Argo CD.
 	Repo ArgoCDRepoSpec `json:"repo,omitempty"`
 
+	// Repositories is a listing of repositories that the operator renders into Argo CD repository Secrets.
+	// +listType=map
+	// +listMapKey=name
+	Repositories []ArgoCDRepositorySpec `json:"repositories,omitempty"`
+
 	// Deprecated: RepositoryCredentials are the Git pull credentials to configure Argo CD with upon creation of the cluster.
 	RepositoryCredentials string `json:"repositoryCredentials,omitempty"`
 
+	// RepositoryCredentialTemplates is a listing of credential templates that the operator renders into Argo CD
+	// repo-creds Secrets. A template applies to every repository whose URL starts with the template URL.
+	// +listType=map
+	// +listMapKey=name
+	RepositoryCredentialTemplates []ArgoCDRepositoryCredentialTemplateSpec `json:"repositoryCredentialTemplates,omitempty"`
+
 	// ResourceHealthChecks customizes resource health check behavior.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Health Check Customizations",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceHealthChecks []ResourceHealthCheck `json:"resourceHealthChecks,omitempty"`
@@ -1085,6 +1742,10 @@ type ArgoCDSpec struct {
 	// TLS defines the TLS options for ArgoCD.
 	TLS ArgoCDTLSSpec `json:"tls,omitempty"`
 
+	// Template is the name of the ArgoCDTemplate providing the defaults of the fields that are not set. The ArgoCDTemplates
+	// selecting the ArgoCD by its labels apply as well, with a lower precedence than the referenced ArgoCDTemplate.
+	Template string `json:"template,omitempty"`
+
 	// UsersAnonymousEnabled toggles anonymous user access.
 	// The anonymous users get default role permissions specified argocd-rbac-cm.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Anonymous Users Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
@@ -1128,6 +1789,45 @@ type ArgoCDSpec struct {
 	WebTerminalEnabled *bool `json:"webTerminalEnabled,omitempty"`
 }
 
+// MutationWebhookFailurePolicy defines how the failure of a mutation webhook is handled.
+// +kubebuilder:validation:Enum=Fail;Ignore
+type MutationWebhookFailurePolicy string
+
+const (
+	// MutationWebhookFailurePolicyFail fails the reconciliation of the ArgoCD when the webhook fails.
+	MutationWebhookFailurePolicyFail MutationWebhookFailurePolicy = "Fail"
+	// MutationWebhookFailurePolicyIgnore leaves the resource unchanged when the webhook fails.
+	MutationWebhookFailurePolicyIgnore MutationWebhookFailurePolicy = "Ignore"
+)
+
+// ArgoCDMutationWebhookSpec defines an HTTP(S) endpoint mutating the resources generated by the operator.
+type ArgoCDMutationWebhookSpec struct {
+	// Name of the webhook, reported in the Events of the mutations it applies.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL the resources are POSTed to. Plain http is only allowed when insecureMutationWebhooksAllowed is set in the
+	// ArgoCDOperatorConfig.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Components restricts the webhook to the resources of the given components, e.g. argocd-application-controller or
+	// argocd-server. The resources of all the components are sent when empty.
+	Components []string `json:"components,omitempty"`
+
+	// TimeoutSeconds is how long the operator waits for the webhook to respond. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy defines how the failure of the webhook is handled, either Fail or Ignore. Defaults to Fail.
+	FailurePolicy MutationWebhookFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// CABundle is a PEM encoded CA bundle used to verify the certificate of an https webhook. The system roots are
+	// used when empty.
+	CABundle string `json:"caBundle,omitempty"`
+}
+
 // NamespaceManagement defines the namespace management settings
 type ManagedNamespaces struct {
 	// Name of the namespace or pattern to be managed
@@ -1210,6 +1910,182 @@ type WebhookSecretKeySelector struct {
 	Key string `json:"key"`
 }
 
+// ArgoCDProjectSpec defines an AppProject managed through the ArgoCD CR.
+// +k8s:openapi-gen=true
+type ArgoCDProjectSpec struct {
+	// Name of the AppProject.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+
+	// Description of the AppProject.
+	Description string `json:"description,omitempty"`
+
+	// SourceRepos contains the repository URLs (or glob patterns) that applications of the project may deploy from.
+	SourceRepos []string `json:"sourceRepos,omitempty"`
+
+	// Destinations contains the clusters and namespaces that applications of the project may deploy to.
+	Destinations []ArgoCDProjectDestination `json:"destinations,omitempty"`
+
+	// ClusterResourceWhitelist contains the cluster-scoped resources that applications of the project may deploy.
+	ClusterResourceWhitelist []ArgoCDProjectClusterResource `json:"clusterResourceWhitelist,omitempty"`
+
+	// ClusterResourceBlacklist contains the cluster-scoped resources that applications of the project may not deploy.
+	ClusterResourceBlacklist []ArgoCDProjectClusterResource `json:"clusterResourceBlacklist,omitempty"`
+
+	// Roles are the project roles, with their policies and the SSO groups bound to them.
+	Roles []ArgoCDProjectRole `json:"roles,omitempty"`
+
+	// SyncWindows control when applications of the project may be synced.
+	SyncWindows []ArgoCDProjectSyncWindow `json:"syncWindows,omitempty"`
+}
+
+// ArgoCDProjectDestination is a cluster and namespace that applications of a project may deploy to.
+// +k8s:openapi-gen=true
+type ArgoCDProjectDestination struct {
+	// Server is the URL of the destination cluster. Either Server or Name must be set.
+	Server string `json:"server,omitempty"`
+	// Name is the name of the destination cluster. Either Server or Name must be set.
+	Name string `json:"name,omitempty"`
+	// Namespace is the destination namespace. Glob patterns are supported.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ArgoCDProjectClusterResource identifies cluster-scoped resources by group and kind, and optionally name.
+// +k8s:openapi-gen=true
+type ArgoCDProjectClusterResource struct {
+	// Group of the resource. Use "*" to match all groups.
+	Group string `json:"group"`
+	// Kind of the resource. Use "*" to match all kinds.
+	Kind string `json:"kind"`
+	// Name of the resource. Glob patterns are supported. When empty, all resources of the kind match.
+	Name string `json:"name,omitempty"`
+}
+
+// ArgoCDProjectRole is a role of an AppProject.
+// +k8s:openapi-gen=true
+type ArgoCDProjectRole struct {
+	// Name of the role.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Description of the role.
+	Description string `json:"description,omitempty"`
+	// Policies are the Casbin policy lines granted to the role.
+	Policies []string `json:"policies,omitempty"`
+	// Groups are the OIDC groups bound to the role.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ArgoCDProjectSyncWindow is a time window during which syncs of a project's applications are allowed or denied.
+// +k8s:openapi-gen=true
+type ArgoCDProjectSyncWindow struct {
+	// Kind is either allow or deny.
+	// +kubebuilder:validation:Enum=allow;deny
+	Kind string `json:"kind"`
+	// Schedule is the cron schedule at which the window opens.
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open, for example 1h.
+	Duration string `json:"duration"`
+	// Applications the window applies to. Glob patterns are supported.
+	Applications []string `json:"applications,omitempty"`
+	// Namespaces the window applies to. Glob patterns are supported.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Clusters the window applies to. Glob patterns are supported.
+	Clusters []string `json:"clusters,omitempty"`
+	// ManualSync allows manual syncs while the window is active.
+	ManualSync bool `json:"manualSync,omitempty"`
+	// TimeZone of the schedule, for example Europe/Berlin. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ArgoCDRepositoryType is the type of a repository known to Argo CD.
+// +kubebuilder:validation:Enum=git;helm;oci
+type ArgoCDRepositoryType string
+
+const (
+	ArgoCDRepositoryTypeGit  ArgoCDRepositoryType = "git"
+	ArgoCDRepositoryTypeHelm ArgoCDRepositoryType = "helm"
+	ArgoCDRepositoryTypeOCI  ArgoCDRepositoryType = "oci"
+)
+
+// ArgoCDRepositorySpec defines a repository to be registered with Argo CD.
+// +k8s:openapi-gen=true
+type ArgoCDRepositorySpec struct {
+	// Name uniquely identifies the repository within the instance. It is used to derive the name of the generated Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// URL of the repository.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// Type of the repository. One of git, helm or oci. Defaults to git.
+	Type ArgoCDRepositoryType `json:"type,omitempty"`
+
+	// Project restricts the repository to the given AppProject. When empty, the repository is global.
+	Project string `json:"project,omitempty"`
+
+	// Proxy is the HTTP/HTTPS proxy used to access the repository.
+	Proxy string `json:"proxy,omitempty"`
+
+	// Insecure skips verification of the repository's TLS certificate or SSH host key.
+	Insecure bool `json:"insecure,omitempty"`
+
+	// CredentialsSecretRef references a Secret in the Argo CD namespace whose keys (for example username, password
+	// or sshPrivateKey) are copied into the generated repository Secret.
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// ArgoCDRepositoryCredentialTemplateSpec defines credentials that Argo CD applies to all repositories matching a URL prefix.
+// +k8s:openapi-gen=true
+type ArgoCDRepositoryCredentialTemplateSpec struct {
+	// Name uniquely identifies the credential template within the instance. It is used to derive the name of the generated Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// URL is the prefix matched against repository URLs.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// Type of the repositories the template applies to. One of git, helm or oci. Defaults to git.
+	Type ArgoCDRepositoryType `json:"type,omitempty"`
+
+	// Proxy is the HTTP/HTTPS proxy used to access matching repositories.
+	Proxy string `json:"proxy,omitempty"`
+
+	// CredentialsSecretRef references a Secret in the Argo CD namespace whose keys are copied into the generated
+	// repo-creds Secret.
+	// +kubebuilder:validation:Required
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+const (
+	// ArgoCDRepositoryPhaseSynced indicates that the generated Secret matches the declared entry.
+	ArgoCDRepositoryPhaseSynced = "Synced"
+	// ArgoCDRepositoryPhaseFailed indicates that the entry could not be rendered, see the status message.
+	ArgoCDRepositoryPhaseFailed = "Failed"
+)
+
+// ArgoCDRepositoryStatus reports the result of rendering a single repository or credential template entry.
+// +k8s:openapi-gen=true
+type ArgoCDRepositoryStatus struct {
+	// Name of the entry in spec.
+	Name string `json:"name"`
+	// SecretName is the name of the Secret generated for the entry.
+	SecretName string `json:"secretName,omitempty"`
+	// Phase is either Synced or Failed.
+	Phase string `json:"phase"`
+	// Message explains why the entry could not be rendered.
+	Message string `json:"message,omitempty"`
+}
+
 const OpenShiftOAuthErrorMessage = "OpenShiftOAuth is not supported when external authentication is enabled on cluster, please provide OIDC config"
 const (
 	ArgoCDConditionType               = "Reconciled"
@@ -1222,6 +2098,101 @@ const (
 	ArgoCDConditionReasonErrorOccurred = "ErrorOccurred"
 )
 
+const (
+	// ArgoCDConditionOIDCConfigValid reports whether the OIDC configuration declared in spec.oidc passed validation.
+	ArgoCDConditionOIDCConfigValid = "OIDCConfigValid"
+
+	// ArgoCDConditionReasonInvalidOIDCConfig is set when the OIDC configuration failed validation and was not written.
+	ArgoCDConditionReasonInvalidOIDCConfig = "InvalidOIDCConfig"
+)
+
+const (
+	// ArgoCDConditionRepoPluginsValid reports whether the Config Management Plugins declared in spec.repo.plugins passed validation.
+	ArgoCDConditionRepoPluginsValid = "RepoPluginsValid"
+
+	// ArgoCDConditionReasonInvalidRepoPlugins is set when the repo plugins failed validation and the repo server was not updated.
+	ArgoCDConditionReasonInvalidRepoPlugins = "InvalidRepoPlugins"
+)
+
+const (
+	// ArgoCDConditionTemplatesValid reports whether the ArgoCDTemplates that may apply to the ArgoCD could all be applied.
+	ArgoCDConditionTemplatesValid = "TemplatesValid"
+
+	// ArgoCDConditionReasonInvalidTemplates is set when the referenced ArgoCDTemplate does not exist or the selector of
+	// an ArgoCDTemplate is invalid, and the template was skipped.
+	ArgoCDConditionReasonInvalidTemplates = "InvalidTemplates"
+)
+
+const (
+	// ArgoCDConditionVerticalPodAutoscalersValid reports whether the VerticalPodAutoscalers requested for the
+	// components can be combined with the autoscaling of their replicas.
+	ArgoCDConditionVerticalPodAutoscalersValid = "VerticalPodAutoscalersValid"
+
+	// ArgoCDConditionReasonConflictingAutoscalers is set when a VerticalPodAutoscaler applying its recommendations
+	// targets a component scaled on CPU or memory, and was not created.
+	ArgoCDConditionReasonConflictingAutoscalers = "ConflictingAutoscalers"
+)
+
+const (
+	// ArgoCDConditionRedisConfigValid reports whether the external Redis declared in spec.redis.external can be used by
+	// the Argo CD components.
+	ArgoCDConditionRedisConfigValid = "RedisConfigValid"
+
+	// ArgoCDConditionReasonInvalidRedisConfig is set when the external Redis cannot be used by a component, which was
+	// not updated.
+	ArgoCDConditionReasonInvalidRedisConfig = "InvalidRedisConfig"
+)
+
+const (
+	// ArgoCDConditionAgentNamespacePoliciesValid reports whether the namespace policies declared in
+	// spec.argoCDAgent.principal.namespace.policies passed validation.
+	ArgoCDConditionAgentNamespacePoliciesValid = "AgentNamespacePoliciesValid"
+
+	// ArgoCDConditionReasonInvalidAgentNamespacePolicies is set when the namespace policies failed validation and the
+	// namespaces served by the principal were not changed.
+	ArgoCDConditionReasonInvalidAgentNamespacePolicies = "InvalidAgentNamespacePolicies"
+)
+
+const (
+	// ArgoCDConditionRBACPolicyValid reports whether the RBAC policy declared in spec.rbac passed validation.
+	ArgoCDConditionRBACPolicyValid = "RBACPolicyValid"
+
+	// ArgoCDConditionReasonInvalidRBACPolicy is set when the RBAC policy failed validation and was not written.
+	ArgoCDConditionReasonInvalidRBACPolicy = "InvalidRBACPolicy"
+)
+
+const (
+	// ArgoCDConditionAgentsDegraded reports whether agents registered with the Argo CD Agent principal are disconnected.
+	ArgoCDConditionAgentsDegraded = "AgentsDegraded"
+
+	// ArgoCDConditionReasonAgentsDisconnected is set when at least one agent is not connected to the principal.
+	ArgoCDConditionReasonAgentsDisconnected = "AgentsDisconnected"
+
+	// ArgoCDConditionReasonAgentVersionSkew is set when all the agents are connected and at least one of them runs a
+	// minor version other than the one of the principal.
+	ArgoCDConditionReasonAgentVersionSkew = "AgentVersionSkew"
+
+	// ArgoCDConditionReasonAgentsHealthy is set when all the agents are connected.
+	ArgoCDConditionReasonAgentsHealthy = "AgentsHealthy"
+
+	// ArgoCDConditionReasonAgentMetricsUnavailable is set when the connections of the agents could not be read from the
+	// principal.
+	ArgoCDConditionReasonAgentMetricsUnavailable = "AgentMetricsUnavailable"
+)
+
+const (
+	// ArgoCDConditionDeprecatedFieldsInUse reports the deprecated fields set by the ArgoCD.
+	ArgoCDConditionDeprecatedFieldsInUse = "DeprecatedFieldsInUse"
+
+	// ArgoCDConditionReasonMigrationAvailable is set when all the deprecated fields in use can be rewritten into their
+	// replacements by the operator.
+	ArgoCDConditionReasonMigrationAvailable = "MigrationAvailable"
+
+	// ArgoCDConditionReasonManualMigrationRequired is set when at least one deprecated field in use has no replacement
+	// the operator can rewrite it into.
+	ArgoCDConditionReasonManualMigrationRequired = "ManualMigrationRequired"
+)
+
 // ArgoCDStatus defines the observed state of ArgoCD
 // +k8s:openapi-gen=true
 type ArgoCDStatus struct {
@@ -1306,10 +2277,162 @@ type ArgoCDStatus struct {
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
 
+	// Repositories reports the result of rendering each entry of spec.repositories.
+	Repositories []ArgoCDRepositoryStatus `json:"repositories,omitempty"`
+
+	// RepositoryCredentialTemplates reports the result of rendering each entry of spec.repositoryCredentialTemplates.
+	RepositoryCredentialTemplates []ArgoCDRepositoryStatus `json:"repositoryCredentialTemplates,omitempty"`
+
+	// Profile reports the values resolved from the sizing profile and the explicit fields of the ArgoCD.
+	Profile *ArgoCDProfileStatus `json:"profile,omitempty"`
+
+	// ResourceRecommendations reports the resources recommended by the VerticalPodAutoscalers of the components.
+	ResourceRecommendations []ArgoCDResourceRecommendation `json:"resourceRecommendations,omitempty"`
+
+	// Templates are the names of the ArgoCDTemplates applied to the ArgoCD, in order of precedence.
+	Templates []string `json:"templates,omitempty"`
+
+	// AgentPKI reports the certificates and the JWT signing key used by the Argo CD Agent principal.
+	AgentPKI []ArgoCDAgentPKIStatus `json:"agentPKI,omitempty"`
+
+	// Agents reports the connections of the agents to the Argo CD Agent principal.
+	Agents *ArgoCDAgentsStatus `json:"agents,omitempty"`
+
+	// AgentNamespaces reports, for each agent, the namespaces of the principal it is allowed to use according to
+	// spec.argoCDAgent.principal.namespace.policies.
+	AgentNamespaces []ArgoCDAgentNamespacesStatus `json:"agentNamespaces,omitempty"`
+
+	// AgentModeMigration reports the last migration of the Argo CD Agent of this ArgoCD between the managed and
+	// autonomous modes.
+	AgentModeMigration *AgentModeMigrationStatus `json:"agentModeMigration,omitempty"`
+
+	// ObservedGeneration is the generation of the ArgoCD last reconciled by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Conditions is an array of the ArgoCD's status conditions
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ArgoCDAgentPKIStatus reports a certificate or signing key used by the Argo CD Agent principal.
+type ArgoCDAgentPKIStatus struct {
+	// Name identifies the entry, one of ca, principal-tls, resource-proxy-tls or jwt.
+	Name string `json:"name"`
+	// SecretName is the name of the Secret holding the certificate or key.
+	SecretName string `json:"secretName"`
+	// Managed is whether the Secret is created and rotated by the operator.
+	Managed bool `json:"managed,omitempty"`
+	// NotAfter is the expiry of the certificate, or when the operator rotates the JWT signing key.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// RenewAt is when the operator renews the certificate or key, if it is managed.
+	RenewAt *metav1.Time `json:"renewAt,omitempty"`
+	// Message describes why the certificate or key is not available.
+	Message string `json:"message,omitempty"`
+}
+
+// ArgoCDAgentsStatus reports the connections of the agents to the Argo CD Agent principal.
+type ArgoCDAgentsStatus struct {
+	// PrincipalVersion is the version of the principal, from the tag of its image.
+	PrincipalVersion string `json:"principalVersion,omitempty"`
+	// Connected is the number of agents connected to the principal.
+	Connected int32 `json:"connected"`
+	// Total is the number of agents having a cluster Secret, or the number of connected agents if greater.
+	Total int32 `json:"total"`
+	// Items reports the connection of each agent. It is empty when the principal only reports the number of connected
+	// agents.
+	Items []ArgoCDAgentStatus `json:"items,omitempty"`
+}
+
+// ArgoCDAgentStatus reports the connection of an agent to the Argo CD Agent principal.
+type ArgoCDAgentStatus struct {
+	// Name is the name of the agent.
+	Name string `json:"name"`
+	// Connected is whether the agent is connected to the principal.
+	Connected bool `json:"connected"`
+	// Mode is the mode the agent connected with, managed or autonomous.
+	Mode string `json:"mode,omitempty"`
+	// Version is the version of the agent.
+	Version string `json:"version,omitempty"`
+	// VersionSkew is whether the agent runs a minor version other than the one of the principal.
+	VersionSkew bool `json:"versionSkew,omitempty"`
+	// LastSeen is when the operator last found the agent connected to the principal, within the interval the metrics
+	// of the principal are polled at.
+	LastSeen *metav1.Time `json:"lastSeen,omitempty"`
+}
+
+// ArgoCDAgentNamespacesStatus reports the namespaces of the principal an agent is allowed to use.
+type ArgoCDAgentNamespacesStatus struct {
+	// Name is the name of the agent.
+	Name string `json:"name"`
+	// Policies are the names of the namespace policies applying to the agent. The agent is not allowed to use any
+	// namespace when it is empty.
+	Policies []string `json:"policies,omitempty"`
+	// Namespaces are the existing namespaces the agent is allowed to use.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// CreateNamespace is the namespace the principal would create for the agent when it connects. It is empty when the
+	// namespace already exists or the policy does not allow its creation.
+	CreateNamespace string `json:"createNamespace,omitempty"`
+}
+
+// ArgoCDProfileOverridesSpec defines whether the features enabled by a sizing profile are enabled.
+type ArgoCDProfileOverridesSpec struct {
+	// HA is whether the sizing profile enables Redis HA. Defaults to the profile.
+	HA *bool `json:"ha,omitempty"`
+
+	// ControllerSharding is whether the sizing profile enables the sharding of the application controller. Defaults
+	// to the profile.
+	ControllerSharding *bool `json:"controllerSharding,omitempty"`
+}
+
+// ArgoCDProfileStatus reports the effective values of the fields tuned by a sizing profile.
+type ArgoCDProfileStatus struct {
+	// Name is the name of the sizing profile.
+	Name string `json:"name"`
+	// ControllerOperationProcessors is the number of operation processors of the Application Controller.
+	ControllerOperationProcessors int32 `json:"controllerOperationProcessors,omitempty"`
+	// ControllerStatusProcessors is the number of status processors of the Application Controller.
+	ControllerStatusProcessors int32 `json:"controllerStatusProcessors,omitempty"`
+	// ControllerParallelismLimit is the limit for parallel kubectl operations of the Application Controller.
+	ControllerParallelismLimit int32 `json:"controllerParallelismLimit,omitempty"`
+	// ControllerShards is the number of shards of the Application Controller when sharding is enabled.
+	ControllerShards int32 `json:"controllerShards,omitempty"`
+	// RepoReplicas is the number of replicas of the Repo Server.
+	RepoReplicas *int32 `json:"repoReplicas,omitempty"`
+	// RepoExecTimeout is the timeout in seconds for tool execution in the Repo Server.
+	RepoExecTimeout *int `json:"repoExecTimeout,omitempty"`
+	// HA is whether Redis HA is enabled.
+	HA bool `json:"ha,omitempty"`
+	// Resources are the container compute resources of each component.
+	Resources []ArgoCDComponentResources `json:"resources,omitempty"`
+}
+
+// ArgoCDComponentResources reports the container compute resources of a component.
+type ArgoCDComponentResources struct {
+	// Component is the name of the component, e.g. application-controller.
+	Component string `json:"component"`
+	// Resources are the container compute resources of the component.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ArgoCDResourceRecommendation reports the resources recommended by the VerticalPodAutoscaler of a component.
+type ArgoCDResourceRecommendation struct {
+	// Component is the name of the component, e.g. application-controller.
+	Component string `json:"component"`
+	// Containers are the recommendations for each container of the component.
+	Containers []ArgoCDContainerResourceRecommendation `json:"containers,omitempty"`
+}
+
+// ArgoCDContainerResourceRecommendation reports the resources recommended for a container.
+type ArgoCDContainerResourceRecommendation struct {
+	// ContainerName is the name of the container.
+	ContainerName string `json:"containerName"`
+	// Target is the recommended resources.
+	Target corev1.ResourceList `json:"target,omitempty"`
+	// LowerBound is the minimum recommended resources.
+	LowerBound corev1.ResourceList `json:"lowerBound,omitempty"`
+	// UpperBound is the maximum recommended resources.
+	UpperBound corev1.ResourceList `json:"upperBound,omitempty"`
+}
+
 // Banner defines an additional banner message to be displayed in Argo CD UI
 // https://argo-cd.readthedocs.io/en/stable/operator-manual/custom-styles/#banners
 type Banner struct {
@@ -1367,6 +2490,41 @@ const (
 	AgentModeAutonomous AgentMode = "autonomous"
 )
 
+// AgentModeMigrationPhase is a type which represents the phases of the migration of an agent between modes
+type AgentModeMigrationPhase string
+
+// Possible agent mode migration phases
+const (
+	// AgentModeMigrationPhaseMigratingApplications indicates that the Applications of the agent are being labelled
+	// with the new mode
+	AgentModeMigrationPhaseMigratingApplications AgentModeMigrationPhase = "MigratingApplications"
+	// AgentModeMigrationPhaseWaitingForAgent indicates that the agent has not restarted in the new mode yet
+	AgentModeMigrationPhaseWaitingForAgent AgentModeMigrationPhase = "WaitingForAgent"
+	// AgentModeMigrationPhaseCompleted indicates that the Applications of the agent are labelled with the new mode and
+	// the agent runs in it
+	AgentModeMigrationPhaseCompleted AgentModeMigrationPhase = "Completed"
+)
+
+// AgentModeMigrationStatus reports the migration of an agent between the managed and autonomous modes.
+type AgentModeMigrationStatus struct {
+	// From is the mode the agent is migrated from.
+	From AgentMode `json:"from"`
+	// To is the mode the agent is migrated to.
+	To AgentMode `json:"to"`
+	// Phase is the phase of the migration: MigratingApplications, WaitingForAgent or Completed.
+	Phase AgentModeMigrationPhase `json:"phase"`
+	// Applications is the number of Applications of the agent.
+	Applications int32 `json:"applications,omitempty"`
+	// MigratedApplications is the number of Applications of the agent labelled with the new mode.
+	MigratedApplications int32 `json:"migratedApplications,omitempty"`
+	// Message describes the state of the migration.
+	Message string `json:"message,omitempty"`
+	// StartTime is when the migration started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the migration completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
 // AgentComponentType is a type which represents possible agent component types
 type AgentComponentType string
 
@@ -1378,6 +2536,7 @@ const (
 	AgentComponentTypeAgent AgentComponentType = "agent"
 )
 
+// +kubebuilder:validation:XValidation:rule="!(has(self.principal) && has(self.principal.enabled) && self.principal.enabled && has(self.agent) && has(self.agent.enabled) && self.agent.enabled)",message="spec.argoCDAgent.principal and spec.argoCDAgent.agent cannot both be enabled"
 type ArgoCDAgentSpec struct {
 
 	// Principal defines configurations for the Principal component of Argo CD Agent.
@@ -1472,8 +2631,13 @@ type PrincipalJWTSpec struct {
 
 	// SecretName is the name of the secret containing the JWT signing key.
 	SecretName string `json:"secretName,omitempty"`
+
+	// Validity is the lifetime of the JWT signing key generated by the operator. Once elapsed, the operator
+	// generates a new key and the agents have to authenticate again. The key is not rotated when unset.
+	Validity *metav1.Duration `json:"validity,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.policies) || !(has(self.allowedNamespaces) || has(self.enableNamespaceCreate) || has(self.namespaceCreatePattern))",message="allowedNamespaces, enableNamespaceCreate and namespaceCreatePattern cannot be set with policies"
 type PrincipalNamespaceSpec struct {
 
 	// AllowedNamespaces is a list of namespaces the principal shall watch and process Argo CD resources in.
@@ -1487,6 +2651,33 @@ type PrincipalNamespaceSpec struct {
 
 	// NamespaceCreateLabels is the set of labels to apply to namespaces created for agents. Ex: "foo=bar,bar=baz"
 	NamespaceCreateLabels []string `json:"namespaceCreateLabels,omitempty"`
+
+	// Policies map the agents to the namespaces of the principal they are allowed to use. When set, the allowed
+	// namespaces and the namespace creation settings of the principal are derived from the policies, and the principal
+	// is only granted access to Argo CD resources in these namespaces instead of in all namespaces.
+	// +listType=map
+	// +listMapKey=name
+	Policies []PrincipalNamespacePolicy `json:"policies,omitempty"`
+}
+
+// PrincipalNamespacePolicy defines the namespaces of the principal a set of agents is allowed to use.
+type PrincipalNamespacePolicy struct {
+
+	// Name is the name of the policy.
+	Name string `json:"name"`
+
+	// Agents are the names of the agents the policy applies to. Supports the `*` and `?` wildcards. The namespace
+	// named after each agent is always allowed. An agent matching several policies is allowed the namespaces of all of them.
+	// +kubebuilder:validation:MinItems=1
+	Agents []string `json:"agents"`
+
+	// Namespaces are the additional namespaces the agents are allowed to use, e.g. with destination based mapping.
+	// Supports the `*` and `?` wildcards.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// CreateNamespace allows the principal to create the namespace named after each agent when it does not exist.
+	// The namespace is created with the labels of namespaceCreateLabels.
+	CreateNamespace bool `json:"createNamespace,omitempty"`
 }
 
 type PrincipalResourceProxySpec struct {
@@ -1508,6 +2699,15 @@ type PrincipalTLSSpec struct {
 
 	// InsecureGenerate is the flag to allow the principal to generate its own set of TLS cert and key on startup when none are configured
 	InsecureGenerate *bool `json:"insecureGenerate,omitempty"`
+
+	// Validity is the lifetime of the principal and resource proxy certificates issued by the operator. (optional, default `8760h`)
+	Validity *metav1.Duration `json:"validity,omitempty"`
+
+	// CAValidity is the lifetime of the CA certificate created by the operator. (optional, default `87600h`)
+	CAValidity *metav1.Duration `json:"caValidity,omitempty"`
+
+	// RenewBefore is how long before expiry the operator renews the certificates it manages. (optional, default `720h`)
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 }
 
 // ArgoCDAgentPrincipalServiceSpec defines the options for the Service backing the ArgoCD Agent Principalcomponent.
@@ -1528,11 +2728,17 @@ func (a *PrincipalSpec) IsEnabled() bool {
 	return a.Enabled != nil && *a.Enabled
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.installProfile) || (has(self.enabled) && self.enabled)",message="installProfile requires the agent to be enabled"
 type AgentSpec struct {
 
 	// Enabled is the flag to enable the Agent component during Argo CD installation. (optional, default `false`)
 	Enabled *bool `json:"enabled,omitempty"`
 
+	// InstallProfile is the install profile of the Argo CD instance running the Agent. The agent-only profile only
+	// installs the components required by the mode of the Agent, and defaults the settings left unset accordingly.
+	// +kubebuilder:validation:Enum=agent-only
+	InstallProfile string `json:"installProfile,omitempty"`
+
 	// AllowedNamespaces is a list of additional namespaces the agent is allowed to
 	// manage applications in. Supports glob patterns.
 	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
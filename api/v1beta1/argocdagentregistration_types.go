@@ -0,0 +1,117 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArgoCDAgentRegistrationConditionReady is the condition reporting whether the client certificate, the cluster
+	// Secret and the bootstrap bundle of the agent are issued.
+	ArgoCDAgentRegistrationConditionReady = "Ready"
+
+	// AgentConnectionStateConnected indicates that the agent is connected to the principal
+	AgentConnectionStateConnected = "Connected"
+	// AgentConnectionStateDisconnected indicates that the agent is known to the principal but not connected
+	AgentConnectionStateDisconnected = "Disconnected"
+	// AgentConnectionStateUnknown indicates that the connection state of the agent could not be obtained
+	AgentConnectionStateUnknown = "Unknown"
+)
+
+// ArgoCDAgentRegistrationSpec defines the desired state of ArgoCDAgentRegistration
+type ArgoCDAgentRegistrationSpec struct {
+	// AgentName is the name of the agent. It is the common name of the client certificate of the agent and the name
+	// of its cluster in Argo CD. Defaults to the name of the ArgoCDAgentRegistration.
+	AgentName string `json:"agentName,omitempty"`
+
+	// Mode is the mode of the agent, either managed or autonomous.
+	// +kubebuilder:validation:Enum=managed;autonomous
+	// +kubebuilder:default=managed
+	Mode AgentMode `json:"mode,omitempty"`
+
+	// Namespace is the namespace of the workload cluster the agent runs in. (optional, default `argocd`)
+	Namespace string `json:"namespace,omitempty"`
+
+	// Labels are added to the Argo CD cluster Secret of the agent, e.g. to select the cluster from the cluster
+	// generator of an ApplicationSet.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// PrincipalAddress is the address the agent connects to the principal on, written to the bootstrap bundle.
+	// Defaults to the host of the principal Route, the address of the principal LoadBalancer Service or the name of
+	// the principal Service.
+	PrincipalAddress string `json:"principalAddress,omitempty"`
+
+	// PrincipalPort is the port the agent connects to the principal on, written to the bootstrap bundle. (optional, default `443`)
+	PrincipalPort int32 `json:"principalPort,omitempty"`
+}
+
+// ArgoCDAgentRegistrationStatus defines the observed state of ArgoCDAgentRegistration
+type ArgoCDAgentRegistrationStatus struct {
+	// AgentName is the name of the registered agent.
+	AgentName string `json:"agentName,omitempty"`
+
+	// ClusterSecretName is the name of the Argo CD cluster Secret of the agent.
+	ClusterSecretName string `json:"clusterSecretName,omitempty"`
+
+	// BootstrapSecretName is the name of the Secret holding the bootstrap bundle of the agent.
+	BootstrapSecretName string `json:"bootstrapSecretName,omitempty"`
+
+	// CertificateNotAfter is the expiry of the client certificate of the agent.
+	CertificateNotAfter *metav1.Time `json:"certificateNotAfter,omitempty"`
+
+	// ConnectionState is the state of the connection of the agent to the principal: Connected, Disconnected or Unknown.
+	ConnectionState string `json:"connectionState,omitempty"`
+
+	// LastSeen is when the agent was last seen connected to the principal.
+	LastSeen *metav1.Time `json:"lastSeen,omitempty"`
+
+	// Mode is the mode the agent is registered in. It only changes to spec.mode once the migration of the agent to it
+	// completed.
+	Mode AgentMode `json:"mode,omitempty"`
+
+	// ModeMigration reports the last migration of the agent between the managed and autonomous modes.
+	ModeMigration *AgentModeMigrationStatus `json:"modeMigration,omitempty"`
+
+	// Conditions is an array of the ArgoCDAgentRegistration's status conditions
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ArgoCDAgentRegistration is the Schema for the argocdagentregistrations API
+type ArgoCDAgentRegistration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDAgentRegistrationSpec   `json:"spec,omitempty"`
+	Status ArgoCDAgentRegistrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ArgoCDAgentRegistrationList contains a list of ArgoCDAgentRegistration
+type ArgoCDAgentRegistrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCDAgentRegistration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArgoCDAgentRegistration{}, &ArgoCDAgentRegistrationList{})
+}
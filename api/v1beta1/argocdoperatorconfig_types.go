@@ -0,0 +1,196 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArgoCDOperatorConfigName is the name of the ArgoCDOperatorConfig singleton.
+	ArgoCDOperatorConfigName = "cluster"
+
+	// ArgoCDOperatorSettingSourceConfig is the source of a setting set in the ArgoCDOperatorConfig.
+	ArgoCDOperatorSettingSourceConfig = "ArgoCDOperatorConfig"
+
+	// ArgoCDOperatorSettingSourceEnvironment is the source of a setting read from the environment of the operator.
+	ArgoCDOperatorSettingSourceEnvironment = "Environment"
+
+	// ArgoCDOperatorSettingSourceDefault is the source of a setting that is not set.
+	ArgoCDOperatorSettingSourceDefault = "Default"
+
+	// ArgoCDOperatorConfigConditionValid is the condition reporting whether the ArgoCDOperatorConfig is valid.
+	ArgoCDOperatorConfigConditionValid = "Valid"
+
+	// ArgoCDOperatorConfigConditionRestartRequired is the condition reporting whether a setting only takes effect once
+	// the operator is restarted.
+	ArgoCDOperatorConfigConditionRestartRequired = "RestartRequired"
+)
+
+// ArgoCDOperatorConfigSpec defines the operator-wide settings. The settings that are not set fall back to the
+// environment variables of the operator.
+type ArgoCDOperatorConfigSpec struct {
+	// ClusterConfigNamespaces are the namespaces of the Argo CD instances granted cluster-scoped permissions, or "*"
+	// for all namespaces. Falls back to ARGOCD_CLUSTER_CONFIG_NAMESPACES.
+	ClusterConfigNamespaces []string `json:"clusterConfigNamespaces,omitempty"`
+
+	// MemoryOptimizationEnabled strips the data of the Secrets and ConfigMaps not tracked by the operator from its
+	// cache. Falls back to MEMORY_OPTIMIZATION_ENABLED. Takes effect once the operator is restarted.
+	MemoryOptimizationEnabled *bool `json:"memoryOptimizationEnabled,omitempty"`
+
+	// ConversionWebhookEnabled starts the ArgoCD conversion webhook. Falls back to ENABLE_CONVERSION_WEBHOOK. Takes
+	// effect once the operator is restarted.
+	ConversionWebhookEnabled *bool `json:"conversionWebhookEnabled,omitempty"`
+
+	// RemoveManagedByLabelOnArgoCDDeletion removes the managed-by label from the managed namespaces when an ArgoCD is
+	// deleted. Falls back to REMOVE_MANAGED_BY_LABEL_ON_ARGOCD_DELETION.
+	RemoveManagedByLabelOnArgoCDDeletion *bool `json:"removeManagedByLabelOnArgoCDDeletion,omitempty"`
+
+	// NamespaceManagementEnabled allows namespace-scoped Argo CD instances to manage namespaces through
+	// NamespaceManagement resources. Falls back to ALLOW_NAMESPACE_MANAGEMENT_IN_NAMESPACE_SCOPED_INSTANCES.
+	NamespaceManagementEnabled *bool `json:"namespaceManagementEnabled,omitempty"`
+
+	// InsecureMutationWebhooksAllowed allows the mutation webhooks of the Argo CD instances to be called over plain
+	// http. Falls back to ALLOW_INSECURE_MUTATION_WEBHOOKS.
+	InsecureMutationWebhooksAllowed *bool `json:"insecureMutationWebhooksAllowed,omitempty"`
+
+	// ImagePullPolicy is the image pull policy of the Argo CD components. Falls back to IMAGE_PULL_POLICY.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Images overrides the default images of the Argo CD components.
+	Images *ArgoCDOperatorConfigImages `json:"images,omitempty"`
+
+	// ClusterRoles overrides the ClusterRoles bound to the Argo CD components.
+	ClusterRoles *ArgoCDOperatorConfigClusterRoles `json:"clusterRoles,omitempty"`
+}
+
+// ArgoCDOperatorConfigImages defines the default images of the Argo CD components.
+type ArgoCDOperatorConfigImages struct {
+	// ArgoCD is the image of the Argo CD components. Falls back to ARGOCD_IMAGE.
+	ArgoCD string `json:"argocd,omitempty"`
+	// Dex is the image of Dex. Falls back to ARGOCD_DEX_IMAGE.
+	Dex string `json:"dex,omitempty"`
+	// Redis is the image of Redis. Falls back to ARGOCD_REDIS_IMAGE.
+	Redis string `json:"redis,omitempty"`
+	// RedisHA is the image of Redis in HA mode. Falls back to ARGOCD_REDIS_HA_IMAGE.
+	RedisHA string `json:"redisHA,omitempty"`
+	// RedisHAProxy is the image of the Redis HA proxy. Falls back to ARGOCD_REDIS_HA_PROXY_IMAGE.
+	RedisHAProxy string `json:"redisHAProxy,omitempty"`
+	// Extension is the image of the extension installer. Falls back to ARGOCD_EXTENSION_IMAGE.
+	Extension string `json:"extension,omitempty"`
+	// ImageUpdater is the image of the Image Updater. Falls back to ARGOCD_IMAGE_UPDATER_IMAGE.
+	ImageUpdater string `json:"imageUpdater,omitempty"`
+	// Principal is the image of the Argo CD agent principal. Falls back to ARGOCD_PRINCIPAL_IMAGE.
+	Principal string `json:"principal,omitempty"`
+	// Agent is the image of the Argo CD agent. Falls back to ARGOCD_AGENT_IMAGE.
+	Agent string `json:"agent,omitempty"`
+}
+
+// ArgoCDOperatorConfigClusterRoles defines the ClusterRoles bound to the Argo CD components.
+type ArgoCDOperatorConfigClusterRoles struct {
+	// Controller is the ClusterRole of the Application Controller. Falls back to CONTROLLER_CLUSTER_ROLE.
+	Controller string `json:"controller,omitempty"`
+	// Server is the ClusterRole of the Argo CD Server. Falls back to SERVER_CLUSTER_ROLE.
+	Server string `json:"server,omitempty"`
+	// Principal is the ClusterRole of the Argo CD agent principal. Falls back to PRINCIPAL_CLUSTER_ROLE.
+	Principal string `json:"principal,omitempty"`
+	// Agent is the ClusterRole of the Argo CD agent. Falls back to AGENT_CLUSTER_ROLE.
+	Agent string `json:"agent,omitempty"`
+}
+
+// ArgoCDOperatorConfigStatus defines the observed state of ArgoCDOperatorConfig
+type ArgoCDOperatorConfigStatus struct {
+	// Settings are the effective values of the operator settings.
+	Settings []ArgoCDOperatorSetting `json:"settings,omitempty"`
+
+	// Conditions is an array of the ArgoCDOperatorConfig's status conditions
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// StorageMigration reports the last migration of the ArgoCD objects to the storage version of the ArgoCD CRD.
+	StorageMigration *ArgoCDStorageMigrationStatus `json:"storageMigration,omitempty"`
+}
+
+// ArgoCDStorageMigrationStatus reports a migration of the ArgoCD objects to the storage version of the ArgoCD CRD.
+type ArgoCDStorageMigrationStatus struct {
+	// StorageVersion is the version the ArgoCD objects are rewritten to.
+	StorageVersion string `json:"storageVersion"`
+	// StoredVersions are the versions the ArgoCD objects may still be stored in once the migration completed.
+	StoredVersions []string `json:"storedVersions,omitempty"`
+	// Migrated are the namespace/name of the ArgoCD objects rewritten to the storage version.
+	Migrated []string `json:"migrated,omitempty"`
+	// Skipped are the ArgoCD objects left as they are stored, as their fields do not round-trip losslessly through the
+	// storage version or they could not be migrated.
+	Skipped []ArgoCDStorageMigrationObject `json:"skipped,omitempty"`
+	// StartTime is when the migration started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the migration completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ArgoCDStorageMigrationObject reports an ArgoCD object that was not migrated to the storage version.
+type ArgoCDStorageMigrationObject struct {
+	// Name of the ArgoCD.
+	Name string `json:"name"`
+	// Namespace of the ArgoCD.
+	Namespace string `json:"namespace"`
+	// LossyFields are the paths of the fields of the v1alpha1 ArgoCD that are lost or changed once converted to the
+	// storage version.
+	LossyFields []string `json:"lossyFields,omitempty"`
+	// Message explains why the ArgoCD was not migrated.
+	Message string `json:"message,omitempty"`
+}
+
+// ArgoCDOperatorSetting reports the effective value of an operator setting.
+type ArgoCDOperatorSetting struct {
+	// Name is the name of the environment variable the setting falls back to.
+	Name string `json:"name"`
+	// Value is the effective value of the setting.
+	Value string `json:"value,omitempty"`
+	// Source is where the value comes from, one of ArgoCDOperatorConfig, Environment or Default.
+	Source string `json:"source"`
+	// RestartRequired is whether the value only takes effect once the operator is restarted.
+	RestartRequired bool `json:"restartRequired,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="the ArgoCDOperatorConfig must be named cluster"
+
+// ArgoCDOperatorConfig is the Schema for the argocdoperatorconfigs API
+type ArgoCDOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDOperatorConfigSpec   `json:"spec,omitempty"`
+	Status ArgoCDOperatorConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ArgoCDOperatorConfigList contains a list of ArgoCDOperatorConfig
+type ArgoCDOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCDOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArgoCDOperatorConfig{}, &ArgoCDOperatorConfigList{})
+}
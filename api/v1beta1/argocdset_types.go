@@ -0,0 +1,120 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArgoCDSetConditionRolloutComplete is the condition reporting whether every ArgoCD of the ArgoCDSet is up to date
+	// and available.
+	ArgoCDSetConditionRolloutComplete = "RolloutComplete"
+)
+
+// ArgoCDSetSpec defines the desired state of ArgoCDSet
+type ArgoCDSetSpec struct {
+	// NamespaceSelector selects the namespaces an ArgoCD is created in.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// Template is the template of the ArgoCD created in each of the selected namespaces. The string values of the
+	// template may contain the {{namespace}}, {{namespace.labels.<key>}} and {{namespace.annotations.<key>}}
+	// placeholders, which are substituted with the name, a label or an annotation of the namespace.
+	Template ArgoCDSetTemplate `json:"template"`
+
+	// Rollout defines how the changes to the template are rolled out to the ArgoCD instances.
+	Rollout ArgoCDSetRolloutSpec `json:"rollout,omitempty"`
+}
+
+// ArgoCDSetTemplate defines the ArgoCD created by an ArgoCDSet.
+type ArgoCDSetTemplate struct {
+	// Labels are the labels of the ArgoCD.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are the annotations of the ArgoCD.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Spec is the spec of the ArgoCD. The spec is validated when the ArgoCD is created or updated.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec ArgoCDSpec `json:"spec,omitempty"`
+}
+
+// ArgoCDSetRolloutSpec defines how the changes to the template of an ArgoCDSet are rolled out.
+type ArgoCDSetRolloutSpec struct {
+	// BatchSize is the number of ArgoCD instances created or updated at a time. The next batch starts once the ArgoCD
+	// instances of the previous batch are Available. All the ArgoCD instances are created or updated at once when not set.
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *int32 `json:"batchSize,omitempty"`
+}
+
+// ArgoCDSetStatus defines the observed state of ArgoCDSet
+type ArgoCDSetStatus struct {
+	// Instances reports the state of the ArgoCD in each of the selected namespaces.
+	Instances []ArgoCDSetInstanceStatus `json:"instances,omitempty"`
+
+	// Desired is the number of selected namespaces.
+	Desired int32 `json:"desired,omitempty"`
+
+	// UpToDate is the number of ArgoCD instances matching the current template, whose spec was not changed directly.
+	UpToDate int32 `json:"upToDate,omitempty"`
+
+	// Available is the number of ArgoCD instances matching the current template with the Available phase.
+	Available int32 `json:"available,omitempty"`
+
+	// Conditions is an array of the ArgoCDSet's status conditions
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ArgoCDSetInstanceStatus reports the state of an ArgoCD created by an ArgoCDSet.
+type ArgoCDSetInstanceStatus struct {
+	// Namespace is the namespace of the ArgoCD.
+	Namespace string `json:"namespace"`
+	// Phase is the phase of the ArgoCD.
+	Phase string `json:"phase,omitempty"`
+	// UpToDate is whether the ArgoCD matches the current template and its spec was not changed directly.
+	UpToDate bool `json:"upToDate,omitempty"`
+	// Message explains why the ArgoCD could not be created or updated.
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// ArgoCDSet is the Schema for the argocdsets API
+type ArgoCDSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDSetSpec   `json:"spec,omitempty"`
+	Status ArgoCDSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ArgoCDSetList contains a list of ArgoCDSet
+type ArgoCDSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCDSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArgoCDSet{}, &ArgoCDSetList{})
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArgoCDTemplateSpec defines the defaults shared by a set of ArgoCD instances.
+type ArgoCDTemplateSpec struct {
+	// Selector selects the ArgoCD instances the template applies to by their labels. An empty selector selects all the
+	// ArgoCD instances, the template only applies to the ArgoCD instances referencing it by name when not set.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Priority orders the templates selected by the same ArgoCD, the defaults of the template with the highest priority
+	// take precedence. Templates with the same priority are ordered by name.
+	Priority int32 `json:"priority,omitempty"`
+
+	// Defaults are the values applied to the fields that are not set in the selected ArgoCD instances.
+	Defaults ArgoCDTemplateDefaults `json:"defaults,omitempty"`
+}
+
+// ArgoCDTemplateDefaults defines the values an ArgoCDTemplate applies to the fields that are not set in an ArgoCD.
+type ArgoCDTemplateDefaults struct {
+	// Image is the default container image for all the Argo CD components.
+	Image string `json:"image,omitempty"`
+
+	// Version is the default tag of the container image for all the Argo CD components.
+	Version string `json:"version,omitempty"`
+
+	// NodePlacement is the default NodeSelector and Tolerations of the Argo CD workloads.
+	NodePlacement *ArgoCDNodePlacementSpec `json:"nodePlacement,omitempty"`
+
+	// Resources are the default compute resources of the Argo CD components.
+	Resources *ArgoCDTemplateResources `json:"resources,omitempty"`
+
+	// RBAC is the default RBAC configuration for Argo CD, applied to each of the RBAC fields that are not set.
+	RBAC *ArgoCDRBACSpec `json:"rbac,omitempty"`
+
+	// Banner is the default banner displayed in the Argo CD UI.
+	Banner *Banner `json:"banner,omitempty"`
+
+	// ExtraConfig are the default entries of the argocd-cm ConfigMap, applied to each of the keys that are not set.
+	ExtraConfig map[string]string `json:"extraConfig,omitempty"`
+}
+
+// ArgoCDTemplateResources defines the default compute resources of the Argo CD components.
+type ArgoCDTemplateResources struct {
+	// ApplicationSet are the default compute resources of the ApplicationSet controller.
+	ApplicationSet *corev1.ResourceRequirements `json:"applicationSet,omitempty"`
+	// Controller are the default compute resources of the Application Controller.
+	Controller *corev1.ResourceRequirements `json:"controller,omitempty"`
+	// Redis are the default compute resources of Redis.
+	Redis *corev1.ResourceRequirements `json:"redis,omitempty"`
+	// Repo are the default compute resources of the Repo Server.
+	Repo *corev1.ResourceRequirements `json:"repo,omitempty"`
+	// Server are the default compute resources of the Argo CD Server.
+	Server *corev1.ResourceRequirements `json:"server,omitempty"`
+}
+
+// ArgoCDTemplateStatus defines the observed state of ArgoCDTemplate
+type ArgoCDTemplateStatus struct {
+	// Conditions is an array of the ArgoCDTemplate's status conditions
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// ArgoCDTemplateConditionSelectorValid reports whether the selector of the ArgoCDTemplate can be matched against
+	// the labels of the ArgoCD instances.
+	ArgoCDTemplateConditionSelectorValid = "SelectorValid"
+
+	// ArgoCDTemplateConditionReasonInvalidSelector is set when the selector of the ArgoCDTemplate is invalid and the
+	// template is not applied to any ArgoCD selected by its labels.
+	ArgoCDTemplateConditionReasonInvalidSelector = "InvalidSelector"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// ArgoCDTemplate is the Schema for the argocdtemplates API
+type ArgoCDTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDTemplateSpec   `json:"spec,omitempty"`
+	Status ArgoCDTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ArgoCDTemplateList contains a list of ArgoCDTemplate
+type ArgoCDTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCDTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArgoCDTemplate{}, &ArgoCDTemplateList{})
+}
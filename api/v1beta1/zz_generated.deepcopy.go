@@ -54,6 +54,29 @@ func (in *AgentClientSpec) DeepCopy() *AgentClientSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentModeMigrationStatus) DeepCopyInto(out *AgentModeMigrationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentModeMigrationStatus.
+func (in *AgentModeMigrationStatus) DeepCopy() *AgentModeMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentModeMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentRedisSpec) DeepCopyInto(out *AgentRedisSpec) {
 	*out = *in
@@ -178,6 +201,54 @@ func (in *ArgoCD) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentNamespacesStatus) DeepCopyInto(out *ArgoCDAgentNamespacesStatus) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentNamespacesStatus.
+func (in *ArgoCDAgentNamespacesStatus) DeepCopy() *ArgoCDAgentNamespacesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentNamespacesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentPKIStatus) DeepCopyInto(out *ArgoCDAgentPKIStatus) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewAt != nil {
+		in, out := &in.RenewAt, &out.RenewAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentPKIStatus.
+func (in *ArgoCDAgentPKIStatus) DeepCopy() *ArgoCDAgentPKIStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentPKIStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDAgentPrincipalRouteSpec) DeepCopyInto(out *ArgoCDAgentPrincipalRouteSpec) {
 	*out = *in
@@ -213,6 +284,122 @@ func (in *ArgoCDAgentPrincipalServiceSpec) DeepCopy() *ArgoCDAgentPrincipalServi
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentRegistration) DeepCopyInto(out *ArgoCDAgentRegistration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentRegistration.
+func (in *ArgoCDAgentRegistration) DeepCopy() *ArgoCDAgentRegistration {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentRegistration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDAgentRegistration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentRegistrationList) DeepCopyInto(out *ArgoCDAgentRegistrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCDAgentRegistration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentRegistrationList.
+func (in *ArgoCDAgentRegistrationList) DeepCopy() *ArgoCDAgentRegistrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentRegistrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDAgentRegistrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentRegistrationSpec) DeepCopyInto(out *ArgoCDAgentRegistrationSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentRegistrationSpec.
+func (in *ArgoCDAgentRegistrationSpec) DeepCopy() *ArgoCDAgentRegistrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentRegistrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentRegistrationStatus) DeepCopyInto(out *ArgoCDAgentRegistrationStatus) {
+	*out = *in
+	if in.CertificateNotAfter != nil {
+		in, out := &in.CertificateNotAfter, &out.CertificateNotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSeen != nil {
+		in, out := &in.LastSeen, &out.LastSeen
+		*out = (*in).DeepCopy()
+	}
+	if in.ModeMigration != nil {
+		in, out := &in.ModeMigration, &out.ModeMigration
+		*out = new(AgentModeMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentRegistrationStatus.
+func (in *ArgoCDAgentRegistrationStatus) DeepCopy() *ArgoCDAgentRegistrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentRegistrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDAgentSpec) DeepCopyInto(out *ArgoCDAgentSpec) {
 	*out = *in
@@ -238,6 +425,47 @@ func (in *ArgoCDAgentSpec) DeepCopy() *ArgoCDAgentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentStatus) DeepCopyInto(out *ArgoCDAgentStatus) {
+	*out = *in
+	if in.LastSeen != nil {
+		in, out := &in.LastSeen, &out.LastSeen
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentStatus.
+func (in *ArgoCDAgentStatus) DeepCopy() *ArgoCDAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAgentsStatus) DeepCopyInto(out *ArgoCDAgentsStatus) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCDAgentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAgentsStatus.
+func (in *ArgoCDAgentsStatus) DeepCopy() *ArgoCDAgentsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAgentsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDApplicationControllerProcessorsSpec) DeepCopyInto(out *ArgoCDApplicationControllerProcessorsSpec) {
 	*out = *in
@@ -289,6 +517,11 @@ func (in *ArgoCDApplicationControllerSpec) DeepCopyInto(out *ArgoCDApplicationCo
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(ArgoCDVPASpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AppSync != nil {
 		in, out := &in.AppSync, &out.AppSync
 		*out = new(metav1.Duration)
@@ -384,6 +617,11 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(ArgoCDVPASpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.WebhookServer.DeepCopyInto(&out.WebhookServer)
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
@@ -428,6 +666,11 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Autoscale != nil {
+		in, out := &in.Autoscale, &out.Autoscale
+		*out = new(ArgoCDAutoscaleSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSet.
@@ -440,6 +683,31 @@ func (in *ArgoCDApplicationSet) DeepCopy() *ArgoCDApplicationSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAutoscaleSpec) DeepCopyInto(out *ArgoCDAutoscaleSpec) {
+	*out = *in
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KEDA != nil {
+		in, out := &in.KEDA, &out.KEDA
+		*out = new(ArgoCDKEDASpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAutoscaleSpec.
+func (in *ArgoCDAutoscaleSpec) DeepCopy() *ArgoCDAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDCASpec) DeepCopyInto(out *ArgoCDCASpec) {
 	*out = *in
@@ -471,352 +739,330 @@ func (in *ArgoCDCertificateSpec) DeepCopy() *ArgoCDCertificateSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
+func (in *ArgoCDComponentResources) DeepCopyInto(out *ArgoCDComponentResources) {
 	*out = *in
-	if in.EnableSATokenRenewal != nil {
-		in, out := &in.EnableSATokenRenewal, &out.EnableSATokenRenewal
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDComponentResources.
+func (in *ArgoCDComponentResources) DeepCopy() *ArgoCDComponentResources {
+	if in == nil {
+		return nil
 	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	out := new(ArgoCDComponentResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDContainerResourceRecommendation) DeepCopyInto(out *ArgoCDContainerResourceRecommendation) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
 		}
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
+	if in.LowerBound != nil {
+		in, out := &in.LowerBound, &out.LowerBound
+		*out = make(v1.ResourceList, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = val.DeepCopy()
 		}
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
+	if in.UpperBound != nil {
+		in, out := &in.UpperBound, &out.UpperBound
+		*out = make(v1.ResourceList, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = val.DeepCopy()
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
-func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDContainerResourceRecommendation.
+func (in *ArgoCDContainerResourceRecommendation) DeepCopy() *ArgoCDContainerResourceRecommendation {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDDexSpec)
+	out := new(ArgoCDContainerResourceRecommendation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
+func (in *ArgoCDDexConnector) DeepCopyInto(out *ArgoCDDexConnector) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(ArgoCDDexGitHubConnector)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Route.DeepCopyInto(&out.Route)
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = new(int32)
-		**out = **in
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(ArgoCDDexGitLabConnector)
+		(*in).DeepCopyInto(*out)
 	}
-}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(ArgoCDDexLDAPConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(ArgoCDDexSAMLConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(ArgoCDDexOIDCConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Microsoft != nil {
+		in, out := &in.Microsoft, &out.Microsoft
+		*out = new(ArgoCDDexMicrosoftConnector)
+		(*in).DeepCopyInto(*out)
+	}
+}
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
-func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexConnector.
+func (in *ArgoCDDexConnector) DeepCopy() *ArgoCDDexConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDGrafanaSpec)
+	out := new(ArgoCDDexConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+func (in *ArgoCDDexGitHubConnector) DeepCopyInto(out *ArgoCDDexGitHubConnector) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+	if in.Orgs != nil {
+		in, out := &in.Orgs, &out.Orgs
+		*out = make([]ArgoCDDexGitHubOrg, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
-func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitHubConnector.
+func (in *ArgoCDDexGitHubConnector) DeepCopy() *ArgoCDDexGitHubConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDHASpec)
+	out := new(ArgoCDDexGitHubConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDImageUpdaterSpec) DeepCopyInto(out *ArgoCDImageUpdaterSpec) {
+func (in *ArgoCDDexGitHubOrg) DeepCopyInto(out *ArgoCDDexGitHubOrg) {
 	*out = *in
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImageUpdaterSpec.
-func (in *ArgoCDImageUpdaterSpec) DeepCopy() *ArgoCDImageUpdaterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitHubOrg.
+func (in *ArgoCDDexGitHubOrg) DeepCopy() *ArgoCDDexGitHubOrg {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDImageUpdaterSpec)
+	out := new(ArgoCDDexGitHubOrg)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
+func (in *ArgoCDDexGitLabConnector) DeepCopyInto(out *ArgoCDDexGitLabConnector) {
 	*out = *in
-	if in.Namespace != nil {
-		in, out := &in.Namespace, &out.Namespace
-		*out = new(string)
-		**out = **in
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImportSpec.
-func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitLabConnector.
+func (in *ArgoCDDexGitLabConnector) DeepCopy() *ArgoCDDexGitLabConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDImportSpec)
+	out := new(ArgoCDDexGitLabConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
+func (in *ArgoCDDexLDAPConnector) DeepCopyInto(out *ArgoCDDexLDAPConnector) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.RootCARef != nil {
+		in, out := &in.RootCARef, &out.RootCARef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.IngressClassName != nil {
-		in, out := &in.IngressClassName, &out.IngressClassName
-		*out = new(string)
-		**out = **in
+	if in.BindPWRef != nil {
+		in, out := &in.BindPWRef, &out.BindPWRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = make([]networkingv1.IngressTLS, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.UserSearch = in.UserSearch
+	if in.GroupSearch != nil {
+		in, out := &in.GroupSearch, &out.GroupSearch
+		*out = new(ArgoCDDexLDAPGroupSearch)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIngressSpec.
-func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPConnector.
+func (in *ArgoCDDexLDAPConnector) DeepCopy() *ArgoCDDexLDAPConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDIngressSpec)
+	out := new(ArgoCDDexLDAPConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
+func (in *ArgoCDDexLDAPGroupSearch) DeepCopyInto(out *ArgoCDDexLDAPGroupSearch) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VerifyTLS != nil {
-		in, out := &in.VerifyTLS, &out.VerifyTLS
-		*out = new(bool)
-		**out = **in
+	if in.UserMatchers != nil {
+		in, out := &in.UserMatchers, &out.UserMatchers
+		*out = make([]ArgoCDDexLDAPUserMatcher, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
-func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPGroupSearch.
+func (in *ArgoCDDexLDAPGroupSearch) DeepCopy() *ArgoCDDexLDAPGroupSearch {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDKeycloakSpec)
+	out := new(ArgoCDDexLDAPGroupSearch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
+func (in *ArgoCDDexLDAPUserMatcher) DeepCopyInto(out *ArgoCDDexLDAPUserMatcher) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ArgoCD, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
-func (in *ArgoCDList) DeepCopy() *ArgoCDList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPUserMatcher.
+func (in *ArgoCDDexLDAPUserMatcher) DeepCopy() *ArgoCDDexLDAPUserMatcher {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDList)
+	out := new(ArgoCDDexLDAPUserMatcher)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ArgoCDList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDMetricsSpec) DeepCopyInto(out *ArgoCDMetricsSpec) {
+func (in *ArgoCDDexLDAPUserSearch) DeepCopyInto(out *ArgoCDDexLDAPUserSearch) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMetricsSpec.
-func (in *ArgoCDMetricsSpec) DeepCopy() *ArgoCDMetricsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPUserSearch.
+func (in *ArgoCDDexLDAPUserSearch) DeepCopy() *ArgoCDDexLDAPUserSearch {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDMetricsSpec)
+	out := new(ArgoCDDexLDAPUserSearch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
+func (in *ArgoCDDexMicrosoftConnector) DeepCopyInto(out *ArgoCDDexMicrosoftConnector) {
 	*out = *in
-	if in.DisableMetrics != nil {
-		in, out := &in.DisableMetrics, &out.DisableMetrics
-		*out = new(bool)
-		**out = **in
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
-func (in *ArgoCDMonitoringSpec) DeepCopy() *ArgoCDMonitoringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexMicrosoftConnector.
+func (in *ArgoCDDexMicrosoftConnector) DeepCopy() *ArgoCDDexMicrosoftConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDMonitoringSpec)
+	out := new(ArgoCDDexMicrosoftConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNetworkPolicySpec) DeepCopyInto(out *ArgoCDNetworkPolicySpec) {
+func (in *ArgoCDDexOIDCConnector) DeepCopyInto(out *ArgoCDDexOIDCConnector) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNetworkPolicySpec.
-func (in *ArgoCDNetworkPolicySpec) DeepCopy() *ArgoCDNetworkPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexOIDCConnector.
+func (in *ArgoCDDexOIDCConnector) DeepCopy() *ArgoCDDexOIDCConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNetworkPolicySpec)
+	out := new(ArgoCDDexOIDCConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+func (in *ArgoCDDexSAMLConnector) DeepCopyInto(out *ArgoCDDexSAMLConnector) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.CARef.DeepCopyInto(&out.CARef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
-func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSAMLConnector.
+func (in *ArgoCDDexSAMLConnector) DeepCopy() *ArgoCDDexSAMLConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNodePlacementSpec)
+	out := new(ArgoCDDexSAMLConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
+	if in.EnableSATokenRenewal != nil {
+		in, out := &in.EnableSATokenRenewal, &out.EnableSATokenRenewal
+		*out = new(bool)
 		**out = **in
 	}
-	if in.SourceNamespaces != nil {
-		in, out := &in.SourceNamespaces, &out.SourceNamespaces
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = make([]ArgoCDDexConnector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -824,32 +1070,55 @@ func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = new(ArgoCDMetricsSpec)
-		**out = **in
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
-func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
+func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNotifications)
+	out := new(ArgoCDDexSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
+func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
 	*out = *in
 	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Route.DeepCopyInto(&out.Route)
 	if in.Size != nil {
 		in, out := &in.Size, &out.Size
@@ -858,69 +1127,86 @@ func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPrometheusSpec.
-func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
+func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDPrometheusSpec)
+	out := new(ArgoCDGrafanaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
+func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
 	*out = *in
-	if in.DefaultPolicy != nil {
-		in, out := &in.DefaultPolicy, &out.DefaultPolicy
-		*out = new(string)
-		**out = **in
-	}
-	if in.Policy != nil {
-		in, out := &in.Policy, &out.Policy
-		*out = new(string)
-		**out = **in
-	}
-	if in.Scopes != nil {
-		in, out := &in.Scopes, &out.Scopes
-		*out = new(string)
-		**out = **in
-	}
-	if in.PolicyMatcherMode != nil {
-		in, out := &in.PolicyMatcherMode, &out.PolicyMatcherMode
-		*out = new(string)
-		**out = **in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
-func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
+func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRBACSpec)
+	out := new(ArgoCDHASpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
+func (in *ArgoCDImageUpdaterSpec) DeepCopyInto(out *ArgoCDImageUpdaterSpec) {
 	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImageUpdaterSpec.
+func (in *ArgoCDImageUpdaterSpec) DeepCopy() *ArgoCDImageUpdaterSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Remote != nil {
-		in, out := &in.Remote, &out.Remote
+	out := new(ArgoCDImageUpdaterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
 		*out = new(string)
 		**out = **in
 	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImportSpec.
+func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
+	*out = *in
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
@@ -928,248 +1214,1405 @@ func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
-func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIngressSpec.
+func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRedisSpec)
+	out := new(ArgoCDIngressSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
+func (in *ArgoCDKEDASpec) DeepCopyInto(out *ArgoCDKEDASpec) {
 	*out = *in
-	if in.ExtraRepoCommandArgs != nil {
-		in, out := &in.ExtraRepoCommandArgs, &out.ExtraRepoCommandArgs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
+	if in.MinReplicaCount != nil {
+		in, out := &in.MinReplicaCount, &out.MinReplicaCount
 		*out = new(int32)
 		**out = **in
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ExecTimeout != nil {
-		in, out := &in.ExecTimeout, &out.ExecTimeout
-		*out = new(int)
+	if in.MaxReplicaCount != nil {
+		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.SidecarContainers != nil {
-		in, out := &in.SidecarContainers, &out.SidecarContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Remote != nil {
-		in, out := &in.Remote, &out.Remote
-		*out = new(string)
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]ArgoCDKEDATrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.SystemCATrust != nil {
-		in, out := &in.SystemCATrust, &out.SystemCATrust
-		*out = new(ArgoCDSystemCATrustSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = new(ArgoCDMetricsSpec)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
-func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKEDASpec.
+func (in *ArgoCDKEDASpec) DeepCopy() *ArgoCDKEDASpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRepoSpec)
+	out := new(ArgoCDKEDASpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRouteSpec) DeepCopyInto(out *ArgoCDRouteSpec) {
+func (in *ArgoCDKEDATrigger) DeepCopyInto(out *ArgoCDKEDATrigger) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(routev1.TLSConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.WildcardPolicy != nil {
-		in, out := &in.WildcardPolicy, &out.WildcardPolicy
-		*out = new(routev1.WildcardPolicyType)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRouteSpec.
-func (in *ArgoCDRouteSpec) DeepCopy() *ArgoCDRouteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKEDATrigger.
+func (in *ArgoCDKEDATrigger) DeepCopy() *ArgoCDKEDATrigger {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRouteSpec)
+	out := new(ArgoCDKEDATrigger)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
+func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
 	*out = *in
-	if in.Dex != nil {
-		in, out := &in.Dex, &out.Dex
-		*out = new(ArgoCDDexSpec)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Keycloak != nil {
-		in, out := &in.Keycloak, &out.Keycloak
-		*out = new(ArgoCDKeycloakSpec)
-		(*in).DeepCopyInto(*out)
+	if in.VerifyTLS != nil {
+		in, out := &in.VerifyTLS, &out.VerifyTLS
+		*out = new(bool)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
-func (in *ArgoCDSSOSpec) DeepCopy() *ArgoCDSSOSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
+func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDSSOSpec)
+	out := new(ArgoCDKeycloakSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerAutoscaleSpec) DeepCopyInto(out *ArgoCDServerAutoscaleSpec) {
+func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
 	*out = *in
-	if in.HPA != nil {
-		in, out := &in.HPA, &out.HPA
-		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCD, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerAutoscaleSpec.
-func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
+func (in *ArgoCDList) DeepCopy() *ArgoCDList {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerAutoscaleSpec)
+	out := new(ArgoCDList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerGRPCSpec) DeepCopyInto(out *ArgoCDServerGRPCSpec) {
+func (in *ArgoCDMetricsSpec) DeepCopyInto(out *ArgoCDMetricsSpec) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerGRPCSpec.
-func (in *ArgoCDServerGRPCSpec) DeepCopy() *ArgoCDServerGRPCSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMetricsSpec.
+func (in *ArgoCDMetricsSpec) DeepCopy() *ArgoCDMetricsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerGRPCSpec)
+	out := new(ArgoCDMetricsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerServiceSpec) DeepCopyInto(out *ArgoCDServerServiceSpec) {
+func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
 	*out = *in
+	if in.DisableMetrics != nil {
+		in, out := &in.DisableMetrics, &out.DisableMetrics
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerServiceSpec.
-func (in *ArgoCDServerServiceSpec) DeepCopy() *ArgoCDServerServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
+func (in *ArgoCDMonitoringSpec) DeepCopy() *ArgoCDMonitoringSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerServiceSpec)
+	out := new(ArgoCDMonitoringSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
+func (in *ArgoCDMutationWebhookSpec) DeepCopyInto(out *ArgoCDMutationWebhookSpec) {
 	*out = *in
-	in.Autoscale.DeepCopyInto(&out.Autoscale)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMutationWebhookSpec.
+func (in *ArgoCDMutationWebhookSpec) DeepCopy() *ArgoCDMutationWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMutationWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNetworkPolicySpec) DeepCopyInto(out *ArgoCDNetworkPolicySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNetworkPolicySpec.
+func (in *ArgoCDNetworkPolicySpec) DeepCopy() *ArgoCDNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
+func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNodePlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SourceNamespaces != nil {
+		in, out := &in.SourceNamespaces, &out.SourceNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(ArgoCDMetricsSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
+func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOIDCClaim) DeepCopyInto(out *ArgoCDOIDCClaim) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOIDCClaim.
+func (in *ArgoCDOIDCClaim) DeepCopy() *ArgoCDOIDCClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOIDCClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOIDCRootCASource) DeepCopyInto(out *ArgoCDOIDCRootCASource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOIDCRootCASource.
+func (in *ArgoCDOIDCRootCASource) DeepCopy() *ArgoCDOIDCRootCASource {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOIDCRootCASource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOIDCSpec) DeepCopyInto(out *ArgoCDOIDCSpec) {
+	*out = *in
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestedScopes != nil {
+		in, out := &in.RequestedScopes, &out.RequestedScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestedIDTokenClaims != nil {
+		in, out := &in.RequestedIDTokenClaims, &out.RequestedIDTokenClaims
+		*out = make(map[string]ArgoCDOIDCClaim, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RootCA != nil {
+		in, out := &in.RootCA, &out.RootCA
+		*out = new(ArgoCDOIDCRootCASource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOIDCSpec.
+func (in *ArgoCDOIDCSpec) DeepCopy() *ArgoCDOIDCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOIDCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOperatorConfig) DeepCopyInto(out *ArgoCDOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOperatorConfig.
+func (in *ArgoCDOperatorConfig) DeepCopy() *ArgoCDOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOperatorConfigClusterRoles) DeepCopyInto(out *ArgoCDOperatorConfigClusterRoles) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOperatorConfigClusterRoles.
+func (in *ArgoCDOperatorConfigClusterRoles) DeepCopy() *ArgoCDOperatorConfigClusterRoles {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOperatorConfigClusterRoles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOperatorConfigImages) DeepCopyInto(out *ArgoCDOperatorConfigImages) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOperatorConfigImages.
+func (in *ArgoCDOperatorConfigImages) DeepCopy() *ArgoCDOperatorConfigImages {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOperatorConfigImages)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOperatorConfigList) DeepCopyInto(out *ArgoCDOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCDOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOperatorConfigList.
+func (in *ArgoCDOperatorConfigList) DeepCopy() *ArgoCDOperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOperatorConfigSpec) DeepCopyInto(out *ArgoCDOperatorConfigSpec) {
+	*out = *in
+	if in.ClusterConfigNamespaces != nil {
+		in, out := &in.ClusterConfigNamespaces, &out.ClusterConfigNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemoryOptimizationEnabled != nil {
+		in, out := &in.MemoryOptimizationEnabled, &out.MemoryOptimizationEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConversionWebhookEnabled != nil {
+		in, out := &in.ConversionWebhookEnabled, &out.ConversionWebhookEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RemoveManagedByLabelOnArgoCDDeletion != nil {
+		in, out := &in.RemoveManagedByLabelOnArgoCDDeletion, &out.RemoveManagedByLabelOnArgoCDDeletion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NamespaceManagementEnabled != nil {
+		in, out := &in.NamespaceManagementEnabled, &out.NamespaceManagementEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InsecureMutationWebhooksAllowed != nil {
+		in, out := &in.InsecureMutationWebhooksAllowed, &out.InsecureMutationWebhooksAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(ArgoCDOperatorConfigImages)
+		**out = **in
+	}
+	if in.ClusterRoles != nil {
+		in, out := &in.ClusterRoles, &out.ClusterRoles
+		*out = new(ArgoCDOperatorConfigClusterRoles)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOperatorConfigSpec.
+func (in *ArgoCDOperatorConfigSpec) DeepCopy() *ArgoCDOperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOperatorConfigStatus) DeepCopyInto(out *ArgoCDOperatorConfigStatus) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make([]ArgoCDOperatorSetting, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageMigration != nil {
+		in, out := &in.StorageMigration, &out.StorageMigration
+		*out = new(ArgoCDStorageMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOperatorConfigStatus.
+func (in *ArgoCDOperatorConfigStatus) DeepCopy() *ArgoCDOperatorConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOperatorConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOperatorSetting) DeepCopyInto(out *ArgoCDOperatorSetting) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOperatorSetting.
+func (in *ArgoCDOperatorSetting) DeepCopy() *ArgoCDOperatorSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOperatorSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProfileOverridesSpec) DeepCopyInto(out *ArgoCDProfileOverridesSpec) {
+	*out = *in
+	if in.HA != nil {
+		in, out := &in.HA, &out.HA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ControllerSharding != nil {
+		in, out := &in.ControllerSharding, &out.ControllerSharding
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProfileOverridesSpec.
+func (in *ArgoCDProfileOverridesSpec) DeepCopy() *ArgoCDProfileOverridesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProfileOverridesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProfileStatus) DeepCopyInto(out *ArgoCDProfileStatus) {
+	*out = *in
+	if in.RepoReplicas != nil {
+		in, out := &in.RepoReplicas, &out.RepoReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RepoExecTimeout != nil {
+		in, out := &in.RepoExecTimeout, &out.RepoExecTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ArgoCDComponentResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProfileStatus.
+func (in *ArgoCDProfileStatus) DeepCopy() *ArgoCDProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProjectClusterResource) DeepCopyInto(out *ArgoCDProjectClusterResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectClusterResource.
+func (in *ArgoCDProjectClusterResource) DeepCopy() *ArgoCDProjectClusterResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProjectClusterResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProjectDestination) DeepCopyInto(out *ArgoCDProjectDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectDestination.
+func (in *ArgoCDProjectDestination) DeepCopy() *ArgoCDProjectDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProjectDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProjectRole) DeepCopyInto(out *ArgoCDProjectRole) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectRole.
+func (in *ArgoCDProjectRole) DeepCopy() *ArgoCDProjectRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProjectRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProjectSpec) DeepCopyInto(out *ArgoCDProjectSpec) {
+	*out = *in
+	if in.SourceRepos != nil {
+		in, out := &in.SourceRepos, &out.SourceRepos
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]ArgoCDProjectDestination, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterResourceWhitelist != nil {
+		in, out := &in.ClusterResourceWhitelist, &out.ClusterResourceWhitelist
+		*out = make([]ArgoCDProjectClusterResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterResourceBlacklist != nil {
+		in, out := &in.ClusterResourceBlacklist, &out.ClusterResourceBlacklist
+		*out = make([]ArgoCDProjectClusterResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ArgoCDProjectRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncWindows != nil {
+		in, out := &in.SyncWindows, &out.SyncWindows
+		*out = make([]ArgoCDProjectSyncWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectSpec.
+func (in *ArgoCDProjectSpec) DeepCopy() *ArgoCDProjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProjectSyncWindow) DeepCopyInto(out *ArgoCDProjectSyncWindow) {
+	*out = *in
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectSyncWindow.
+func (in *ArgoCDProjectSyncWindow) DeepCopy() *ArgoCDProjectSyncWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProjectSyncWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
+	*out = *in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPrometheusSpec.
+func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDPrometheusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACPermission) DeepCopyInto(out *ArgoCDRBACPermission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACPermission.
+func (in *ArgoCDRBACPermission) DeepCopy() *ArgoCDRBACPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACPolicyConfigMapRef) DeepCopyInto(out *ArgoCDRBACPolicyConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACPolicyConfigMapRef.
+func (in *ArgoCDRBACPolicyConfigMapRef) DeepCopy() *ArgoCDRBACPolicyConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACPolicyConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACPolicyOverlay) DeepCopyInto(out *ArgoCDRBACPolicyOverlay) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACPolicyOverlay.
+func (in *ArgoCDRBACPolicyOverlay) DeepCopy() *ArgoCDRBACPolicyOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACPolicyOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACRole) DeepCopyInto(out *ArgoCDRBACRole) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]ArgoCDRBACPermission, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACRole.
+func (in *ArgoCDRBACRole) DeepCopy() *ArgoCDRBACRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
+	*out = *in
+	if in.DefaultPolicy != nil {
+		in, out := &in.DefaultPolicy, &out.DefaultPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = new(string)
+		**out = **in
+	}
+	if in.PolicyMatcherMode != nil {
+		in, out := &in.PolicyMatcherMode, &out.PolicyMatcherMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ArgoCDRBACRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PolicyOverlays != nil {
+		in, out := &in.PolicyOverlays, &out.PolicyOverlays
+		*out = make([]ArgoCDRBACPolicyOverlay, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
+func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisAuthSpec) DeepCopyInto(out *ArgoCDRedisAuthSpec) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisAuthSpec.
+func (in *ArgoCDRedisAuthSpec) DeepCopy() *ArgoCDRedisAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisExternalSpec) DeepCopyInto(out *ArgoCDRedisExternalSpec) {
+	*out = *in
+	if in.Sentinel != nil {
+		in, out := &in.Sentinel, &out.Sentinel
+		*out = new(ArgoCDRedisSentinelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ArgoCDRedisExternalTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExternalSpec.
+func (in *ArgoCDRedisExternalSpec) DeepCopy() *ArgoCDRedisExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisExternalTLSSpec) DeepCopyInto(out *ArgoCDRedisExternalTLSSpec) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExternalTLSSpec.
+func (in *ArgoCDRedisExternalTLSSpec) DeepCopy() *ArgoCDRedisExternalTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisExternalTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisSentinelSpec) DeepCopyInto(out *ArgoCDRedisSentinelSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSentinelSpec.
+func (in *ArgoCDRedisSentinelSpec) DeepCopy() *ArgoCDRedisSentinelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisSentinelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(ArgoCDVPASpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(string)
+		**out = **in
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ArgoCDRedisExternalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ArgoCDRedisAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
+func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoPlugin) DeepCopyInto(out *ArgoCDRepoPlugin) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoPlugin.
+func (in *ArgoCDRepoPlugin) DeepCopy() *ArgoCDRepoPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
+	*out = *in
+	if in.ExtraRepoCommandArgs != nil {
+		in, out := &in.ExtraRepoCommandArgs, &out.ExtraRepoCommandArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Autoscale != nil {
+		in, out := &in.Autoscale, &out.Autoscale
+		*out = new(ArgoCDAutoscaleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(ArgoCDVPASpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecTimeout != nil {
+		in, out := &in.ExecTimeout, &out.ExecTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarContainers != nil {
+		in, out := &in.SidecarContainers, &out.SidecarContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]ArgoCDRepoPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SystemCATrust != nil {
+		in, out := &in.SystemCATrust, &out.SystemCATrust
+		*out = new(ArgoCDSystemCATrustSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(ArgoCDMetricsSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
+func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepositoryCredentialTemplateSpec) DeepCopyInto(out *ArgoCDRepositoryCredentialTemplateSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepositoryCredentialTemplateSpec.
+func (in *ArgoCDRepositoryCredentialTemplateSpec) DeepCopy() *ArgoCDRepositoryCredentialTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepositoryCredentialTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepositorySpec) DeepCopyInto(out *ArgoCDRepositorySpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepositorySpec.
+func (in *ArgoCDRepositorySpec) DeepCopy() *ArgoCDRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepositoryStatus) DeepCopyInto(out *ArgoCDRepositoryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepositoryStatus.
+func (in *ArgoCDRepositoryStatus) DeepCopy() *ArgoCDRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDResourceRecommendation) DeepCopyInto(out *ArgoCDResourceRecommendation) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ArgoCDContainerResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDResourceRecommendation.
+func (in *ArgoCDResourceRecommendation) DeepCopy() *ArgoCDResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRouteSpec) DeepCopyInto(out *ArgoCDRouteSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(routev1.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WildcardPolicy != nil {
+		in, out := &in.WildcardPolicy, &out.WildcardPolicy
+		*out = new(routev1.WildcardPolicyType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRouteSpec.
+func (in *ArgoCDRouteSpec) DeepCopy() *ArgoCDRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
+	*out = *in
+	if in.Dex != nil {
+		in, out := &in.Dex, &out.Dex
+		*out = new(ArgoCDDexSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = new(ArgoCDKeycloakSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
+func (in *ArgoCDSSOSpec) DeepCopy() *ArgoCDSSOSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSSOSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerAutoscaleSpec) DeepCopyInto(out *ArgoCDServerAutoscaleSpec) {
+	*out = *in
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerAutoscaleSpec.
+func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerGRPCSpec) DeepCopyInto(out *ArgoCDServerGRPCSpec) {
+	*out = *in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerGRPCSpec.
+func (in *ArgoCDServerGRPCSpec) DeepCopy() *ArgoCDServerGRPCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerGRPCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerServiceSpec) DeepCopyInto(out *ArgoCDServerServiceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerServiceSpec.
+func (in *ArgoCDServerServiceSpec) DeepCopy() *ArgoCDServerServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
+	*out = *in
+	in.Autoscale.DeepCopyInto(&out.Autoscale)
 	in.GRPC.DeepCopyInto(&out.GRPC)
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
@@ -1178,285 +2621,819 @@ func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(ArgoCDVPASpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Route.DeepCopyInto(&out.Route)
+	out.Service = in.Service
+	if in.SidecarContainers != nil {
+		in, out := &in.SidecarContainers, &out.SidecarContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraCommandArgs != nil {
+		in, out := &in.ExtraCommandArgs, &out.ExtraCommandArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(ArgoCDMetricsSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerSpec.
+func (in *ArgoCDServerSpec) DeepCopy() *ArgoCDServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSet) DeepCopyInto(out *ArgoCDSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSet.
+func (in *ArgoCDSet) DeepCopy() *ArgoCDSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSetInstanceStatus) DeepCopyInto(out *ArgoCDSetInstanceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSetInstanceStatus.
+func (in *ArgoCDSetInstanceStatus) DeepCopy() *ArgoCDSetInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSetInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSetList) DeepCopyInto(out *ArgoCDSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCDSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSetList.
+func (in *ArgoCDSetList) DeepCopy() *ArgoCDSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSetRolloutSpec) DeepCopyInto(out *ArgoCDSetRolloutSpec) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSetRolloutSpec.
+func (in *ArgoCDSetRolloutSpec) DeepCopy() *ArgoCDSetRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSetRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSetSpec) DeepCopyInto(out *ArgoCDSetSpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.Template.DeepCopyInto(&out.Template)
+	in.Rollout.DeepCopyInto(&out.Rollout)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSetSpec.
+func (in *ArgoCDSetSpec) DeepCopy() *ArgoCDSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSetStatus) DeepCopyInto(out *ArgoCDSetStatus) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]ArgoCDSetInstanceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSetStatus.
+func (in *ArgoCDSetStatus) DeepCopy() *ArgoCDSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSetTemplate) DeepCopyInto(out *ArgoCDSetTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSetTemplate.
+func (in *ArgoCDSetTemplate) DeepCopy() *ArgoCDSetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
+	*out = *in
+	if in.ApplicationSet != nil {
+		in, out := &in.ApplicationSet, &out.ApplicationSet
+		*out = new(ArgoCDApplicationSet)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Controller.DeepCopyInto(&out.Controller)
+	if in.ExtraConfig != nil {
+		in, out := &in.ExtraConfig, &out.ExtraConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Grafana.DeepCopyInto(&out.Grafana)
+	in.HA.DeepCopyInto(&out.HA)
+	in.ImageUpdater.DeepCopyInto(&out.ImageUpdater)
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(ArgoCDImportSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.InitialSSHKnownHosts = in.InitialSSHKnownHosts
+	if in.KustomizeVersions != nil {
+		in, out := &in.KustomizeVersions, &out.KustomizeVersions
+		*out = make([]KustomizeVersionSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalUsers != nil {
+		in, out := &in.LocalUsers, &out.LocalUsers
+		*out = make([]LocalUserSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(ArgoCDOIDCSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	if in.MutationWebhooks != nil {
+		in, out := &in.MutationWebhooks, &out.MutationWebhooks
+		*out = make([]ArgoCDMutationWebhookSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(ArgoCDNodePlacementSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Route.DeepCopyInto(&out.Route)
-	out.Service = in.Service
-	if in.SidecarContainers != nil {
-		in, out := &in.SidecarContainers, &out.SidecarContainers
-		*out = make([]v1.Container, len(*in))
+	in.Notifications.DeepCopyInto(&out.Notifications)
+	if in.ProfileOverrides != nil {
+		in, out := &in.ProfileOverrides, &out.ProfileOverrides
+		*out = new(ArgoCDProfileOverridesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]ArgoCDProjectSpec, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+	in.Prometheus.DeepCopyInto(&out.Prometheus)
+	in.RBAC.DeepCopyInto(&out.RBAC)
+	in.Redis.DeepCopyInto(&out.Redis)
+	in.Repo.DeepCopyInto(&out.Repo)
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]ArgoCDRepositorySpec, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ExtraCommandArgs != nil {
-		in, out := &in.ExtraCommandArgs, &out.ExtraCommandArgs
+	if in.RepositoryCredentialTemplates != nil {
+		in, out := &in.RepositoryCredentialTemplates, &out.RepositoryCredentialTemplates
+		*out = make([]ArgoCDRepositoryCredentialTemplateSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceHealthChecks != nil {
+		in, out := &in.ResourceHealthChecks, &out.ResourceHealthChecks
+		*out = make([]ResourceHealthCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceIgnoreDifferences != nil {
+		in, out := &in.ResourceIgnoreDifferences, &out.ResourceIgnoreDifferences
+		*out = new(ResourceIgnoreDifference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceActions != nil {
+		in, out := &in.ResourceActions, &out.ResourceActions
+		*out = make([]ResourceAction, len(*in))
+		copy(*out, *in)
+	}
+	in.Server.DeepCopyInto(&out.Server)
+	if in.SourceNamespaces != nil {
+		in, out := &in.SourceNamespaces, &out.SourceNamespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
+	if in.SSO != nil {
+		in, out := &in.SSO, &out.SSO
+		*out = new(ArgoCDSSOSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.TLS.DeepCopyInto(&out.TLS)
+	if in.Banner != nil {
+		in, out := &in.Banner, &out.Banner
+		*out = new(Banner)
+		**out = **in
+	}
+	if in.CmdParams != nil {
+		in, out := &in.CmdParams, &out.CmdParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ArgoCDAgent != nil {
+		in, out := &in.ArgoCDAgent, &out.ArgoCDAgent
+		*out = new(ArgoCDAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceManagement != nil {
+		in, out := &in.NamespaceManagement, &out.NamespaceManagement
+		*out = make([]ManagedNamespaces, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebhookSecrets != nil {
+		in, out := &in.WebhookSecrets, &out.WebhookSecrets
+		*out = new(ArgoCDWebhookSecretsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebTerminalEnabled != nil {
+		in, out := &in.WebTerminalEnabled, &out.WebTerminalEnabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSpec.
+func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]ArgoCDRepositoryStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.RepositoryCredentialTemplates != nil {
+		in, out := &in.RepositoryCredentialTemplates, &out.RepositoryCredentialTemplates
+		*out = make([]ArgoCDRepositoryStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(ArgoCDProfileStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = make([]ArgoCDResourceRecommendation, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AgentPKI != nil {
+		in, out := &in.AgentPKI, &out.AgentPKI
+		*out = make([]ArgoCDAgentPKIStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = new(ArgoCDAgentsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentNamespaces != nil {
+		in, out := &in.AgentNamespaces, &out.AgentNamespaces
+		*out = make([]ArgoCDAgentNamespacesStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.AgentModeMigration != nil {
+		in, out := &in.AgentModeMigration, &out.AgentModeMigration
+		*out = new(AgentModeMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = new(ArgoCDMetricsSpec)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
+func (in *ArgoCDStatus) DeepCopy() *ArgoCDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDStorageMigrationObject) DeepCopyInto(out *ArgoCDStorageMigrationObject) {
+	*out = *in
+	if in.LossyFields != nil {
+		in, out := &in.LossyFields, &out.LossyFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStorageMigrationObject.
+func (in *ArgoCDStorageMigrationObject) DeepCopy() *ArgoCDStorageMigrationObject {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDStorageMigrationObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDStorageMigrationStatus) DeepCopyInto(out *ArgoCDStorageMigrationStatus) {
+	*out = *in
+	if in.StoredVersions != nil {
+		in, out := &in.StoredVersions, &out.StoredVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Migrated != nil {
+		in, out := &in.Migrated, &out.Migrated
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Skipped != nil {
+		in, out := &in.Skipped, &out.Skipped
+		*out = make([]ArgoCDStorageMigrationObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStorageMigrationStatus.
+func (in *ArgoCDStorageMigrationStatus) DeepCopy() *ArgoCDStorageMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDStorageMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSystemCATrustSpec) DeepCopyInto(out *ArgoCDSystemCATrustSpec) {
+	*out = *in
+	if in.ClusterTrustBundles != nil {
+		in, out := &in.ClusterTrustBundles, &out.ClusterTrustBundles
+		*out = make([]v1.ClusterTrustBundleProjection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]v1.SecretProjection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]v1.ConfigMapProjection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerSpec.
-func (in *ArgoCDServerSpec) DeepCopy() *ArgoCDServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSystemCATrustSpec.
+func (in *ArgoCDSystemCATrustSpec) DeepCopy() *ArgoCDSystemCATrustSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerSpec)
+	out := new(ArgoCDSystemCATrustSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
+func (in *ArgoCDTLSSpec) DeepCopyInto(out *ArgoCDTLSSpec) {
 	*out = *in
-	if in.ApplicationSet != nil {
-		in, out := &in.ApplicationSet, &out.ApplicationSet
-		*out = new(ArgoCDApplicationSet)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Controller.DeepCopyInto(&out.Controller)
-	if in.ExtraConfig != nil {
-		in, out := &in.ExtraConfig, &out.ExtraConfig
+	out.CA = in.CA
+	if in.InitialCerts != nil {
+		in, out := &in.InitialCerts, &out.InitialCerts
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	in.Grafana.DeepCopyInto(&out.Grafana)
-	in.HA.DeepCopyInto(&out.HA)
-	in.ImageUpdater.DeepCopyInto(&out.ImageUpdater)
-	if in.Import != nil {
-		in, out := &in.Import, &out.Import
-		*out = new(ArgoCDImportSpec)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTLSSpec.
+func (in *ArgoCDTLSSpec) DeepCopy() *ArgoCDTLSSpec {
+	if in == nil {
+		return nil
 	}
-	out.InitialSSHKnownHosts = in.InitialSSHKnownHosts
-	if in.KustomizeVersions != nil {
-		in, out := &in.KustomizeVersions, &out.KustomizeVersions
-		*out = make([]KustomizeVersionSpec, len(*in))
-		copy(*out, *in)
+	out := new(ArgoCDTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTemplate) DeepCopyInto(out *ArgoCDTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTemplate.
+func (in *ArgoCDTemplate) DeepCopy() *ArgoCDTemplate {
+	if in == nil {
+		return nil
 	}
-	if in.LocalUsers != nil {
-		in, out := &in.LocalUsers, &out.LocalUsers
-		*out = make([]LocalUserSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(ArgoCDTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	in.Monitoring.DeepCopyInto(&out.Monitoring)
-	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTemplateDefaults) DeepCopyInto(out *ArgoCDTemplateDefaults) {
+	*out = *in
 	if in.NodePlacement != nil {
 		in, out := &in.NodePlacement, &out.NodePlacement
 		*out = new(ArgoCDNodePlacementSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Notifications.DeepCopyInto(&out.Notifications)
-	in.Prometheus.DeepCopyInto(&out.Prometheus)
-	in.RBAC.DeepCopyInto(&out.RBAC)
-	in.Redis.DeepCopyInto(&out.Redis)
-	in.Repo.DeepCopyInto(&out.Repo)
-	if in.ResourceHealthChecks != nil {
-		in, out := &in.ResourceHealthChecks, &out.ResourceHealthChecks
-		*out = make([]ResourceHealthCheck, len(*in))
-		copy(*out, *in)
-	}
-	if in.ResourceIgnoreDifferences != nil {
-		in, out := &in.ResourceIgnoreDifferences, &out.ResourceIgnoreDifferences
-		*out = new(ResourceIgnoreDifference)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ArgoCDTemplateResources)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ResourceActions != nil {
-		in, out := &in.ResourceActions, &out.ResourceActions
-		*out = make([]ResourceAction, len(*in))
-		copy(*out, *in)
-	}
-	in.Server.DeepCopyInto(&out.Server)
-	if in.SourceNamespaces != nil {
-		in, out := &in.SourceNamespaces, &out.SourceNamespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.SSO != nil {
-		in, out := &in.SSO, &out.SSO
-		*out = new(ArgoCDSSOSpec)
+	if in.RBAC != nil {
+		in, out := &in.RBAC, &out.RBAC
+		*out = new(ArgoCDRBACSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	in.TLS.DeepCopyInto(&out.TLS)
 	if in.Banner != nil {
 		in, out := &in.Banner, &out.Banner
 		*out = new(Banner)
 		**out = **in
 	}
-	if in.CmdParams != nil {
-		in, out := &in.CmdParams, &out.CmdParams
+	if in.ExtraConfig != nil {
+		in, out := &in.ExtraConfig, &out.ExtraConfig
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.ArgoCDAgent != nil {
-		in, out := &in.ArgoCDAgent, &out.ArgoCDAgent
-		*out = new(ArgoCDAgentSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTemplateDefaults.
+func (in *ArgoCDTemplateDefaults) DeepCopy() *ArgoCDTemplateDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDTemplateDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTemplateList) DeepCopyInto(out *ArgoCDTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCDTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTemplateList.
+func (in *ArgoCDTemplateList) DeepCopy() *ArgoCDTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTemplateResources) DeepCopyInto(out *ArgoCDTemplateResources) {
+	*out = *in
+	if in.ApplicationSet != nil {
+		in, out := &in.ApplicationSet, &out.ApplicationSet
+		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.NamespaceManagement != nil {
-		in, out := &in.NamespaceManagement, &out.NamespaceManagement
-		*out = make([]ManagedNamespaces, len(*in))
-		copy(*out, *in)
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.WebhookSecrets != nil {
-		in, out := &in.WebhookSecrets, &out.WebhookSecrets
-		*out = new(ArgoCDWebhookSecretsSpec)
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.WebTerminalEnabled != nil {
-		in, out := &in.WebTerminalEnabled, &out.WebTerminalEnabled
-		*out = new(bool)
-		**out = **in
+	if in.Repo != nil {
+		in, out := &in.Repo, &out.Repo
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSpec.
-func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTemplateResources.
+func (in *ArgoCDTemplateResources) DeepCopy() *ArgoCDTemplateResources {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDSpec)
+	out := new(ArgoCDTemplateResources)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
+func (in *ArgoCDTemplateSpec) DeepCopyInto(out *ArgoCDTemplateSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
+	in.Defaults.DeepCopyInto(&out.Defaults)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
-func (in *ArgoCDStatus) DeepCopy() *ArgoCDStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTemplateSpec.
+func (in *ArgoCDTemplateSpec) DeepCopy() *ArgoCDTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDStatus)
+	out := new(ArgoCDTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDSystemCATrustSpec) DeepCopyInto(out *ArgoCDSystemCATrustSpec) {
+func (in *ArgoCDTemplateStatus) DeepCopyInto(out *ArgoCDTemplateStatus) {
 	*out = *in
-	if in.ClusterTrustBundles != nil {
-		in, out := &in.ClusterTrustBundles, &out.ClusterTrustBundles
-		*out = make([]v1.ClusterTrustBundleProjection, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
-		*out = make([]v1.SecretProjection, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ConfigMaps != nil {
-		in, out := &in.ConfigMaps, &out.ConfigMaps
-		*out = make([]v1.ConfigMapProjection, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSystemCATrustSpec.
-func (in *ArgoCDSystemCATrustSpec) DeepCopy() *ArgoCDSystemCATrustSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTemplateStatus.
+func (in *ArgoCDTemplateStatus) DeepCopy() *ArgoCDTemplateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDSystemCATrustSpec)
+	out := new(ArgoCDTemplateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDTLSSpec) DeepCopyInto(out *ArgoCDTLSSpec) {
+func (in *ArgoCDVPASpec) DeepCopyInto(out *ArgoCDVPASpec) {
 	*out = *in
-	out.CA = in.CA
-	if in.InitialCerts != nil {
-		in, out := &in.InitialCerts, &out.InitialCerts
-		*out = make(map[string]string, len(*in))
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTLSSpec.
-func (in *ArgoCDTLSSpec) DeepCopy() *ArgoCDTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDVPASpec.
+func (in *ArgoCDVPASpec) DeepCopy() *ArgoCDVPASpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDTLSSpec)
+	out := new(ArgoCDVPASpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1865,6 +3842,11 @@ func (in *PrincipalJWTSpec) DeepCopyInto(out *PrincipalJWTSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalJWTSpec.
@@ -1877,6 +3859,31 @@ func (in *PrincipalJWTSpec) DeepCopy() *PrincipalJWTSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrincipalNamespacePolicy) DeepCopyInto(out *PrincipalNamespacePolicy) {
+	*out = *in
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalNamespacePolicy.
+func (in *PrincipalNamespacePolicy) DeepCopy() *PrincipalNamespacePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PrincipalNamespacePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrincipalNamespaceSpec) DeepCopyInto(out *PrincipalNamespaceSpec) {
 	*out = *in
@@ -1895,6 +3902,13 @@ func (in *PrincipalNamespaceSpec) DeepCopyInto(out *PrincipalNamespaceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PrincipalNamespacePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalNamespaceSpec.
@@ -2039,6 +4053,21 @@ func (in *PrincipalTLSSpec) DeepCopyInto(out *PrincipalTLSSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CAValidity != nil {
+		in, out := &in.CAValidity, &out.CAValidity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalTLSSpec.
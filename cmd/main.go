@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -24,6 +25,7 @@ import (
 	goruntime "runtime"
 	"strings"
 
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/util/env"
 	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
@@ -37,8 +39,13 @@ import (
 
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocd"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagentregistration"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocdexport"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdoperatorconfig"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdset"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+	"github.com/argoproj-labs/argocd-operator/controllers/storagemigration"
 
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -50,6 +57,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"go.uber.org/zap/zapcore"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -76,9 +84,11 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(argocdv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -93,6 +103,7 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var labelSelectorFlag string
+	var migrateStorageVersion bool
 
 	var secureMetrics = false
 	var enableHTTP2 = false
@@ -106,6 +117,7 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", enableHTTP2, "If HTTP/2 should be enabled for the metrics and webhook servers.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", secureMetrics, "If the metrics endpoint should be served securely.")
+	flag.BoolVar(&migrateStorageVersion, "migrate-storage-version", false, "Migrate the ArgoCD objects to the storage version of the ArgoCD CRD and exit.")
 
 	//Configure log level
 	logLevelStr := strings.ToLower(os.Getenv("LOG_LEVEL"))
@@ -150,6 +162,14 @@ func main() {
 
 	printVersion()
 
+	if migrateStorageVersion {
+		if err := runStorageMigration(); err != nil {
+			setupLog.Error(err, "storage version migration failed")
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Check the label selector format eg. "foo=bar"
 	if _, err := labels.Parse(labelSelectorFlag); err != nil {
 		setupLog.Error(err, "error parsing the labelSelector '%s'.", labelSelectorFlag)
@@ -166,6 +186,12 @@ func main() {
 	if err != nil {
 		setupLog.Error(err, "Failed to get watch namespace, defaulting to all namespace mode")
 	}
+
+	// Apply the operator-wide settings of the ArgoCDOperatorConfig, the environment variables are used as a fallback.
+	if err := loadOperatorConfig(); err != nil {
+		setupLog.Error(err, "unable to load the ArgoCDOperatorConfig, using the environment variables")
+	}
+	argoutil.RecordOperatorStartupSettings()
 	setupLog.Info(fmt.Sprintf("Watching namespace \"%s\"", namespace))
 
 	// Set default manager options
@@ -186,7 +212,7 @@ func main() {
 
 	// Use transformers to strip data from Secrets and ConfigMaps
 	// that are not tracked by the operator to reduce memory usage.
-	if strings.ToLower(os.Getenv("MEMORY_OPTIMIZATION_ENABLED")) != "false" {
+	if strings.ToLower(argoutil.GetOperatorSetting(common.ArgoCDMemoryOptimizationEnabledEnvName)) != "false" {
 		setupLog.Info("memory optimization is enabled")
 		options.Cache = cache.Options{
 			Scheme: scheme,
@@ -197,6 +223,12 @@ func main() {
 		}
 	}
 
+	// The Applications migrated between agent modes are read from the API server, as the cache would watch all the
+	// Applications of the cluster.
+	options.Client = crclient.Options{Cache: &crclient.CacheOptions{
+		DisableFor: []crclient.Object{&argocdv1alpha1.Application{}},
+	}}
+
 	if watchedNsCache := getDefaultWatchedNamespacesCacheOptions(); watchedNsCache != nil {
 		options.Cache.DefaultNamespaces = watchedNsCache
 	}
@@ -208,7 +240,7 @@ func main() {
 	}
 
 	var client crclient.Client
-	if strings.ToLower(os.Getenv("MEMORY_OPTIMIZATION_ENABLED")) != "false" {
+	if strings.ToLower(argoutil.GetOperatorSetting(common.ArgoCDMemoryOptimizationEnabledEnvName)) != "false" {
 		liveClient, err := crclient.New(ctrl.GetConfigOrDie(), crclient.Options{Scheme: mgr.GetScheme()})
 		if err != nil {
 			setupLog.Error(err, "unable to create live client")
@@ -236,6 +268,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := argocdv1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+		setupLog.Error(err, "")
+		os.Exit(1)
+	}
+
 	// Setup Scheme for Prometheus if available.
 	if argocd.IsPrometheusAPIAvailable() {
 		if err := monitoringv1.AddToScheme(mgr.GetScheme()); err != nil {
@@ -265,13 +302,24 @@ func main() {
 		setupLog.Error(err, "Failed to initialize Kubernetes client")
 		os.Exit(1)
 	}
+	// The principals are scraped in the background, so that the reconciles read the connected agents from a cache. Their
+	// Pods are listed from the API server, as the cache of the manager would watch all the Pods of the cluster.
+	principalMetricsPoller := argocdagent.NewPrincipalMetricsPoller(client,
+		&argocdagent.PrincipalMetricsReader{Client: mgr.GetAPIReader()})
+	if err := mgr.Add(principalMetricsPoller); err != nil {
+		setupLog.Error(err, "unable to set up the principal metrics poller")
+		os.Exit(1)
+	}
+
 	if err = (&argocd.ReconcileArgoCD{
-		Client:            client,
-		Scheme:            mgr.GetScheme(),
-		LabelSelector:     labelSelectorFlag,
-		K8sClient:         k8sClient,
-		LocalUsers:        argocd.NewLocalUsersInfo(),
-		FipsConfigChecker: argoutil.NewLinuxFipsConfigChecker(),
+		Client:                client,
+		Scheme:                mgr.GetScheme(),
+		LabelSelector:         labelSelectorFlag,
+		K8sClient:             k8sClient,
+		LocalUsers:            argocd.NewLocalUsersInfo(),
+		FipsConfigChecker:     argoutil.NewLinuxFipsConfigChecker(),
+		AgentConnectionReader: principalMetricsPoller,
+		AgentConnectionEvents: principalMetricsPoller.Subscribe(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ArgoCD")
 		os.Exit(1)
@@ -291,8 +339,34 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&argocdoperatorconfig.ArgoCDOperatorConfigReconciler{
+		Client: client,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ArgoCDOperatorConfig")
+		os.Exit(1)
+	}
+
+	if err = (&argocdset.ArgoCDSetReconciler{
+		Client: client,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ArgoCDSet")
+		os.Exit(1)
+	}
+
+	if err = (&argocdagentregistration.ArgoCDAgentRegistrationReconciler{
+		Client:           client,
+		Scheme:           mgr.GetScheme(),
+		ConnectionReader: principalMetricsPoller,
+		ConnectionEvents: principalMetricsPoller.Subscribe(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ArgoCDAgentRegistration")
+		os.Exit(1)
+	}
+
 	// Start webhook only if ENABLE_CONVERSION_WEBHOOK is set
-	if strings.EqualFold(os.Getenv("ENABLE_CONVERSION_WEBHOOK"), "true") {
+	if strings.EqualFold(argoutil.GetOperatorSetting(common.ArgoCDEnableConversionWebhookEnvName), "true") {
 		if err = (&v1beta1.ArgoCD{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ArgoCD")
 			os.Exit(1)
@@ -300,6 +374,17 @@ func main() {
 	}
 	//+kubebuilder:scaffold:builder
 
+	// Migrate the ArgoCD objects still stored in an older version, e.g. after an upgrade. The CRD is not cached.
+	storageMigrationClient, err := crclient.New(ctrl.GetConfigOrDie(), crclient.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		setupLog.Error(err, "unable to create storage version migration client")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&storagemigration.StorageVersionMigrator{Client: storageMigrationClient}); err != nil {
+		setupLog.Error(err, "unable to set up storage version migration")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -352,6 +437,45 @@ func getWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// loadOperatorConfig applies the ArgoCDOperatorConfig before the manager is created, so that the settings only read at
+// startup are taken from it as well.
+func loadOperatorConfig() error {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	c, err := crclient.New(cfg, crclient.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	return argoutil.RefreshOperatorConfig(context.Background(), c)
+}
+
+// runStorageMigration migrates the ArgoCD objects to the storage version of the ArgoCD CRD once. It fails when some
+// ArgoCD objects could not be migrated.
+func runStorageMigration() error {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	c, err := crclient.New(cfg, crclient.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	status, err := (&storagemigration.StorageVersionMigrator{Client: c}).Migrate(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(status.Skipped) > 0 {
+		return fmt.Errorf("%d ArgoCD objects could not be migrated to %s", len(status.Skipped), status.StorageVersion)
+	}
+	return nil
+}
+
 func initK8sClient() (*kubernetes.Clientset, error) {
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -16,4 +16,32 @@ const (
 	// AnnotationOpenShiftOriginatingServiceName is the annotation on secrets used to
 	// identify the service that created the secret.
 	AnnotationOpenShiftOriginatingServiceName = "service.beta.openshift.io/originating-service-name"
+
+	// AnnotationRBACPolicyOverlays is the annotation on the RBAC ConfigMap that lists the
+	// policy overlay keys written by the operator
+	AnnotationRBACPolicyOverlays = "argocds.argoproj.io/rbac-policy-overlays"
+
+	// AnnotationRotateRedisPassword is the annotation on the ArgoCD requesting a rotation of the Redis password
+	// whenever its value changes. The last handled value is recorded on the Redis password Secret.
+	AnnotationRotateRedisPassword = "argocds.argoproj.io/rotate-redis-password"
+
+	// AnnotationRedisPasswordRotatedAt is the annotation on the Redis password Secret recording when the password
+	// was last rotated
+	AnnotationRedisPasswordRotatedAt = "argocds.argoproj.io/redis-password-rotated-at"
+
+	// AnnotationRedisAuthClientsRevision is the annotation on the Redis password Secret recording the rotation
+	// that the argocd components have been released to
+	AnnotationRedisAuthClientsRevision = "argocds.argoproj.io/redis-auth-clients-revision"
+
+	// AnnotationAgentJWTKeyRotatedAt is the annotation on the JWT signing key Secret of the Argo CD Agent principal
+	// recording when the operator last generated the key
+	AnnotationAgentJWTKeyRotatedAt = "argocds.argoproj.io/agent-jwt-key-rotated-at"
+
+	// AnnotationMigrateDeprecatedFields is the annotation on the ArgoCD opting in to the automatic rewrite of its
+	// deprecated fields into their replacements when set to "true"
+	AnnotationMigrateDeprecatedFields = "argocds.argoproj.io/migrate-deprecated-fields"
+
+	// AnnotationDeprecatedFieldsMigrationPreview is the annotation on the ArgoCD holding the JSON merge patch the
+	// operator would apply to it to rewrite its deprecated fields into their replacements
+	AnnotationDeprecatedFieldsMigrationPreview = "argocds.argoproj.io/deprecated-fields-migration-preview"
 )
@@ -42,6 +42,9 @@ const (
 	// ArgoCDRedisHAComponent is the name of the Redis HA control plane component
 	ArgoCDRedisHAComponent = "argocd-redis-ha"
 
+	// ArgoCDRepoServerComponent is the name of the Repo server control plane component
+	ArgoCDRepoServerComponent = "argocd-repo-server"
+
 	// ArgoCDDexServerComponent is the name of the Dex server control plane component
 	ArgoCDDexServerComponent = "argocd-dex-server"
 
@@ -315,6 +318,9 @@ vs-ssh.visualstudio.com ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC7Hr1oTWqNqOlzGJOf
 	ArgoCDDefaultClusterDomain = "cluster.local"
 	// ArgoCDDefaultWebTerminalEnabled is the default web terminal enabled switch.
 	ArgoCDDefaultWebTerminalEnabled = "false"
+
+	// ArgoCDDefaultMutationWebhookTimeoutSeconds is the default time to wait for a mutation webhook to respond.
+	ArgoCDDefaultMutationWebhookTimeoutSeconds = 10
 )
 
 // DefaultLabels returns the default set of labels for controllers.
@@ -95,6 +95,12 @@ const (
 	// ArgoCDKeyOIDCConfig is the configuration key for the OIDC configuration.
 	ArgoCDKeyOIDCConfig = "oidc.config"
 
+	// ArgoCDKeyOIDCClientSecret is the Argo CD secret key holding the client secret of the typed OIDC configuration.
+	ArgoCDKeyOIDCClientSecret = "oidc.clientSecret" // #nosec G101
+
+	// ArgoCDKeyOIDCRootCA is the Argo CD secret key holding the root CA of the typed OIDC configuration.
+	ArgoCDKeyOIDCRootCA = "oidc.rootCA"
+
 	// ArgoCDKeyPartOf is the resource part-of key for labels.
 	ArgoCDKeyPartOf = "app.kubernetes.io/part-of"
 
@@ -250,6 +256,47 @@ const (
 	// ArgoCDCATrustChecksum is applied to repo-server Deployment to trigger reconciliation when some of the SystemCATrust sources changes
 	ArgoCDCATrustChecksum = "argocd.argoproj.io/ca-trust-checksum"
 
+	// ArgoCDRepoPluginsChecksum is applied to repo-server Deployment to trigger a rollout when the configuration of a Config Management Plugin changes
+	ArgoCDRepoPluginsChecksum = "argocd.argoproj.io/repo-plugins-checksum"
+
+	// ArgoCDRedisAuthChecksum is applied to the Redis workloads to trigger a rollout when the passwords accepted by Redis change
+	ArgoCDRedisAuthChecksum = "argocd.argoproj.io/redis-auth-checksum"
+
+	// ArgoCDRedisAuthRevision is applied to the workloads of the Redis clients to trigger a rollout once Redis accepts a rotated password
+	ArgoCDRedisAuthRevision = "argocd.argoproj.io/redis-auth-revision"
+
+	// ArgoCDAgentPKIChecksum is applied to the principal Deployment to trigger a rollout when its certificates or JWT signing key change
+	ArgoCDAgentPKIChecksum = "argocd.argoproj.io/agent-pki-checksum"
+
+	// ArgoCDAgentNameLabel is applied to the Argo CD cluster Secret of an agent, with the name of the agent as value
+	ArgoCDAgentNameLabel = "argocd-agent.argoproj-labs.io/agent-name"
+
+	// ArgoCDAgentModeLabel is applied to the Applications of an agent migrated between modes, on the principal and in
+	// the workload cluster, with the mode owning the Applications as value
+	ArgoCDAgentModeLabel = "argocd-agent.argoproj-labs.io/agent-mode"
+
+	// ArgoCDAgentModeMigrationFromAnnotation is applied to the Deployment of an agent migrated between modes, with the
+	// mode the agent is migrated from as value
+	ArgoCDAgentModeMigrationFromAnnotation = "argocd-agent.argoproj-labs.io/mode-migration-from"
+
+	// ArgoCDAgentModeMigrationStartedAnnotation is applied to the Deployment of an agent migrated between modes, with
+	// the time the migration started as value, in RFC 3339 format
+	ArgoCDAgentModeMigrationStartedAnnotation = "argocd-agent.argoproj-labs.io/mode-migration-started"
+
+	// ArgoCDAgentPrincipalNamespaceLabel is applied to the Roles and RoleBindings granting the principal access to the namespaces
+	// of its namespace policies, with the namespace of the ArgoCD as value
+	ArgoCDAgentPrincipalNamespaceLabel = "argocd.argoproj.io/agent-principal-namespace"
+
+	// ArgoCDSetLabel is applied to the ArgoCD instances created by an ArgoCDSet, with the name of the ArgoCDSet as value
+	ArgoCDSetLabel = "argocd.argoproj.io/argocdset"
+
+	// ArgoCDSetChecksum is applied to the ArgoCD instances created by an ArgoCDSet to detect when their rendered template changes
+	ArgoCDSetChecksum = "argocd.argoproj.io/argocdset-checksum"
+
+	// ArgoCDSetSpecChecksum is applied to the ArgoCD instances created by an ArgoCDSet to detect when their spec is changed
+	// by other means than the ArgoCDSet
+	ArgoCDSetSpecChecksum = "argocd.argoproj.io/argocdset-spec-checksum"
+
 	// ArgoCDControllerClusterRoleEnvName is an environment variable to specify a custom cluster role for Argo CD application controller
 	ArgoCDControllerClusterRoleEnvName = "CONTROLLER_CLUSTER_ROLE"
 
@@ -265,6 +312,9 @@ const (
 	// ArgoCDDexSecretKey is used to reference Dex secret from Argo CD secret into Argo CD configmap
 	ArgoCDDexSecretKey = "oidc.dex.clientSecret" // #nosec G101
 
+	// ArgoCDDexConnectorSecretKeyPrefix is the prefix of the Argo CD secret keys holding the sensitive fields of typed Dex connectors
+	ArgoCDDexConnectorSecretKeyPrefix = "dex.connector." // #nosec G101
+
 	// Label Selector is an env variable for ArgoCD instance reconcilliation.
 	ArgoCDLabelSelectorKey = "ARGOCD_LABEL_SELECTOR"
 
@@ -292,6 +342,34 @@ const (
 	// ArgoCDImagePullPolicyEnvName is the environment variable used to get the global image pull policy
 	// for all ArgoCD components managed by the operator.
 	ArgoCDImagePullPolicyEnvName = "IMAGE_PULL_POLICY"
+
+	// ArgoCDPrincipalImageEnvName is the environment variable used to get the image
+	// to used for the principal component of Argo CD Agent.
+	ArgoCDPrincipalImageEnvName = "ARGOCD_PRINCIPAL_IMAGE"
+
+	// ArgoCDAgentImageEnvName is the environment variable used to get the image
+	// to used for the agent component of Argo CD Agent.
+	ArgoCDAgentImageEnvName = "ARGOCD_AGENT_IMAGE"
+
+	// ArgoCDClusterConfigNamespacesEnvName is the environment variable listing the namespaces of the Argo CD
+	// instances granted cluster-scoped permissions.
+	ArgoCDClusterConfigNamespacesEnvName = "ARGOCD_CLUSTER_CONFIG_NAMESPACES"
+
+	// ArgoCDMemoryOptimizationEnabledEnvName is the environment variable controlling whether the data of the
+	// Secrets and ConfigMaps not tracked by the operator is stripped from the cache.
+	ArgoCDMemoryOptimizationEnabledEnvName = "MEMORY_OPTIMIZATION_ENABLED"
+
+	// ArgoCDEnableConversionWebhookEnvName is the environment variable controlling whether the conversion webhook is started.
+	ArgoCDEnableConversionWebhookEnvName = "ENABLE_CONVERSION_WEBHOOK"
+
+	// ArgoCDRemoveManagedByLabelOnDeletionEnvName is the environment variable controlling whether the managed-by
+	// label is removed from the managed namespaces when an ArgoCD is deleted.
+	ArgoCDRemoveManagedByLabelOnDeletionEnvName = "REMOVE_MANAGED_BY_LABEL_ON_ARGOCD_DELETION"
+
+	// ArgoCDAllowInsecureMutationWebhooksEnvName is the environment variable controlling whether the mutation webhooks
+	// of the Argo CD instances may be called over plain http.
+	ArgoCDAllowInsecureMutationWebhooksEnvName = "ALLOW_INSECURE_MUTATION_WEBHOOKS"
+
 	// ArgoCDWebTerminalEnabledKey is the configuration key for enabling the web terminal.
 	ArgoCDWebTerminalEnabledKey = "exec.enabled"
 	// ArgoCDWebTerminalEnabledDefaultValue is the default value for enabling the web terminal.
@@ -17,6 +17,10 @@ package common
 import "time"
 
 const (
+	// ArgoCDAgentInstallProfileAgentOnly is the value of the install profile that only installs the components
+	// required by the Argo CD Agent on a workload cluster.
+	ArgoCDAgentInstallProfileAgentOnly = "agent-only"
+
 	// ArgoCDAppName is the application name for labels.
 	ArgoCDAppName = "argocd"
 
@@ -50,6 +54,18 @@ const (
 	// ArgoCDKnownHostsConfigMapName is the upstream hard-coded SSH known hosts data ConfigMap name.
 	ArgoCDKnownHostsConfigMapName = "argocd-ssh-known-hosts-cm"
 
+	// ArgoCDProfileSmall is the value of the small sizing profile.
+	ArgoCDProfileSmall = "small"
+
+	// ArgoCDProfileMedium is the value of the medium sizing profile.
+	ArgoCDProfileMedium = "medium"
+
+	// ArgoCDProfileLarge is the value of the large sizing profile.
+	ArgoCDProfileLarge = "large"
+
+	// ArgoCDProfileCustom is the value of the custom sizing profile, which applies no defaults.
+	ArgoCDProfileCustom = "custom"
+
 	// ArgoCDRedisHAConfigMapName is the upstream ArgoCD Redis HA ConfigMap name.
 	ArgoCDRedisHAConfigMapName = "argocd-redis-ha-configmap"
 
@@ -65,6 +81,18 @@ const (
 	// ArgoCDSecretName is the upstream hard-coded ArgoCD Secret name.
 	ArgoCDSecretName = "argocd-secret"
 
+	// ArgoCDSecretTypeRepository is the secret-type label value for Argo CD repository Secrets.
+	ArgoCDSecretTypeRepository = "repository"
+
+	// ArgoCDSecretTypeRepoCreds is the secret-type label value for Argo CD repository credential template Secrets.
+	ArgoCDSecretTypeRepoCreds = "repo-creds"
+
+	// ArgoCDRepositorySecretSuffix is the name suffix for Secrets generated from spec.repositories.
+	ArgoCDRepositorySecretSuffix = "repository"
+
+	// ArgoCDRepoCredsSecretSuffix is the name suffix for Secrets generated from spec.repositoryCredentialTemplates.
+	ArgoCDRepoCredsSecretSuffix = "repo-creds"
+
 	// ArgoCDStatusCompleted is the completed status value.
 	ArgoCDStatusCompleted = "Completed"
 
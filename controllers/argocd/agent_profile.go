@@ -0,0 +1,75 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"k8s.io/utils/ptr"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// isAgentOnlyProfile returns true when the given ArgoCD runs an enabled Agent with the agent-only install profile.
+func isAgentOnlyProfile(cr *argoproj.ArgoCD) bool {
+	if cr.Spec.ArgoCDAgent == nil || cr.Spec.ArgoCDAgent.Agent == nil {
+		return false
+	}
+	agent := cr.Spec.ArgoCDAgent.Agent
+	return agent.IsEnabled() && agent.InstallProfile == common.ArgoCDAgentInstallProfileAgentOnly
+}
+
+// getAgentProfileMode returns the mode of the Agent of the given ArgoCD, which is managed when not set.
+func getAgentProfileMode(cr *argoproj.ArgoCD) argoproj.AgentMode {
+	if client := cr.Spec.ArgoCDAgent.Agent.Client; client != nil && client.Mode != "" {
+		return argoproj.AgentMode(client.Mode)
+	}
+	return argoproj.AgentModeManaged
+}
+
+// applyAgentInstallProfile will set the defaults of the agent-only install profile of the given ArgoCD on the fields
+// that are not set explicitly. Only the in-memory ArgoCD is modified, the profile defaults are never persisted.
+//
+// Both modes only run the application controller, the repo server and Redis next to the Agent: the Argo CD server is
+// disabled, since the Argo CD server of the principal serves the UI and API through the resource proxy. Autonomous
+// agents own the Applications of the workload cluster, so the ApplicationSet controller is installed as well, whereas
+// the ApplicationSets of managed agents are reconciled by the principal.
+func applyAgentInstallProfile(cr *argoproj.ArgoCD) {
+	if !isAgentOnlyProfile(cr) {
+		return
+	}
+	agent := cr.Spec.ArgoCDAgent.Agent
+
+	if cr.Spec.Server.Enabled == nil {
+		cr.Spec.Server.Enabled = ptr.To(false)
+	}
+
+	if getAgentProfileMode(cr) == argoproj.AgentModeAutonomous && cr.Spec.ApplicationSet == nil {
+		cr.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	}
+
+	// The Agent creates the Applications in the namespaces it is allowed to manage, which have to be watched by the
+	// application controller.
+	if len(cr.Spec.SourceNamespaces) == 0 && len(agent.AllowedNamespaces) > 0 {
+		cr.Spec.SourceNamespaces = append([]string{}, agent.AllowedNamespaces...)
+	}
+
+	// The Agent shares the Redis of the application controller, including a remote one.
+	if cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" && (agent.Redis == nil || agent.Redis.ServerAddress == "") {
+		if agent.Redis == nil {
+			agent.Redis = &argoproj.AgentRedisSpec{}
+		}
+		agent.Redis.ServerAddress = *cr.Spec.Redis.Remote
+	}
+}
@@ -0,0 +1,92 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestAgentOnlyArgoCD(mode argoproj.AgentMode, opts ...argoCDOpt) *argoproj.ArgoCD {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ArgoCDAgent = &argoproj.ArgoCDAgentSpec{
+			Agent: &argoproj.AgentSpec{
+				Enabled:        ptr.To(true),
+				InstallProfile: common.ArgoCDAgentInstallProfileAgentOnly,
+				Client:         &argoproj.AgentClientSpec{Mode: string(mode)},
+			},
+		}
+	})
+	for _, o := range opts {
+		o(a)
+	}
+	return a
+}
+
+func TestApplyAgentInstallProfile(t *testing.T) {
+	t.Run("managed mode only runs the components of the agent", func(t *testing.T) {
+		a := makeTestAgentOnlyArgoCD(argoproj.AgentModeManaged)
+		applyAgentInstallProfile(a)
+
+		assert.False(t, a.Spec.Server.IsEnabled())
+		assert.Nil(t, a.Spec.ApplicationSet)
+		assert.True(t, a.Spec.Controller.IsEnabled())
+		assert.True(t, a.Spec.Repo.IsEnabled())
+		assert.True(t, a.Spec.Redis.IsEnabled())
+	})
+
+	t.Run("autonomous mode installs the ApplicationSet controller", func(t *testing.T) {
+		a := makeTestAgentOnlyArgoCD(argoproj.AgentModeAutonomous)
+		applyAgentInstallProfile(a)
+
+		assert.False(t, a.Spec.Server.IsEnabled())
+		require.NotNil(t, a.Spec.ApplicationSet)
+		assert.True(t, a.Spec.ApplicationSet.IsEnabled())
+	})
+
+	t.Run("explicit fields override the profile", func(t *testing.T) {
+		a := makeTestAgentOnlyArgoCD(argoproj.AgentModeAutonomous, func(a *argoproj.ArgoCD) {
+			a.Spec.Server.Enabled = ptr.To(true)
+			a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{Enabled: ptr.To(false)}
+			a.Spec.SourceNamespaces = []string{"apps"}
+			a.Spec.ArgoCDAgent.Agent.AllowedNamespaces = []string{"team-*"}
+		})
+		applyAgentInstallProfile(a)
+
+		assert.True(t, a.Spec.Server.IsEnabled())
+		assert.False(t, a.Spec.ApplicationSet.IsEnabled())
+		assert.Equal(t, []string{"apps"}, a.Spec.SourceNamespaces)
+	})
+
+	t.Run("controller and Redis settings follow the agent", func(t *testing.T) {
+		a := makeTestAgentOnlyArgoCD(argoproj.AgentModeManaged, func(a *argoproj.ArgoCD) {
+			a.Spec.Redis.Remote = ptr.To("redis.example.com:6379")
+			a.Spec.ArgoCDAgent.Agent.AllowedNamespaces = []string{"team-*"}
+		})
+		applyAgentInstallProfile(a)
+
+		assert.Equal(t, []string{"team-*"}, a.Spec.SourceNamespaces)
+		require.NotNil(t, a.Spec.ArgoCDAgent.Agent.Redis)
+		assert.Equal(t, "redis.example.com:6379", a.Spec.ArgoCDAgent.Agent.Redis.ServerAddress)
+	})
+
+	t.Run("no defaults without the profile or when the agent is disabled", func(t *testing.T) {
+		for _, a := range []*argoproj.ArgoCD{
+			makeTestArgoCD(),
+			makeTestAgentOnlyArgoCD(argoproj.AgentModeAutonomous, func(a *argoproj.ArgoCD) {
+				a.Spec.ArgoCDAgent.Agent.InstallProfile = ""
+			}),
+			makeTestAgentOnlyArgoCD(argoproj.AgentModeAutonomous, func(a *argoproj.ArgoCD) {
+				a.Spec.ArgoCDAgent.Agent.Enabled = ptr.To(false)
+			}),
+		} {
+			expected := a.DeepCopy()
+			applyAgentInstallProfile(a)
+			assert.Equal(t, expected, a)
+		}
+	})
+}
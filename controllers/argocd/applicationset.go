@@ -113,6 +113,11 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) ([]s
 		cmd = append(cmd, "--allowed-scm-providers", fmt.Sprint(strings.Join(cr.Spec.ApplicationSet.SCMProviders, ",")))
 	}
 
+	// Only a single replica reconciles the ApplicationSets when the controller is scaled out, the others stand by
+	if isApplicationSetAutoscaled(cr) {
+		cmd = append(cmd, "--enable-leader-election")
+	}
+
 	// ApplicationSet command arguments provided by the user
 	extraArgs := cr.Spec.ApplicationSet.ExtraCommandArgs
 	cmd = appendUniqueArgs(cmd, extraArgs)
@@ -298,6 +303,10 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 	}
 	AddSeccompProfileForOpenShift(r.Client, podSpec)
 
+	if err := r.applyMutationWebhooks(cr, deploy, common.ArgoCDApplicationSetControllerComponent, ""); err != nil {
+		return err
+	}
+
 	if deplExists {
 		// Add Kubernetes-specific labels/annotations from the live object in the source to preserve metadata.
 		addKubernetesData(deploy.Spec.Template.Labels, existing.Spec.Template.Labels)
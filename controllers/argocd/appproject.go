@@ -0,0 +1,157 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"reflect"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// reconcileAppProjects creates and updates the AppProjects declared in spec.projects.
+// AppProjects that the operator created are deleted once they are removed from spec.projects;
+// pre-existing AppProjects are updated while declared but never deleted.
+func (r *ReconcileArgoCD) reconcileAppProjects(cr *argoproj.ArgoCD) error {
+	desired := map[string]bool{}
+
+	for _, project := range cr.Spec.Projects {
+		desired[project.Name] = true
+		if err := r.reconcileAppProject(cr, project); err != nil {
+			return err
+		}
+	}
+
+	projects := &argocdv1alpha1.AppProjectList{}
+	selector := client.MatchingLabels{
+		common.ArgoCDKeyManagedBy: cr.Name,
+		common.ArgoCDKeyPartOf:    common.ArgoCDAppName,
+	}
+	if err := r.List(context.TODO(), projects, client.InNamespace(cr.Namespace), selector); err != nil {
+		return err
+	}
+	for i := range projects.Items {
+		project := &projects.Items[i]
+		if desired[project.Name] || !metav1.IsControlledBy(project, cr) {
+			continue
+		}
+		argoutil.LogResourceDeletion(log, project, "project was removed from the ArgoCD CR")
+		if err := r.Delete(context.TODO(), project); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileAppProject creates or updates a single AppProject.
+func (r *ReconcileArgoCD) reconcileAppProject(cr *argoproj.ArgoCD, project argoproj.ArgoCDProjectSpec) error {
+	spec := newAppProjectSpec(project)
+
+	existing := &argocdv1alpha1.AppProject{}
+	found, err := argoutil.IsObjectFound(r.Client, cr.Namespace, project.Name, existing)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		appProject := &argocdv1alpha1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      project.Name,
+				Namespace: cr.Namespace,
+				Labels:    argoutil.LabelsForCluster(cr),
+			},
+			Spec: spec,
+		}
+		if err := controllerutil.SetControllerReference(cr, appProject, r.Scheme); err != nil {
+			return err
+		}
+		argoutil.LogResourceCreation(log, appProject)
+		return r.Create(context.TODO(), appProject)
+	}
+
+	// Tokens are issued through the Argo CD API and stored on the role, keep them.
+	for i := range spec.Roles {
+		for _, role := range existing.Spec.Roles {
+			if role.Name == spec.Roles[i].Name {
+				spec.Roles[i].JWTTokens = role.JWTTokens
+			}
+		}
+	}
+
+	if reflect.DeepEqual(existing.Spec, spec) {
+		return nil
+	}
+	existing.Spec = spec
+	argoutil.LogResourceUpdate(log, existing, "updating", "spec")
+	return r.Update(context.TODO(), existing)
+}
+
+// newAppProjectSpec converts a project declared on the ArgoCD CR into an AppProject spec.
+func newAppProjectSpec(project argoproj.ArgoCDProjectSpec) argocdv1alpha1.AppProjectSpec {
+	spec := argocdv1alpha1.AppProjectSpec{
+		Description: project.Description,
+		SourceRepos: project.SourceRepos,
+	}
+	for _, d := range project.Destinations {
+		spec.Destinations = append(spec.Destinations, argocdv1alpha1.ApplicationDestination{
+			Server:    d.Server,
+			Name:      d.Name,
+			Namespace: d.Namespace,
+		})
+	}
+	for _, res := range project.ClusterResourceWhitelist {
+		spec.ClusterResourceWhitelist = append(spec.ClusterResourceWhitelist, argocdv1alpha1.ClusterResourceRestrictionItem{
+			Group: res.Group,
+			Kind:  res.Kind,
+			Name:  res.Name,
+		})
+	}
+	for _, res := range project.ClusterResourceBlacklist {
+		spec.ClusterResourceBlacklist = append(spec.ClusterResourceBlacklist, argocdv1alpha1.ClusterResourceRestrictionItem{
+			Group: res.Group,
+			Kind:  res.Kind,
+			Name:  res.Name,
+		})
+	}
+	for _, role := range project.Roles {
+		spec.Roles = append(spec.Roles, argocdv1alpha1.ProjectRole{
+			Name:        role.Name,
+			Description: role.Description,
+			Policies:    role.Policies,
+			Groups:      role.Groups,
+		})
+	}
+	for _, w := range project.SyncWindows {
+		spec.SyncWindows = append(spec.SyncWindows, &argocdv1alpha1.SyncWindow{
+			Kind:         w.Kind,
+			Schedule:     w.Schedule,
+			Duration:     w.Duration,
+			Applications: w.Applications,
+			Namespaces:   w.Namespaces,
+			Clusters:     w.Clusters,
+			ManualSync:   w.ManualSync,
+			TimeZone:     w.TimeZone,
+		})
+	}
+	return spec
+}
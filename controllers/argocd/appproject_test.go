@@ -0,0 +1,108 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestProject(name string) argoproj.ArgoCDProjectSpec {
+	return argoproj.ArgoCDProjectSpec{
+		Name:        name,
+		Description: "team project",
+		SourceRepos: []string{"https://github.com/example/*"},
+		Destinations: []argoproj.ArgoCDProjectDestination{
+			{Server: "https://kubernetes.default.svc", Namespace: "team-*"},
+		},
+		ClusterResourceWhitelist: []argoproj.ArgoCDProjectClusterResource{
+			{Group: "", Kind: "Namespace"},
+		},
+		ClusterResourceBlacklist: []argoproj.ArgoCDProjectClusterResource{
+			{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "cluster-admin"},
+		},
+		Roles: []argoproj.ArgoCDProjectRole{
+			{
+				Name:     "ci",
+				Policies: []string{"p, proj:" + name + ":ci, applications, sync, " + name + "/*, allow"},
+				Groups:   []string{"team-ci"},
+			},
+		},
+		SyncWindows: []argoproj.ArgoCDProjectSyncWindow{
+			{Kind: "deny", Schedule: "0 22 * * *", Duration: "8h", Applications: []string{"*"}},
+		},
+	}
+}
+
+func TestReconcileArgoCD_reconcileAppProjects(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Projects = []argoproj.ArgoCDProjectSpec{makeTestProject("team-a")}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileAppProjects(cr))
+
+	project := &argocdv1alpha1.AppProject{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "team-a", Namespace: testNamespace}, project))
+	assert.True(t, metav1.IsControlledBy(project, cr))
+	assert.Equal(t, cr.Name, project.Labels[common.ArgoCDKeyManagedBy])
+	assert.Equal(t, "team project", project.Spec.Description)
+	assert.Equal(t, []string{"https://github.com/example/*"}, project.Spec.SourceRepos)
+	assert.Equal(t, []argocdv1alpha1.ApplicationDestination{{Server: "https://kubernetes.default.svc", Namespace: "team-*"}}, project.Spec.Destinations)
+	assert.Equal(t, []argocdv1alpha1.ClusterResourceRestrictionItem{{Group: "", Kind: "Namespace"}}, project.Spec.ClusterResourceWhitelist)
+	assert.Equal(t, []argocdv1alpha1.ClusterResourceRestrictionItem{{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "cluster-admin"}}, project.Spec.ClusterResourceBlacklist)
+	require.Len(t, project.Spec.Roles, 1)
+	assert.Equal(t, []string{"team-ci"}, project.Spec.Roles[0].Groups)
+	require.Len(t, project.Spec.SyncWindows, 1)
+	assert.Equal(t, "deny", project.Spec.SyncWindows[0].Kind)
+
+	// Update the declared project and ensure issued tokens survive.
+	project.Spec.Roles[0].JWTTokens = []argocdv1alpha1.JWTToken{{IssuedAt: 1, ID: "token"}}
+	require.NoError(t, r.Update(context.TODO(), project))
+	cr.Spec.Projects[0].SourceRepos = []string{"https://gitlab.example.com/*"}
+	require.NoError(t, r.reconcileAppProjects(cr))
+
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "team-a", Namespace: testNamespace}, project))
+	assert.Equal(t, []string{"https://gitlab.example.com/*"}, project.Spec.SourceRepos)
+	assert.Equal(t, []argocdv1alpha1.JWTToken{{IssuedAt: 1, ID: "token"}}, project.Spec.Roles[0].JWTTokens)
+}
+
+func TestReconcileArgoCD_reconcileAppProjects_deletesOnlyOperatorCreated(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Projects = []argoproj.ArgoCDProjectSpec{makeTestProject("created"), makeTestProject("default")}
+	})
+	preExisting := &argocdv1alpha1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: testNamespace},
+		Spec:       argocdv1alpha1.AppProjectSpec{SourceRepos: []string{"*"}},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, preExisting}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileAppProjects(cr))
+
+	adopted := &argocdv1alpha1.AppProject{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "default", Namespace: testNamespace}, adopted))
+	assert.Equal(t, []string{"https://github.com/example/*"}, adopted.Spec.SourceRepos)
+	assert.False(t, metav1.IsControlledBy(adopted, cr))
+
+	cr.Spec.Projects = nil
+	require.NoError(t, r.reconcileAppProjects(cr))
+
+	err := r.Get(context.TODO(), types.NamespacedName{Name: "created", Namespace: testNamespace}, &argocdv1alpha1.AppProject{})
+	assert.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "default", Namespace: testNamespace}, &argocdv1alpha1.AppProject{}))
+}
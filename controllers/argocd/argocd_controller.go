@@ -26,6 +26,7 @@ import (
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 
 	corev1 "k8s.io/api/core/v1"
@@ -41,8 +42,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logr "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	tlsProfile "github.com/argoproj-labs/argocd-operator/pkg/tlsprofile"
 )
@@ -104,6 +108,24 @@ type ReconcileArgoCD struct {
 	// re-run to renew the Dex OAuth client token before it expires.
 	// Key: ArgoCD namespace, Value: time.Duration
 	dexTokenRequeueAfter sync.Map
+	// redisPasswordRequeueAfter stores the duration after which the reconciler should
+	// re-run to rotate the Redis password once the rotation interval elapses.
+	// Key: ArgoCD namespace, Value: time.Duration
+	redisPasswordRequeueAfter sync.Map
+	// agentPKIRequeueAfter stores the duration after which the reconciler should
+	// re-run to renew the certificates and the JWT signing key of the Argo CD Agent principal.
+	// Key: ArgoCD namespace, Value: time.Duration
+	agentPKIRequeueAfter sync.Map
+	// mutationWebhookPatches stores the last result of a mutation webhook for a resource, so that the webhook is only
+	// called again when the resource or the webhook changes, and its Event is only emitted when the patch changes.
+	// Key: ArgoCD namespace/name, webhook name, resource kind, namespace and name, Value: mutationWebhookResult
+	mutationWebhookPatches sync.Map
+	// AgentConnectionReader reads the number of agents connected to the Argo CD Agent principal, reported in the status
+	// of the ArgoCD. The connections are not reported when nil.
+	AgentConnectionReader argocdagent.AgentConnectionReader
+	// AgentConnectionEvents receives an event for an ArgoCD whenever the number of agents connected to its principal
+	// changes, so that its status is refreshed.
+	AgentConnectionEvents <-chan event.GenericEvent
 	// CentralTLSConfigProfile specifies the TLS configuration profile in the cluster.
 	CentralTLSConfigProfile tlsProfile.TLSConfigProfile
 }
@@ -122,6 +144,8 @@ var ActiveInstanceMap = make(map[string]string)
 //+kubebuilder:rbac:groups=apps,resourceNames=argocd-operator,resources=deployments/finalizers,verbs=update
 //+kubebuilder:rbac:groups=argoproj.io,resources=argocds;argocds/finalizers;argocds/status,verbs=*
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=*
+//+kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=*
+//+kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=*
 //+kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=*
 //+kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*
@@ -136,6 +160,9 @@ var ActiveInstanceMap = make(map[string]string)
 //+kubebuilder:rbac:groups=argoproj.io,resources=notificationsconfigurations;notificationsconfigurations/finalizers,verbs=*
 //+kubebuilder:rbac:groups="apiregistration.k8s.io",resources="apiservices",verbs=get;list
 //+kubebuilder:rbac:groups=argoproj.io,resources=namespacemanagements;namespacemanagements/finalizers;namespacemanagements/status,verbs=*
+//+kubebuilder:rbac:groups=argoproj.io,resources=argocdoperatorconfigs;argocdoperatorconfigs/status,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=argoproj.io,resources=argocdtemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=argoproj.io,resources=argocdtemplates/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=argocd-image-updater.argoproj.io,resources=imageupdaters;imageupdaters/finalizers,verbs=*
 //+kubebuilder:rbac:groups=config.openshift.io,resources=authentications,verbs=get;list;watch
 //+kubebuilder:rbac:groups=certificates.k8s.io,resources=clustertrustbundles,verbs=get;list;watch
@@ -198,6 +225,11 @@ func (r *ReconcileArgoCD) internalReconcile(ctx context.Context, request ctrl.Re
 		return reconcile.Result{}, argocd, argoCDStatus, err
 	}
 
+	// Apply the latest operator-wide settings before they are read while reconciling.
+	if err := argoutil.RefreshOperatorConfig(ctx, r.Client); err != nil {
+		return reconcile.Result{}, argocd, argoCDStatus, err
+	}
+
 	// Redis TLS Checksum and Repo Server TLS Checksum should be preserved between reconcile calls (the lifecycle of these fields is greater than a single reconcile call, unlike the other fields in .status)
 	argoCDStatus.RepoTLSChecksum = argocd.Status.RepoTLSChecksum
 	argoCDStatus.RedisTLSChecksum = argocd.Status.RedisTLSChecksum
@@ -254,6 +286,7 @@ func (r *ReconcileArgoCD) internalReconcile(ctx context.Context, request ctrl.Re
 		delete(ActiveInstanceMap, argocd.Namespace)
 		ActiveInstancesByPhase.WithLabelValues(newPhase).Dec()
 		ActiveInstancesTotal.Dec()
+
 		ActiveInstanceReconciliationCount.DeleteLabelValues(argocd.Namespace)
 		ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": argocd.Namespace})
 
@@ -299,6 +332,20 @@ func (r *ReconcileArgoCD) internalReconcile(ctx context.Context, request ctrl.Re
 			return reconcile.Result{}, argocd, argoCDStatus, err
 		}
 	}
+
+	if err := r.reconcileDeprecatedFields(argocd, argoCDStatus); err != nil {
+		return reconcile.Result{}, argocd, argoCDStatus, err
+	}
+
+	// Apply the install and sizing profile defaults once the ArgoCD is no longer updated, so that they are never persisted.
+	applyAgentInstallProfile(argocd)
+	applySizingProfile(argocd)
+
+	// The ArgoCDTemplates only default the fields left unset by the ArgoCD and its sizing profile.
+	if err = r.applyArgoCDTemplates(ctx, argocd); err != nil {
+		return reconcile.Result{}, argocd, argoCDStatus, err
+	}
+
 	if err = r.restoreTrackingLabelsForOrphanedNamespaces(ctx, argocd); err != nil {
 		return reconcile.Result{}, argocd, argoCDStatus, err
 	}
@@ -376,10 +423,11 @@ func (r *ReconcileArgoCD) internalReconcile(ctx context.Context, request ctrl.Re
 
 	// If Dex is in use, requeue before the token reaches its renewal threshold so
 	// the operator proactively renews it without waiting for an external event.
+	result := reconcile.Result{}
 	if UseDex(argocd) && isDexSATokenExpiryFeatureEnabled(argocd) {
 		if v, ok := r.dexTokenRequeueAfter.Load(argocd.Namespace); ok {
 			if d, ok := v.(time.Duration); ok {
-				return reconcile.Result{RequeueAfter: d}, argocd, argoCDStatus, nil
+				result.RequeueAfter = d
 			}
 		}
 	} else {
@@ -388,13 +436,30 @@ func (r *ReconcileArgoCD) internalReconcile(ctx context.Context, request ctrl.Re
 		r.dexTokenRequeueAfter.Delete(argocd.Namespace)
 	}
 
-	return reconcile.Result{}, argocd, argoCDStatus, nil
+	// Requeue when the Redis password rotation interval elapses, unless Dex requires an earlier requeue.
+	if v, ok := r.redisPasswordRequeueAfter.Load(argocd.Namespace); ok {
+		if d, ok := v.(time.Duration); ok && (result.RequeueAfter == 0 || d < result.RequeueAfter) {
+			result.RequeueAfter = d
+		}
+	}
+
+	// Requeue when a certificate or the JWT signing key of the Argo CD Agent principal is due for renewal.
+	if v, ok := r.agentPKIRequeueAfter.Load(argocd.Namespace); ok {
+		if d, ok := v.(time.Duration); ok && (result.RequeueAfter == 0 || d < result.RequeueAfter) {
+			result.RequeueAfter = d
+		}
+	}
+
+	return result, argocd, argoCDStatus, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileArgoCD) SetupWithManager(mgr ctrl.Manager) error {
 	bldr := ctrl.NewControllerManagedBy(mgr)
-	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper, r.nmMapper, r.systemCATrustMapper)
+	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper, r.nmMapper, r.systemCATrustMapper, r.referencedConfigMapMapper, r.dexConnectorSecretMapper, r.repositoryCredentialsSecretMapper, r.operatorConfigMapper, r.argoCDTemplateMapper)
+	if r.AgentConnectionEvents != nil {
+		bldr.WatchesRawSource(source.Channel(r.AgentConnectionEvents, &handler.EnqueueRequestForObject{}))
+	}
 	return bldr.Complete(r)
 }
 
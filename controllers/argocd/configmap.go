@@ -37,11 +37,21 @@ import (
 // createRBACConfigMap will create the Argo CD RBAC ConfigMap resource.
 func (r *ReconcileArgoCD) createRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
 	data := make(map[string]string)
-	data[common.ArgoCDKeyRBACPolicyCSV] = getRBACPolicy(cr)
 	data[common.ArgoCDKeyRBACPolicyDefault] = getRBACDefaultPolicy(cr)
 	data[common.ArgoCDKeyRBACScopes] = getRBACScopes(cr)
 	cm.Data = data
 
+	// Only write the declared policies once they pass validation, the status condition reports the error.
+	policies, err := r.getRBACPolicies(cr)
+	if err != nil {
+		log.Error(err, "invalid RBAC policy, not writing it to the RBAC ConfigMap")
+	} else {
+		applyRBACPolicies(cm, policies)
+	}
+	if _, ok := cm.Data[common.ArgoCDKeyRBACPolicyCSV]; !ok {
+		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = common.ArgoCDDefaultRBACPolicy
+	}
+
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
 	}
@@ -119,20 +129,18 @@ func getKustomizeBuildOptions(cr *argoproj.ArgoCD) string {
 	return kbo
 }
 
-// getOIDCConfig will return the OIDC configuration for the given ArgoCD.
-func getOIDCConfig(cr *argoproj.ArgoCD) string {
-	config := common.ArgoCDDefaultOIDCConfig
-	if len(cr.Spec.OIDCConfig) > 0 {
-		config = cr.Spec.OIDCConfig
-	}
-	return config
-}
-
-// getRBACPolicy will return the RBAC policy for the given ArgoCD.
+// getRBACPolicy will return the RBAC policy for the given ArgoCD, with the structured roles appended.
 func getRBACPolicy(cr *argoproj.ArgoCD) string {
 	policy := common.ArgoCDDefaultRBACPolicy
-	if cr.Spec.RBAC.Policy != nil {
-		policy = *cr.Spec.RBAC.Policy
+	if cr.Spec.RBAC.Policy != nil || len(cr.Spec.RBAC.Roles) > 0 {
+		var parts []string
+		if cr.Spec.RBAC.Policy != nil && *cr.Spec.RBAC.Policy != "" {
+			parts = append(parts, strings.TrimRight(*cr.Spec.RBAC.Policy, "\n"))
+		}
+		if roles := renderRBACRoles(cr.Spec.RBAC.Roles); roles != "" {
+			parts = append(parts, roles)
+		}
+		policy = strings.Join(parts, "\n")
 	}
 	return policy
 }
@@ -339,6 +347,10 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRedi
 		return err
 	}
 
+	if err := r.reconcileRepoPluginsConfigMap(cr); err != nil {
+		return err
+	}
+
 	return r.reconcileGPGKeysConfigMap(cr)
 }
 
@@ -401,7 +413,12 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 		}
 	}
 
-	cm.Data[common.ArgoCDKeyOIDCConfig] = getOIDCConfig(cr)
+	// An invalid typed OIDC configuration is not written, the OIDCConfigValid condition reports the error.
+	oidcConfig, oidcConfigErr := r.getOIDCConfig(cr)
+	if oidcConfigErr != nil {
+		log.Error(oidcConfigErr, "invalid OIDC configuration, keeping the existing OIDC configuration")
+	}
+	cm.Data[common.ArgoCDKeyOIDCConfig] = oidcConfig
 
 	if c := getResourceHealthChecks(cr); c != nil {
 		for k, v := range c {
@@ -452,7 +469,10 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 
 	// create dex config if dex is enabled through `.spec.sso`
 	if UseDex(cr) {
-		dexConfig := getDexConfig(cr)
+		dexConfig, err := getDexConfigWithConnectors(cr)
+		if err != nil {
+			return err
+		}
 
 		// Append the default OpenShift dex config if the openShiftOAuth is requested through `.spec.sso.dex`.
 		if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth {
@@ -550,6 +570,9 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 		if cm.Data[common.ArgoCDWebTerminalEnabledKey] != existingCM.Data[common.ArgoCDWebTerminalEnabledKey] {
 			webTerminalFlagChanged = true
 		}
+		if oidcConfigErr != nil {
+			cm.Data[common.ArgoCDKeyOIDCConfig] = existingCM.Data[common.ArgoCDKeyOIDCConfig]
+		}
 		// reconcile dex configuration if dex is enabled `.spec.sso.dex.provider` or there is
 		// existing dex configuration
 		if UseDex(cr) {
@@ -651,10 +674,18 @@ func (r *ReconcileArgoCD) reconcileRBAC(cr *argoproj.ArgoCD) error {
 // reconcileRBACConfigMap will ensure that the RBAC ConfigMap is syncronized with the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
 	var changes []string
-	// Policy CSV
-	if cr.Spec.RBAC.Policy != nil && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != *cr.Spec.RBAC.Policy {
-		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = *cr.Spec.RBAC.Policy
-		changes = append(changes, "rbac policy")
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+
+	// Policy CSV and overlays, left untouched when they fail validation.
+	policies, err := r.getRBACPolicies(cr)
+	if err != nil {
+		log.Error(err, "invalid RBAC policy, not updating it in the RBAC ConfigMap")
+	} else {
+		for _, key := range applyRBACPolicies(cm, policies) {
+			changes = append(changes, "rbac "+key)
+		}
 	}
 
 	// Default Policy
@@ -681,7 +712,6 @@ func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argop
 
 	// Check OwnerReferences
 	var ownerRefChanged bool
-	var err error
 	if ownerRefChanged, err = modifyOwnerReferenceIfNeeded(cr, cm, r.Scheme); err != nil {
 		return err
 	}
@@ -3,14 +3,17 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/argoproj/argo-cd/v3/util/glob"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -94,11 +97,25 @@ func (r *ReconcileArgoCD) isUserManagedSecret(ctx context.Context, o client.Obje
 		ok = true
 	} else if argocd.Spec.ApplicationSet != nil && argocd.Spec.ApplicationSet.WebhookServer.Route.UseExternalCertificate() && argocd.Spec.ApplicationSet.WebhookServer.Route.TLS.ExternalCertificate.Name == o.GetName() {
 		ok = true
+	} else if isOIDCReferencedSecret(&argocd, o.GetName()) {
+		ok = true
 	}
 
 	return namespacedName, ok
 }
 
+// isOIDCReferencedSecret checks if the given secret name is referenced by the typed OIDC configuration of the ArgoCD CR.
+func isOIDCReferencedSecret(argocd *argoproj.ArgoCD, name string) bool {
+	spec := argocd.Spec.OIDC
+	if spec == nil {
+		return false
+	}
+	if spec.ClientSecretRef != nil && spec.ClientSecretRef.Name == name {
+		return true
+	}
+	return spec.RootCA != nil && spec.RootCA.SecretKeyRef != nil && spec.RootCA.SecretKeyRef.Name == name
+}
+
 // tlsSecretMapper maps a watch event on a secret of type TLS back to the
 // ArgoCD object that we want to reconcile.
 func (r *ReconcileArgoCD) tlsSecretMapper(ctx context.Context, o client.Object) []reconcile.Request {
@@ -212,7 +229,9 @@ func (r *ReconcileArgoCD) namespaceResourceMapper(ctx context.Context, o client.
 			return result
 		}
 		for _, argocd := range argocds.Items {
-			if glob.MatchStringInList(argocd.Spec.SourceNamespaces, namespaceName, glob.GLOB) {
+			// The principal is granted access to the namespaces of its namespace policies as well.
+			if glob.MatchStringInList(argocd.Spec.SourceNamespaces, namespaceName, glob.GLOB) ||
+				argocdagent.IsPrincipalNamespaceAllowed(&argocd, namespaceName) {
 				namespacedName := client.ObjectKey{
 					Name:      argocd.Name,
 					Namespace: argocd.Namespace,
@@ -282,6 +301,109 @@ func (r *ReconcileArgoCD) applicationSetSCMTLSConfigMapMapper(ctx context.Contex
 	return result
 }
 
+// referencedConfigMapMapper maps a watch event on a ConfigMap referenced as an RBAC policy overlay,
+// as the OIDC root CA or as a repo plugin configuration, back to the ArgoCD objects that we want to reconcile.
+func (r *ReconcileArgoCD) referencedConfigMapMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result []reconcile.Request
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.List(ctx, argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		referenced := argocd.Spec.OIDC != nil && argocd.Spec.OIDC.RootCA != nil &&
+			argocd.Spec.OIDC.RootCA.ConfigMapKeyRef != nil && argocd.Spec.OIDC.RootCA.ConfigMapKeyRef.Name == o.GetName()
+		for _, overlay := range argocd.Spec.RBAC.PolicyOverlays {
+			if overlay.ConfigMapRef.Name == o.GetName() {
+				referenced = true
+				break
+			}
+		}
+		for _, p := range argocd.Spec.Repo.Plugins {
+			if p.ConfigMapRef != nil && p.ConfigMapRef.Name == o.GetName() {
+				referenced = true
+				break
+			}
+		}
+		if referenced {
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Name:      argocd.Name,
+					Namespace: argocd.Namespace,
+				},
+			})
+		}
+	}
+
+	return result
+}
+
+// dexConnectorSecretMapper maps a watch event on a Secret referenced by a typed Dex connector,
+// back to the ArgoCD objects that we want to reconcile.
+func (r *ReconcileArgoCD) dexConnectorSecretMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result []reconcile.Request
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.List(ctx, argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for i := range argocds.Items {
+		argocd := &argocds.Items[i]
+		for _, ref := range getDexConnectorSecretRefs(argocd) {
+			if ref.ref.Name == o.GetName() {
+				result = append(result, reconcile.Request{
+					NamespacedName: client.ObjectKey{
+						Name:      argocd.Name,
+						Namespace: argocd.Namespace,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// repositoryCredentialsSecretMapper maps a watch event on a Secret referenced as the credentials of a declared
+// repository or repository credential template, back to the ArgoCD objects that we want to reconcile.
+func (r *ReconcileArgoCD) repositoryCredentialsSecretMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result []reconcile.Request
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.List(ctx, argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		referenced := false
+		for _, repo := range argocd.Spec.Repositories {
+			if repo.CredentialsSecretRef != nil && repo.CredentialsSecretRef.Name == o.GetName() {
+				referenced = true
+				break
+			}
+		}
+		for _, tmpl := range argocd.Spec.RepositoryCredentialTemplates {
+			if tmpl.CredentialsSecretRef.Name == o.GetName() {
+				referenced = true
+				break
+			}
+		}
+		if referenced {
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Name:      argocd.Name,
+					Namespace: argocd.Namespace,
+				},
+			})
+		}
+	}
+
+	return result
+}
+
 // namespaceResourceMapper maps a watch event on a namespaceManagement, back to the
 // ArgoCD object that we want to reconcile.
 func (r *ReconcileArgoCD) nmMapper(ctx context.Context, o client.Object) []reconcile.Request {
@@ -305,3 +427,59 @@ func (r *ReconcileArgoCD) nmMapper(ctx context.Context, o client.Object) []recon
 
 	return result
 }
+
+// operatorConfigMapper maps a watch event on the ArgoCDOperatorConfig, back to all the ArgoCD objects,
+// so that the operator-wide settings apply to every instance.
+func (r *ReconcileArgoCD) operatorConfigMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result []reconcile.Request
+
+	argocdList := &argoproj.ArgoCDList{}
+	if err := r.List(ctx, argocdList); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocdList.Items {
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      argocd.Name,
+				Namespace: argocd.Namespace,
+			},
+		})
+	}
+
+	return result
+}
+
+// argoCDTemplateMapper maps a watch event on an ArgoCDTemplate, back to the ArgoCD objects that reference it, that it
+// selects, or that it was applied to so that an ArgoCD no longer selected drops its defaults. The ArgoCD objects
+// reporting skipped templates are reconciled as well, so that the TemplatesValid condition clears once they are fixed.
+func (r *ReconcileArgoCD) argoCDTemplateMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result []reconcile.Request
+
+	template, ok := o.(*argoproj.ArgoCDTemplate)
+	if !ok {
+		return result
+	}
+
+	argocdList := &argoproj.ArgoCDList{}
+	if err := r.List(ctx, argocdList); err != nil {
+		return result
+	}
+
+	for i := range argocdList.Items {
+		argocd := &argocdList.Items[i]
+		// An invalid selector selects nothing, it is reported in the status of the ArgoCDTemplate.
+		selected, _ := argoCDTemplateSelects(template, argocd)
+		skipped := meta.FindStatusCondition(argocd.Status.Conditions, argoproj.ArgoCDConditionTemplatesValid) != nil
+		if argocd.Spec.Template == template.Name || selected || skipped || slices.Contains(argocd.Status.Templates, template.Name) {
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Name:      argocd.Name,
+					Namespace: argocd.Namespace,
+				},
+			})
+		}
+	}
+
+	return result
+}
@@ -311,13 +311,15 @@ func getArgoServerCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 		log.Info("Repo Server is disabled. This would affect the functioning of ArgoCD Server.")
 	}
 
-	if cr.Spec.Redis.IsEnabled() {
+	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.External != nil {
+		cmd = append(cmd, argoutil.GetRedisExternalArgs(cr)...)
+	} else if cr.Spec.Redis.IsEnabled() {
 		cmd = append(cmd, "--redis", argoutil.GetRedisServerAddress(cr))
 	} else {
 		log.Info("Redis is Disabled. Skipping adding Redis configuration to ArgoCD Server.")
 	}
 
-	if useTLSForRedis {
+	if useTLSForRedis && cr.Spec.Redis.External == nil {
 		cmd = append(cmd, "--redis-use-tls")
 		if isRedisTLSVerificationDisabled(cr) {
 			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
@@ -524,10 +526,16 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 		}
 	}
 	deploy.Spec.Template.Labels[common.ArgoCDKeyName] = nameWithSuffix("redis", cr)
+	if err := r.setRedisAuthChecksum(cr, &deploy.Spec.Template); err != nil {
+		return err
+	}
 
 	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
 		return err
 	}
+	if err := r.applyMutationWebhooks(cr, deploy, common.ArgoCDRedisComponent, ""); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("redis", "redis", cr)
 	deplFound, err := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
@@ -817,6 +825,9 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
 	deploy.Spec.Template.Spec.ServiceAccountName = getServiceAccountName(cr.Name, common.ArgoCDRedisHAComponent)
+	if err := r.setRedisAuthChecksum(cr, &deploy.Spec.Template); err != nil {
+		return err
+	}
 
 	version, err := getClusterVersion(r.Client)
 	if err != nil {
@@ -825,6 +836,9 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 	if err := applyReconcilerHook(cr, deploy, version); err != nil {
 		return err
 	}
+	if err := r.applyMutationWebhooks(cr, deploy, common.ArgoCDRedisHAComponent, version); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
 	deplExists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
@@ -898,6 +912,13 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 			existing.Spec.Template.Spec.ServiceAccountName = deploy.Spec.Template.Spec.ServiceAccountName
 			changes = append(changes, "serviceAccountName")
 		}
+		if existing.Spec.Template.Annotations[common.ArgoCDRedisAuthChecksum] != deploy.Spec.Template.Annotations[common.ArgoCDRedisAuthChecksum] {
+			if existing.Spec.Template.Annotations == nil {
+				existing.Spec.Template.Annotations = make(map[string]string)
+			}
+			existing.Spec.Template.Annotations[common.ArgoCDRedisAuthChecksum] = deploy.Spec.Template.Annotations[common.ArgoCDRedisAuthChecksum]
+			changes = append(changes, "redis auth checksum")
+		}
 		if len(changes) > 0 {
 			argoutil.LogResourceUpdate(log, existing, "updating", strings.Join(changes, ", "))
 			return r.Update(context.TODO(), existing)
@@ -921,7 +942,7 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 	deploy := newDeploymentWithSuffix("server", "server", cr)
 	serverEnv := cr.Spec.Server.Env
 	serverEnv = argoutil.EnvMerge(serverEnv, proxyEnvVars(), false)
-	serverEnv = argoutil.EnvMerge(serverEnv, argoutil.GetRedisAuthEnv(), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, argoutil.GetRedisAuthEnv(cr), false)
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
 	if cr.Spec.Server.InitContainers != nil {
@@ -929,6 +950,7 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 	}
 
 	redisAuthVolume, redisAuthMount := argoutil.MountRedisAuthToArgo(cr)
+	redisExternalTLSVolumes, redisExternalTLSMounts := argoutil.MountRedisExternalTLSToArgo(cr)
 
 	serverVolumeMounts := []corev1.VolumeMount{
 		{
@@ -960,6 +982,7 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 		},
 		redisAuthMount,
 	}
+	serverVolumeMounts = append(serverVolumeMounts, redisExternalTLSMounts...)
 
 	if cr.Spec.Server.VolumeMounts != nil {
 		serverVolumeMounts = append(serverVolumeMounts, cr.Spec.Server.VolumeMounts...)
@@ -1075,6 +1098,7 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 		},
 		redisAuthVolume,
 	}
+	serverVolumes = append(serverVolumes, redisExternalTLSVolumes...)
 
 	if cr.Spec.Server.Volumes != nil {
 		serverVolumes = append(serverVolumes, cr.Spec.Server.Volumes...)
@@ -1125,6 +1149,9 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 			deploy.Spec.Template.Annotations[key] = value
 		}
 	}
+	if _, err := argoutil.SetRedisAuthRevision(r.Client, cr, &deploy.Spec.Template); err != nil {
+		return err
+	}
 
 	if cr.Spec.Server.Labels != nil {
 		for key, value := range cr.Spec.Server.Labels {
@@ -1134,6 +1161,9 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
 		return err
 	}
+	if err := r.applyMutationWebhooks(cr, deploy, common.ArgoCDServerComponent, ""); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("server", "server", cr)
 	deplExists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
@@ -1315,7 +1345,7 @@ func caseInsensitiveGetenv(s string) (string, string) {
 }
 
 func isRemoveManagedByLabelOnArgoCDDeletion() bool {
-	if v := os.Getenv("REMOVE_MANAGED_BY_LABEL_ON_ARGOCD_DELETION"); v != "" {
+	if v := argoutil.GetOperatorSetting(common.ArgoCDRemoveManagedByLabelOnDeletionEnvName); v != "" {
 		return strings.ToLower(v) == "true"
 	}
 	return false
@@ -1352,7 +1382,7 @@ func getRolloutInitContainer() []corev1.Container {
 		},
 	}
 
-	if value, exists := os.LookupEnv(common.ArgoCDExtensionImageEnvName); exists {
+	if value, exists := argoutil.LookupOperatorSetting(common.ArgoCDExtensionImageEnvName); exists {
 		containers[0].Image = value
 	} else {
 		containers[0].Image = common.ArgoCDExtensionInstallerImage
@@ -1375,7 +1375,7 @@ func TestReconcileArgoCD_reconcileServerDeployment(t *testing.T) {
 					"--logformat",
 					"text",
 				},
-				Env: argoutil.GetRedisAuthEnv(),
+				Env: argoutil.GetRedisAuthEnv(a),
 				Ports: []corev1.ContainerPort{
 					{ContainerPort: 8080},
 					{ContainerPort: 8083},
@@ -1866,7 +1866,7 @@ func TestReconcileArgoCD_reconcileServerDeploymentWithInsecure(t *testing.T) {
 					"--logformat",
 					"text",
 				},
-				Env: argoutil.GetRedisAuthEnv(),
+				Env: argoutil.GetRedisAuthEnv(a),
 				Ports: []corev1.ContainerPort{
 					{ContainerPort: 8080},
 					{ContainerPort: 8083},
@@ -1951,7 +1951,7 @@ func TestReconcileArgoCD_reconcileServerDeploymentChangedToInsecure(t *testing.T
 					"--logformat",
 					"text",
 				},
-				Env: argoutil.GetRedisAuthEnv(),
+				Env: argoutil.GetRedisAuthEnv(a),
 				Ports: []corev1.ContainerPort{
 					{ContainerPort: 8080},
 					{ContainerPort: 8083},
@@ -0,0 +1,304 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// migrateDeprecatedFields rewrites the deprecated fields of the given spec into their replacements. It returns the
+// paths of the deprecated fields it rewrote, and the paths of those in use that have no replacement it can rewrite
+// them into and are left unchanged.
+func migrateDeprecatedFields(spec *argoproj.ArgoCDSpec) ([]string, []string) {
+	var migrated, manual []string
+
+	//lint:ignore SA1019 known to be deprecated
+	if !reflect.DeepEqual(spec.Grafana, argoproj.ArgoCDGrafanaSpec{}) { //nolint:staticcheck // SA1019: We must test deprecated fields.
+		// Grafana is no longer supported and the field is ignored.
+		spec.Grafana = argoproj.ArgoCDGrafanaSpec{} //nolint:staticcheck // SA1019: We must migrate deprecated fields.
+		migrated = append(migrated, "spec.grafana")
+	}
+
+	if spec.SSO != nil && spec.SSO.Keycloak != nil {
+		if spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
+			// Keycloak is no longer supported, another SSO provider has to be configured instead.
+			manual = append(manual, "spec.sso.keycloak", "spec.sso.provider")
+		} else {
+			spec.SSO.Keycloak = nil
+			if reflect.DeepEqual(spec.SSO, &argoproj.ArgoCDSSOSpec{}) {
+				spec.SSO = nil
+			}
+			migrated = append(migrated, "spec.sso.keycloak")
+		}
+	} else if spec.SSO != nil && spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
+		manual = append(manual, "spec.sso.provider")
+	}
+
+	//lint:ignore SA1019 known to be deprecated
+	if dynamicScaling := spec.Controller.Sharding.DynamicScalingEnabled; dynamicScaling != nil { //nolint:staticcheck // SA1019: We must test deprecated fields.
+		if *dynamicScaling {
+			// Dynamic scaling has no replacement yet.
+			manual = append(manual, "spec.controller.sharding.dynamicScalingEnabled")
+		} else {
+			// Disabled dynamic scaling keeps the profile from enabling sharding, which its override does instead.
+			if profile, ok := sizingProfiles[spec.Profile]; ok && profile.controllerShards > 0 && !spec.Controller.Sharding.Enabled && spec.Controller.Sharding.Replicas == 0 {
+				if spec.ProfileOverrides == nil {
+					spec.ProfileOverrides = &argoproj.ArgoCDProfileOverridesSpec{}
+				}
+				if spec.ProfileOverrides.ControllerSharding == nil {
+					spec.ProfileOverrides.ControllerSharding = ptr.To(false)
+				}
+			}
+			spec.Controller.Sharding.DynamicScalingEnabled = nil //nolint:staticcheck // SA1019: We must migrate deprecated fields.
+			migrated = append(migrated, "spec.controller.sharding.dynamicScalingEnabled")
+		}
+	}
+
+	//lint:ignore SA1019 known to be deprecated
+	if spec.ApplicationSet != nil && spec.ApplicationSet.Logformat != "" { //nolint:staticcheck // SA1019: We must test deprecated fields.
+		migrateLogFormat(&spec.ApplicationSet.Logformat, &spec.ApplicationSet.LogFormat) //nolint:staticcheck // SA1019: We must migrate deprecated fields.
+		migrated = append(migrated, "spec.applicationSet.logformat")
+	}
+
+	//lint:ignore SA1019 known to be deprecated
+	if spec.Notifications.Logformat != "" { //nolint:staticcheck // SA1019: We must test deprecated fields.
+		migrateLogFormat(&spec.Notifications.Logformat, &spec.Notifications.LogFormat) //nolint:staticcheck // SA1019: We must migrate deprecated fields.
+		migrated = append(migrated, "spec.notifications.logformat")
+	}
+
+	//lint:ignore SA1019 known to be deprecated
+	if spec.ConfigManagementPlugins != "" { //nolint:staticcheck // SA1019: We must test deprecated fields.
+		if plugins, err := convertConfigManagementPlugins(spec); err != nil {
+			log.Info(fmt.Sprintf("the legacy config management plugins cannot be migrated: %v", err))
+			manual = append(manual, "spec.configManagementPlugins")
+		} else {
+			spec.Repo.Plugins = append(spec.Repo.Plugins, plugins...)
+			spec.ConfigManagementPlugins = "" //nolint:staticcheck // SA1019: We must migrate deprecated fields.
+			migrated = append(migrated, "spec.configManagementPlugins")
+		}
+	}
+
+	userMigrated, userManual := migrateExtraConfigLocalUsers(spec)
+	migrated = append(migrated, userMigrated...)
+	manual = append(manual, userManual...)
+
+	return migrated, manual
+}
+
+// migrateLogFormat moves the given deprecated log format to the given log format, unless the latter is already set
+// and takes precedence. An invalid log format, which falls back to the default one, is dropped.
+func migrateLogFormat(deprecated, logFormat *string) {
+	if *logFormat == "" {
+		if format := strings.ToLower(*deprecated); format == "text" || format == "json" {
+			*logFormat = format
+		}
+	}
+	*deprecated = ""
+}
+
+// convertConfigManagementPlugins returns the repo plugins replacing the legacy config management plugins of the given
+// spec, which were run by the repo server container and are now run as sidecars using the repo server image.
+func convertConfigManagementPlugins(spec *argoproj.ArgoCDSpec) ([]argoproj.ArgoCDRepoPlugin, error) {
+	legacy := []argocdv1alpha1.ConfigManagementPlugin{}
+	//lint:ignore SA1019 known to be deprecated
+	if err := yaml.UnmarshalStrict([]byte(spec.ConfigManagementPlugins), &legacy); err != nil { //nolint:staticcheck // SA1019: We must migrate deprecated fields.
+		return nil, err
+	}
+
+	var plugins []argoproj.ArgoCDRepoPlugin
+	for _, p := range legacy {
+		if err := validateRepoPluginName(p.Name); err != nil {
+			return nil, err
+		}
+		if p.LockRepo {
+			return nil, fmt.Errorf("plugin %q: lockRepo is not supported by sidecar plugins", p.Name)
+		}
+		for _, existing := range spec.Repo.Plugins {
+			if existing.Name == p.Name {
+				return nil, fmt.Errorf("plugin %q: a repo plugin with the same name already exists", p.Name)
+			}
+		}
+
+		config := map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "ConfigManagementPlugin",
+			"metadata":   map[string]interface{}{"name": p.Name},
+			"spec":       map[string]interface{}{"generate": p.Generate},
+		}
+		if p.Init != nil {
+			config["spec"].(map[string]interface{})["init"] = p.Init
+		}
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, argoproj.ArgoCDRepoPlugin{Name: p.Name, Configuration: string(data)})
+	}
+
+	migrated := &argoproj.ArgoCD{Spec: *spec.DeepCopy()}
+	migrated.Spec.Repo.Plugins = append(migrated.Spec.Repo.Plugins, plugins...)
+	if err := validateRepoPlugins(migrated); err != nil {
+		return nil, err
+	}
+	return plugins, nil
+}
+
+// migrateExtraConfigLocalUsers rewrites the local users defined in the extraConfig of the given spec into its local
+// users. It returns the paths of the extraConfig keys it rewrote, and of those it cannot rewrite.
+func migrateExtraConfigLocalUsers(spec *argoproj.ArgoCDSpec) ([]string, []string) {
+	var migrated, manual []string
+
+	names := []string{}
+	for name := range localUsersInExtraConfig(argoproj.ArgoCD{Spec: *spec}) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := "accounts." + name
+		path := fmt.Sprintf("spec.extraConfig[%s]", key)
+
+		// A user already in the local users, or with capabilities the local users do not support, is left unchanged.
+		convertible := true
+		for _, user := range spec.LocalUsers {
+			convertible = convertible && user.Name != name
+		}
+		capabilities := map[string]bool{}
+		for _, capability := range strings.Split(spec.ExtraConfig[key], ",") {
+			capability = strings.TrimSpace(capability)
+			convertible = convertible && (capability == localUserApiKey || capability == "login")
+			capabilities[capability] = true
+		}
+		if !convertible {
+			manual = append(manual, path)
+			continue
+		}
+
+		user := argoproj.LocalUserSpec{Name: name, Login: capabilities["login"]}
+		if !capabilities[localUserApiKey] {
+			user.ApiKey = ptr.To(false)
+		}
+		if enabled, ok := spec.ExtraConfig[key+".enabled"]; ok {
+			user.Enabled = ptr.To(enabled != "false")
+			delete(spec.ExtraConfig, key+".enabled")
+			migrated = append(migrated, fmt.Sprintf("spec.extraConfig[%s.enabled]", key))
+		}
+		delete(spec.ExtraConfig, key)
+		spec.LocalUsers = append(spec.LocalUsers, user)
+		migrated = append(migrated, path)
+	}
+	if len(spec.ExtraConfig) == 0 {
+		spec.ExtraConfig = nil
+	}
+	sort.Strings(migrated)
+	return migrated, manual
+}
+
+// getDeprecatedFieldsMigrationPreview returns the JSON merge patch rewriting the spec of the given ArgoCD into the given
+// migrated spec.
+func getDeprecatedFieldsMigrationPreview(cr *argoproj.ArgoCD, migrated *argoproj.ArgoCDSpec) (string, error) {
+	original, err := json.Marshal(map[string]interface{}{"spec": cr.Spec})
+	if err != nil {
+		return "", err
+	}
+	modified, err := json.Marshal(map[string]interface{}{"spec": migrated})
+	if err != nil {
+		return "", err
+	}
+	patch, err := jsonpatch.CreateMergePatch(original, modified)
+	if err != nil {
+		return "", err
+	}
+	return string(patch), nil
+}
+
+// reconcileDeprecatedFields rewrites the deprecated fields of the given ArgoCD into their replacements when the ArgoCD
+// opts in, and annotates it with the proposed rewrite otherwise. The DeprecatedFieldsInUse condition lists the
+// deprecated fields left in the ArgoCD, before any default is applied to it.
+func (r *ReconcileArgoCD) reconcileDeprecatedFields(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	spec := cr.Spec.DeepCopy()
+	migrated, manual := migrateDeprecatedFields(spec)
+
+	preview := ""
+	if len(migrated) > 0 {
+		var err error
+		if preview, err = getDeprecatedFieldsMigrationPreview(cr, spec); err != nil {
+			return err
+		}
+	}
+
+	if preview != "" && strings.EqualFold(cr.Annotations[common.AnnotationMigrateDeprecatedFields], "true") {
+		cr.Spec = *spec
+		delete(cr.Annotations, common.AnnotationDeprecatedFieldsMigrationPreview)
+		argoutil.LogResourceUpdate(log, cr, "rewriting deprecated fields", strings.Join(migrated, ", "))
+		if err := r.Update(context.TODO(), cr); err != nil {
+			return fmt.Errorf("failed to rewrite the deprecated fields of %s: %w", cr.Name, err)
+		}
+		message := fmt.Sprintf("the deprecated fields %s were rewritten into their replacements: %s", strings.Join(migrated, ", "), preview)
+		if err := argoutil.CreateEvent(r.Client, corev1.EventTypeNormal, "Migrated", message, "DeprecatedFieldsMigrated", cr.ObjectMeta, cr.TypeMeta); err != nil {
+			return err
+		}
+		migrated, preview = nil, ""
+	} else if cr.Annotations[common.AnnotationDeprecatedFieldsMigrationPreview] != preview {
+		if preview == "" {
+			delete(cr.Annotations, common.AnnotationDeprecatedFieldsMigrationPreview)
+		} else {
+			if cr.Annotations == nil {
+				cr.Annotations = map[string]string{}
+			}
+			cr.Annotations[common.AnnotationDeprecatedFieldsMigrationPreview] = preview
+		}
+		argoutil.LogResourceUpdate(log, cr, "updating the deprecated fields migration preview")
+		if err := r.Update(context.TODO(), cr); err != nil {
+			return fmt.Errorf("failed to update the deprecated fields migration preview of %s: %w", cr.Name, err)
+		}
+	}
+
+	if len(migrated) == 0 && len(manual) == 0 {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionDeprecatedFieldsInUse)
+		return nil
+	}
+	condition := metav1.Condition{
+		Type:    argoproj.ArgoCDConditionDeprecatedFieldsInUse,
+		Status:  metav1.ConditionTrue,
+		Reason:  argoproj.ArgoCDConditionReasonMigrationAvailable,
+		Message: "deprecated fields in use: " + strings.Join(append(migrated, manual...), ", "),
+	}
+	if len(manual) > 0 {
+		condition.Reason = argoproj.ArgoCDConditionReasonManualMigrationRequired
+		condition.Message += fmt.Sprintf(". %s must be migrated manually", strings.Join(manual, ", "))
+	}
+	if len(migrated) > 0 {
+		condition.Message += fmt.Sprintf(". Annotate the ArgoCD with %s=true to rewrite %s into their replacements", common.AnnotationMigrateDeprecatedFields, strings.Join(migrated, ", "))
+	}
+	argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+	return nil
+}
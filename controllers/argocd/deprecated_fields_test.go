@@ -0,0 +1,232 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestMigrateDeprecatedFields(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         argoproj.ArgoCDSpec
+		wantSpec     argoproj.ArgoCDSpec
+		wantMigrated []string
+		wantManual   []string
+	}{
+		{
+			name: "no deprecated fields",
+		},
+		{
+			name:         "grafana is dropped",
+			spec:         argoproj.ArgoCDSpec{Grafana: argoproj.ArgoCDGrafanaSpec{Enabled: true}},
+			wantMigrated: []string{"spec.grafana"},
+		},
+		{
+			name: "keycloak configuration next to dex is dropped",
+			spec: argoproj.ArgoCDSpec{SSO: &argoproj.ArgoCDSSOSpec{
+				Provider: argoproj.SSOProviderTypeDex,
+				Dex:      &argoproj.ArgoCDDexSpec{OpenShiftOAuth: true},
+				Keycloak: &argoproj.ArgoCDKeycloakSpec{Image: "keycloak"},
+			}},
+			wantSpec: argoproj.ArgoCDSpec{SSO: &argoproj.ArgoCDSSOSpec{
+				Provider: argoproj.SSOProviderTypeDex,
+				Dex:      &argoproj.ArgoCDDexSpec{OpenShiftOAuth: true},
+			}},
+			wantMigrated: []string{"spec.sso.keycloak"},
+		},
+		{
+			name:       "keycloak provider must be replaced manually",
+			spec:       argoproj.ArgoCDSpec{SSO: &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeKeycloak}},
+			wantSpec:   argoproj.ArgoCDSpec{SSO: &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeKeycloak}},
+			wantManual: []string{"spec.sso.provider"},
+		},
+		{
+			name: "dynamic scaling is kept",
+			spec: argoproj.ArgoCDSpec{Controller: argoproj.ArgoCDApplicationControllerSpec{
+				Sharding: argoproj.ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: ptr.To(true)},
+			}},
+			wantSpec: argoproj.ArgoCDSpec{Controller: argoproj.ArgoCDApplicationControllerSpec{
+				Sharding: argoproj.ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: ptr.To(true)},
+			}},
+			wantManual: []string{"spec.controller.sharding.dynamicScalingEnabled"},
+		},
+		{
+			name: "disabled dynamic scaling is dropped without a profile enabling sharding",
+			spec: argoproj.ArgoCDSpec{Controller: argoproj.ArgoCDApplicationControllerSpec{
+				Sharding: argoproj.ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: ptr.To(false)},
+			}},
+			wantMigrated: []string{"spec.controller.sharding.dynamicScalingEnabled"},
+		},
+		{
+			name: "disabled dynamic scaling turns off the sharding of the profile",
+			spec: argoproj.ArgoCDSpec{Profile: common.ArgoCDProfileLarge, Controller: argoproj.ArgoCDApplicationControllerSpec{
+				Sharding: argoproj.ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: ptr.To(false)},
+			}},
+			wantSpec: argoproj.ArgoCDSpec{
+				Profile:          common.ArgoCDProfileLarge,
+				ProfileOverrides: &argoproj.ArgoCDProfileOverridesSpec{ControllerSharding: ptr.To(false)},
+			},
+			wantMigrated: []string{"spec.controller.sharding.dynamicScalingEnabled"},
+		},
+		{
+			name: "log formats are moved",
+			spec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{Logformat: "JSON"},
+				Notifications:  argoproj.ArgoCDNotifications{Logformat: "json", LogFormat: "text"},
+			},
+			wantSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{LogFormat: "json"},
+				Notifications:  argoproj.ArgoCDNotifications{LogFormat: "text"},
+			},
+			wantMigrated: []string{"spec.applicationSet.logformat", "spec.notifications.logformat"},
+		},
+		{
+			name: "legacy config management plugins become repo plugins",
+			spec: argoproj.ArgoCDSpec{ConfigManagementPlugins: `
+- name: kasane
+  init:
+    command: [kasane, update]
+  generate:
+    command: [kasane, show]
+`},
+			wantSpec: argoproj.ArgoCDSpec{Repo: argoproj.ArgoCDRepoSpec{Plugins: []argoproj.ArgoCDRepoPlugin{{
+				Name: "kasane",
+				Configuration: `apiVersion: argoproj.io/v1alpha1
+kind: ConfigManagementPlugin
+metadata:
+  name: kasane
+spec:
+  generate:
+    command:
+    - kasane
+    - show
+  init:
+    command:
+    - kasane
+    - update
+`,
+			}}}},
+			wantMigrated: []string{"spec.configManagementPlugins"},
+		},
+		{
+			name:       "legacy config management plugins locking the repo must be migrated manually",
+			spec:       argoproj.ArgoCDSpec{ConfigManagementPlugins: "- name: kasane\n  generate:\n    command: [kasane]\n  lockRepo: true\n"},
+			wantSpec:   argoproj.ArgoCDSpec{ConfigManagementPlugins: "- name: kasane\n  generate:\n    command: [kasane]\n  lockRepo: true\n"},
+			wantManual: []string{"spec.configManagementPlugins"},
+		},
+		{
+			name: "extraConfig local users become local users",
+			spec: argoproj.ArgoCDSpec{
+				ExtraConfig: map[string]string{
+					"accounts.alice":         "apiKey, login",
+					"accounts.alice.enabled": "false",
+					"accounts.bob":           "login",
+					"accounts.carol":         "apiKey",
+					"accounts.dave":          "apiKey",
+					"users.anonymous":        "true",
+				},
+				LocalUsers: []argoproj.LocalUserSpec{{Name: "dave"}},
+			},
+			wantSpec: argoproj.ArgoCDSpec{
+				ExtraConfig: map[string]string{
+					"accounts.dave":   "apiKey",
+					"users.anonymous": "true",
+				},
+				LocalUsers: []argoproj.LocalUserSpec{
+					{Name: "dave"},
+					{Name: "alice", Login: true, Enabled: ptr.To(false)},
+					{Name: "bob", Login: true, ApiKey: ptr.To(false)},
+					{Name: "carol"},
+				},
+			},
+			wantMigrated: []string{
+				"spec.extraConfig[accounts.alice.enabled]",
+				"spec.extraConfig[accounts.alice]",
+				"spec.extraConfig[accounts.bob]",
+				"spec.extraConfig[accounts.carol]",
+			},
+			wantManual: []string{"spec.extraConfig[accounts.dave]"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := test.spec.DeepCopy()
+			migrated, manual := migrateDeprecatedFields(spec)
+			assert.Equal(t, test.wantMigrated, migrated)
+			assert.Equal(t, test.wantManual, manual)
+			assert.Equal(t, &test.wantSpec, spec)
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcileDeprecatedFields(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Notifications.Logformat = "json"
+		a.Spec.Controller.Sharding.DynamicScalingEnabled = ptr.To(true)
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	// The rewrite is only previewed until the ArgoCD opts in.
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileDeprecatedFields(cr, status))
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+	assert.Equal(t, `{"spec":{"notifications":{"logFormat":"json","logformat":null}}}`, cr.Annotations[common.AnnotationDeprecatedFieldsMigrationPreview])
+	assert.Equal(t, "json", cr.Spec.Notifications.Logformat)
+
+	condition := meta.FindStatusCondition(status.Conditions, argoproj.ArgoCDConditionDeprecatedFieldsInUse)
+	require.NotNil(t, condition)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonManualMigrationRequired, condition.Reason)
+	assert.Contains(t, condition.Message, "deprecated fields in use: spec.notifications.logformat, spec.controller.sharding.dynamicScalingEnabled")
+
+	cr.Annotations[common.AnnotationMigrateDeprecatedFields] = "true"
+	require.NoError(t, cl.Update(context.TODO(), cr))
+
+	status = &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileDeprecatedFields(cr, status))
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+	assert.NotContains(t, cr.Annotations, common.AnnotationDeprecatedFieldsMigrationPreview)
+	assert.Empty(t, cr.Spec.Notifications.Logformat)
+	assert.Equal(t, "json", cr.Spec.Notifications.LogFormat)
+	assert.Equal(t, ptr.To(true), cr.Spec.Controller.Sharding.DynamicScalingEnabled)
+	assert.Len(t, listTestEvents(t, cl, "DeprecatedFieldsMigrated"), 1)
+
+	condition = meta.FindStatusCondition(status.Conditions, argoproj.ArgoCDConditionDeprecatedFieldsInUse)
+	require.NotNil(t, condition)
+	assert.Equal(t, "deprecated fields in use: spec.controller.sharding.dynamicScalingEnabled. spec.controller.sharding.dynamicScalingEnabled must be migrated manually", condition.Message)
+
+	// The condition is removed once no deprecated field is in use.
+	cr.Spec.Controller.Sharding.DynamicScalingEnabled = nil
+	cr.Status.Conditions = status.Conditions
+	status = &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileDeprecatedFields(cr, status))
+	assert.Empty(t, status.Conditions)
+	assert.Nil(t, meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionDeprecatedFieldsInUse))
+}
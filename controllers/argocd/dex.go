@@ -306,7 +306,10 @@ func (r *ReconcileArgoCD) reconcileDexLegacySATokenSecrets(cr *argoproj.ArgoCD)
 // reconcileDexConfiguration will ensure that Dex is configured properly.
 func (r *ReconcileArgoCD) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
 	actual := cm.Data[common.ArgoCDKeyDexConfig]
-	desired := getDexConfig(cr)
+	desired, err := getDexConfigWithConnectors(cr)
+	if err != nil {
+		return err
+	}
 
 	// Append the default OpenShift dex config if the openShiftOAuth is requested through `.spec.sso.dex`.
 	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth {
@@ -397,6 +400,7 @@ func (r *ReconcileArgoCD) getOpenShiftDexConfig(cr *argoproj.ArgoCD) (string, er
 	if err := addDexConfigFromCR(cr, dex); err != nil {
 		return "", err
 	}
+	addDexConnectorsFromCR(cr, dex)
 
 	bytes, err := yaml.Marshal(dex)
 	return string(bytes), err
@@ -420,6 +424,13 @@ func addDexConfigFromCR(cr *argoproj.ArgoCD, dex map[string]interface{}) error {
 	return nil
 }
 
+// addDexConnectorsFromCR appends the typed Dex connectors of the given ArgoCD to the Dex configuration.
+func addDexConnectorsFromCR(cr *argoproj.ArgoCD, dex map[string]interface{}) {
+	if connectors := renderDexConnectors(cr); len(connectors) > 0 {
+		appendDexConnectors(dex, connectors)
+	}
+}
+
 // reconcileDexServiceAccount will ensure that the Dex ServiceAccount is configured properly for OpenShift OAuth.
 func (r *ReconcileArgoCD) reconcileDexServiceAccount(cr *argoproj.ArgoCD) error {
 	// if openShiftOAuth set to false in `.spec.sso.dex`, no need to configure it
@@ -583,6 +594,10 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 	deploy.Spec.Template.Spec.ServiceAccountName = getServiceAccountName(cr.Name, common.ArgoCDDefaultDexServiceAccountName)
 	deploy.Spec.Template.Spec.Volumes = dexVolumes
 
+	if err := r.applyMutationWebhooks(cr, deploy, common.ArgoCDDexServerComponent, ""); err != nil {
+		return err
+	}
+
 	existing := newDeploymentWithSuffix("dex-server", "dex-server", cr)
 	deplExists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
 	if err != nil {
@@ -758,6 +773,10 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 		},
 	}
 
+	if err := r.applyMutationWebhooks(cr, svc, common.ArgoCDDexServerComponent, ""); err != nil {
+		return err
+	}
+
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
 	}
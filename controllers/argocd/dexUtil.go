@@ -2,7 +2,6 @@ package argocd
 
 import (
 	"fmt"
-	"os"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -45,7 +44,7 @@ func getDexContainerImage(cr *argoproj.ArgoCD) string {
 		tag = common.ArgoCDDefaultDexVersion
 		defaultTag = true
 	}
-	if e := os.Getenv(common.ArgoCDDexImageEnvName); e != "" && (defaultTag && defaultImg) {
+	if e := argoutil.GetOperatorSetting(common.ArgoCDDexImageEnvName); e != "" && (defaultTag && defaultImg) {
 		return e
 	}
 	return argoutil.CombineImageTag(img, tag)
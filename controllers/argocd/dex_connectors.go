@@ -0,0 +1,315 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// dexConnectorSecretRef is a sensitive connector field copied from a Secret into argocd-secret.
+type dexConnectorSecretRef struct {
+	// key in argocd-secret
+	key string
+	ref corev1.SecretKeySelector
+	// encode the value in base64, for connector fields holding binary data such as CA certificates
+	base64 bool
+}
+
+// getDexConnectors returns the typed Dex connectors of the given ArgoCD.
+func getDexConnectors(cr *argoproj.ArgoCD) []argoproj.ArgoCDDexConnector {
+	if cr.Spec.SSO == nil || cr.Spec.SSO.Dex == nil {
+		return nil
+	}
+	return cr.Spec.SSO.Dex.Connectors
+}
+
+// dexConnectorSecretKey returns the argocd-secret key holding the given field of a connector.
+func dexConnectorSecretKey(id, field string) string {
+	return common.ArgoCDDexConnectorSecretKeyPrefix + id + "." + field
+}
+
+// dexConnectorSecretValue returns the reference to the given field of a connector, resolved by Argo CD from argocd-secret.
+func dexConnectorSecretValue(id, field string) string {
+	return "$" + dexConnectorSecretKey(id, field)
+}
+
+// renderDexConnectors converts the typed Dex connectors of the given ArgoCD into Dex connector configurations.
+func renderDexConnectors(cr *argoproj.ArgoCD) []DexConnector {
+	var connectors []DexConnector
+	for _, c := range getDexConnectors(cr) {
+		config := map[string]interface{}{}
+		switch c.Type {
+		case argoproj.ArgoCDDexConnectorTypeGitHub:
+			if c.GitHub == nil {
+				continue
+			}
+			config["clientID"] = c.GitHub.ClientID
+			config["clientSecret"] = dexConnectorSecretValue(c.ID, "clientSecret")
+			if len(c.GitHub.Orgs) > 0 {
+				var orgs []map[string]interface{}
+				for _, org := range c.GitHub.Orgs {
+					o := map[string]interface{}{"name": org.Name}
+					if len(org.Teams) > 0 {
+						o["teams"] = org.Teams
+					}
+					orgs = append(orgs, o)
+				}
+				config["orgs"] = orgs
+			}
+			setIfNotEmpty(config, "hostName", c.GitHub.HostName)
+			setIfTrue(config, "loadAllGroups", c.GitHub.LoadAllGroups)
+		case argoproj.ArgoCDDexConnectorTypeGitLab:
+			if c.GitLab == nil {
+				continue
+			}
+			setIfNotEmpty(config, "baseURL", c.GitLab.BaseURL)
+			config["clientID"] = c.GitLab.ClientID
+			config["clientSecret"] = dexConnectorSecretValue(c.ID, "clientSecret")
+			if len(c.GitLab.Groups) > 0 {
+				config["groups"] = c.GitLab.Groups
+			}
+			setIfTrue(config, "useLoginAsID", c.GitLab.UseLoginAsID)
+		case argoproj.ArgoCDDexConnectorTypeLDAP:
+			if c.LDAP == nil {
+				continue
+			}
+			config["host"] = c.LDAP.Host
+			setIfTrue(config, "insecureNoSSL", c.LDAP.InsecureNoSSL)
+			setIfTrue(config, "insecureSkipVerify", c.LDAP.InsecureSkipVerify)
+			setIfTrue(config, "startTLS", c.LDAP.StartTLS)
+			if c.LDAP.RootCARef != nil {
+				config["rootCAData"] = dexConnectorSecretValue(c.ID, "rootCAData")
+			}
+			setIfNotEmpty(config, "bindDN", c.LDAP.BindDN)
+			if c.LDAP.BindPWRef != nil {
+				config["bindPW"] = dexConnectorSecretValue(c.ID, "bindPW")
+			}
+			setIfNotEmpty(config, "usernamePrompt", c.LDAP.UsernamePrompt)
+			userSearch := map[string]interface{}{
+				"baseDN":    c.LDAP.UserSearch.BaseDN,
+				"username":  c.LDAP.UserSearch.Username,
+				"idAttr":    c.LDAP.UserSearch.IDAttr,
+				"emailAttr": c.LDAP.UserSearch.EmailAttr,
+			}
+			setIfNotEmpty(userSearch, "filter", c.LDAP.UserSearch.Filter)
+			setIfNotEmpty(userSearch, "nameAttr", c.LDAP.UserSearch.NameAttr)
+			setIfNotEmpty(userSearch, "preferredUsernameAttr", c.LDAP.UserSearch.PreferredUsernameAttr)
+			config["userSearch"] = userSearch
+			if c.LDAP.GroupSearch != nil {
+				groupSearch := map[string]interface{}{
+					"baseDN":   c.LDAP.GroupSearch.BaseDN,
+					"nameAttr": c.LDAP.GroupSearch.NameAttr,
+				}
+				setIfNotEmpty(groupSearch, "filter", c.LDAP.GroupSearch.Filter)
+				if len(c.LDAP.GroupSearch.UserMatchers) > 0 {
+					var matchers []map[string]interface{}
+					for _, m := range c.LDAP.GroupSearch.UserMatchers {
+						matchers = append(matchers, map[string]interface{}{"userAttr": m.UserAttr, "groupAttr": m.GroupAttr})
+					}
+					groupSearch["userMatchers"] = matchers
+				}
+				config["groupSearch"] = groupSearch
+			}
+		case argoproj.ArgoCDDexConnectorTypeSAML:
+			if c.SAML == nil {
+				continue
+			}
+			config["ssoURL"] = c.SAML.SSOURL
+			config["caData"] = dexConnectorSecretValue(c.ID, "caData")
+			setIfNotEmpty(config, "entityIssuer", c.SAML.EntityIssuer)
+			setIfNotEmpty(config, "ssoIssuer", c.SAML.SSOIssuer)
+			config["usernameAttr"] = c.SAML.UsernameAttr
+			config["emailAttr"] = c.SAML.EmailAttr
+			setIfNotEmpty(config, "groupsAttr", c.SAML.GroupsAttr)
+			setIfNotEmpty(config, "nameIDPolicyFormat", c.SAML.NameIDPolicyFormat)
+		case argoproj.ArgoCDDexConnectorTypeOIDC:
+			if c.OIDC == nil {
+				continue
+			}
+			config["issuer"] = c.OIDC.Issuer
+			config["clientID"] = c.OIDC.ClientID
+			config["clientSecret"] = dexConnectorSecretValue(c.ID, "clientSecret")
+			if len(c.OIDC.Scopes) > 0 {
+				config["scopes"] = c.OIDC.Scopes
+			}
+			setIfTrue(config, "getUserInfo", c.OIDC.GetUserInfo)
+			setIfTrue(config, "insecureSkipEmailVerified", c.OIDC.InsecureSkipEmailVerified)
+			setIfTrue(config, "insecureEnableGroups", c.OIDC.InsecureEnableGroups)
+			setIfNotEmpty(config, "userIDKey", c.OIDC.UserIDKey)
+			setIfNotEmpty(config, "userNameKey", c.OIDC.UserNameKey)
+		case argoproj.ArgoCDDexConnectorTypeMicrosoft:
+			if c.Microsoft == nil {
+				continue
+			}
+			config["clientID"] = c.Microsoft.ClientID
+			config["clientSecret"] = dexConnectorSecretValue(c.ID, "clientSecret")
+			setIfNotEmpty(config, "tenant", c.Microsoft.Tenant)
+			if len(c.Microsoft.Groups) > 0 {
+				config["groups"] = c.Microsoft.Groups
+			}
+			setIfTrue(config, "onlySecurityGroups", c.Microsoft.OnlySecurityGroups)
+		default:
+			continue
+		}
+		connectors = append(connectors, DexConnector{
+			ID:     c.ID,
+			Name:   c.Name,
+			Type:   string(c.Type),
+			Config: config,
+		})
+	}
+	return connectors
+}
+
+func setIfNotEmpty(m map[string]interface{}, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func setIfTrue(m map[string]interface{}, key string, value bool) {
+	if value {
+		m[key] = true
+	}
+}
+
+// getDexConnectorSecretRefs returns the sensitive fields of the typed Dex connectors of the given ArgoCD.
+func getDexConnectorSecretRefs(cr *argoproj.ArgoCD) []dexConnectorSecretRef {
+	var refs []dexConnectorSecretRef
+	add := func(id, field string, ref *corev1.SecretKeySelector, encode bool) {
+		if ref != nil {
+			refs = append(refs, dexConnectorSecretRef{key: dexConnectorSecretKey(id, field), ref: *ref, base64: encode})
+		}
+	}
+	for _, c := range getDexConnectors(cr) {
+		switch {
+		case c.Type == argoproj.ArgoCDDexConnectorTypeGitHub && c.GitHub != nil:
+			add(c.ID, "clientSecret", &c.GitHub.ClientSecretRef, false)
+		case c.Type == argoproj.ArgoCDDexConnectorTypeGitLab && c.GitLab != nil:
+			add(c.ID, "clientSecret", &c.GitLab.ClientSecretRef, false)
+		case c.Type == argoproj.ArgoCDDexConnectorTypeLDAP && c.LDAP != nil:
+			add(c.ID, "rootCAData", c.LDAP.RootCARef, true)
+			add(c.ID, "bindPW", c.LDAP.BindPWRef, false)
+		case c.Type == argoproj.ArgoCDDexConnectorTypeSAML && c.SAML != nil:
+			add(c.ID, "caData", &c.SAML.CARef, true)
+		case c.Type == argoproj.ArgoCDDexConnectorTypeOIDC && c.OIDC != nil:
+			add(c.ID, "clientSecret", &c.OIDC.ClientSecretRef, false)
+		case c.Type == argoproj.ArgoCDDexConnectorTypeMicrosoft && c.Microsoft != nil:
+			add(c.ID, "clientSecret", &c.Microsoft.ClientSecretRef, false)
+		}
+	}
+	return refs
+}
+
+// applyDexConnectorSecrets copies the sensitive fields of the typed Dex connectors into argocd-secret, where the
+// Dex configuration references them, and removes the connector keys that are no longer referenced.
+// It appends the change reasons to *changes when the secret is modified.
+func applyDexConnectorSecrets(ctx context.Context, c client.Client, cr *argoproj.ArgoCD, argocdSecret *corev1.Secret, changes *[]string) error {
+	desired := map[string][]byte{}
+	if UseDex(cr) {
+		for _, ref := range getDexConnectorSecretRefs(cr) {
+			src := &corev1.Secret{}
+			if err := c.Get(ctx, types.NamespacedName{Name: ref.ref.Name, Namespace: cr.Namespace}, src); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return err
+				}
+				log.Info(fmt.Sprintf("warning: Dex connector secret reference not found (Secret %s/%s), skipping sync of %s", cr.Namespace, ref.ref.Name, ref.key))
+				continue
+			}
+			val, ok := src.Data[ref.ref.Key]
+			if !ok || len(val) == 0 {
+				log.Info(fmt.Sprintf("warning: key %q missing or empty in Secret %s/%s, skipping sync of %s", ref.ref.Key, cr.Namespace, ref.ref.Name, ref.key))
+				continue
+			}
+			if ref.base64 {
+				val = []byte(base64.StdEncoding.EncodeToString(val))
+			}
+			desired[ref.key] = val
+		}
+	}
+
+	if argocdSecret.Data == nil {
+		argocdSecret.Data = make(map[string][]byte)
+	}
+
+	var changed []string
+	for key, val := range desired {
+		if !bytes.Equal(argocdSecret.Data[key], val) {
+			argocdSecret.Data[key] = val
+			changed = append(changed, key)
+		}
+	}
+	for key := range argocdSecret.Data {
+		if _, ok := desired[key]; !ok && strings.HasPrefix(key, common.ArgoCDDexConnectorSecretKeyPrefix) {
+			delete(argocdSecret.Data, key)
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) > 0 {
+		sort.Strings(changed)
+		*changes = append(*changes, fmt.Sprintf("dex connector secrets (%s)", strings.Join(changed, ", ")))
+	}
+	return nil
+}
+
+// getDexConfigWithConnectors will return the Dex configuration for the given ArgoCD, with the typed connectors
+// appended to the connectors of the configuration.
+func getDexConfigWithConnectors(cr *argoproj.ArgoCD) (string, error) {
+	config := getDexConfig(cr)
+	connectors := renderDexConnectors(cr)
+	if len(connectors) == 0 {
+		return config, nil
+	}
+
+	dex := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(config), dex); err != nil {
+		return "", err
+	}
+	appendDexConnectors(dex, connectors)
+
+	out, err := yaml.Marshal(dex)
+	return string(out), err
+}
+
+// appendDexConnectors appends the given connectors to the connectors of the Dex configuration.
+func appendDexConnectors(dex map[string]interface{}, connectors []DexConnector) {
+	var all []interface{}
+	switch existing := dex["connectors"].(type) {
+	case []interface{}:
+		all = append(all, existing...)
+	case []DexConnector:
+		for _, connector := range existing {
+			all = append(all, connector)
+		}
+	}
+	for _, connector := range connectors {
+		all = append(all, connector)
+	}
+	dex["connectors"] = all
+}
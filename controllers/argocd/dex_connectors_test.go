@@ -0,0 +1,175 @@
+package argocd
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func secretKeySelector(name, key string) corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+}
+
+func makeTestArgoCDWithDexConnectors(connectors ...argoproj.ArgoCDDexConnector) *argoproj.ArgoCD {
+	return makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex:      &argoproj.ArgoCDDexSpec{Connectors: connectors},
+		}
+	})
+}
+
+func TestGetDexConfigWithConnectors(t *testing.T) {
+	ldapCA := secretKeySelector("ldap", "ca.crt")
+	cr := makeTestArgoCDWithDexConnectors(
+		argoproj.ArgoCDDexConnector{
+			ID:   "github",
+			Name: "GitHub",
+			Type: argoproj.ArgoCDDexConnectorTypeGitHub,
+			GitHub: &argoproj.ArgoCDDexGitHubConnector{
+				ClientID:        "client",
+				ClientSecretRef: secretKeySelector("github", "clientSecret"),
+				Orgs:            []argoproj.ArgoCDDexGitHubOrg{{Name: "example", Teams: []string{"platform"}}},
+			},
+		},
+		argoproj.ArgoCDDexConnector{
+			ID:   "ldap",
+			Name: "LDAP",
+			Type: argoproj.ArgoCDDexConnectorTypeLDAP,
+			LDAP: &argoproj.ArgoCDDexLDAPConnector{
+				Host:      "ldap.example.com:636",
+				RootCARef: &ldapCA,
+				BindDN:    "cn=admin,dc=example,dc=com",
+				BindPWRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ldap"}, Key: "password"},
+				UserSearch: argoproj.ArgoCDDexLDAPUserSearch{
+					BaseDN:    "ou=people,dc=example,dc=com",
+					Username:  "uid",
+					IDAttr:    "uid",
+					EmailAttr: "mail",
+				},
+			},
+		},
+	)
+	cr.Spec.SSO.Dex.Config = "connectors:\n- type: mock\n  id: mock\n  name: Mock\nlogger:\n  level: debug\n"
+
+	config, err := getDexConfigWithConnectors(cr)
+	require.NoError(t, err)
+
+	dex := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(config), dex))
+	assert.Equal(t, map[interface{}]interface{}{"level": "debug"}, dex["logger"])
+
+	connectors := dex["connectors"].([]interface{})
+	require.Len(t, connectors, 3)
+	assert.Equal(t, "mock", connectors[0].(map[interface{}]interface{})["id"])
+
+	github := connectors[1].(map[interface{}]interface{})
+	assert.Equal(t, "github", github["type"])
+	githubConfig := github["config"].(map[interface{}]interface{})
+	assert.Equal(t, "client", githubConfig["clientID"])
+	assert.Equal(t, "$dex.connector.github.clientSecret", githubConfig["clientSecret"])
+
+	ldapConfig := connectors[2].(map[interface{}]interface{})["config"].(map[interface{}]interface{})
+	assert.Equal(t, "$dex.connector.ldap.rootCAData", ldapConfig["rootCAData"])
+	assert.Equal(t, "$dex.connector.ldap.bindPW", ldapConfig["bindPW"])
+	assert.Equal(t, "uid", ldapConfig["userSearch"].(map[interface{}]interface{})["idAttr"])
+}
+
+func TestApplyDexConnectorSecrets(t *testing.T) {
+	cr := makeTestArgoCDWithDexConnectors(
+		argoproj.ArgoCDDexConnector{
+			ID:   "oidc",
+			Name: "OIDC",
+			Type: argoproj.ArgoCDDexConnectorTypeOIDC,
+			OIDC: &argoproj.ArgoCDDexOIDCConnector{
+				Issuer:          "https://idp.example.com",
+				ClientID:        "argocd",
+				ClientSecretRef: secretKeySelector("idp", "clientSecret"),
+			},
+		},
+		argoproj.ArgoCDDexConnector{
+			ID:   "saml",
+			Name: "SAML",
+			Type: argoproj.ArgoCDDexConnectorTypeSAML,
+			SAML: &argoproj.ArgoCDDexSAMLConnector{
+				SSOURL:       "https://saml.example.com/sso",
+				CARef:        secretKeySelector("idp", "ca.crt"),
+				UsernameAttr: "name",
+				EmailAttr:    "email",
+			},
+		},
+	)
+	idp := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp", Namespace: testNamespace},
+		Data: map[string][]byte{
+			"clientSecret": []byte("s3cr3t"),
+			"ca.crt":       []byte("-----BEGIN CERTIFICATE-----"),
+		},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, idp}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	argocdSecret := &corev1.Secret{Data: map[string][]byte{
+		"dex.connector.removed.clientSecret": []byte("stale"),
+		"server.secretkey":                   []byte("key"),
+	}}
+	var changes []string
+	require.NoError(t, applyDexConnectorSecrets(context.TODO(), r.Client, cr, argocdSecret, &changes))
+
+	assert.Equal(t, map[string][]byte{
+		"dex.connector.oidc.clientSecret": []byte("s3cr3t"),
+		"dex.connector.saml.caData":       []byte(base64.StdEncoding.EncodeToString([]byte("-----BEGIN CERTIFICATE-----"))),
+		"server.secretkey":                []byte("key"),
+	}, argocdSecret.Data)
+	assert.Len(t, changes, 1)
+
+	// Rotating the referenced Secret updates argocd-secret.
+	idp.Data["clientSecret"] = []byte("rotated")
+	require.NoError(t, r.Update(context.TODO(), idp))
+	changes = nil
+	require.NoError(t, applyDexConnectorSecrets(context.TODO(), r.Client, cr, argocdSecret, &changes))
+	assert.Equal(t, []byte("rotated"), argocdSecret.Data["dex.connector.oidc.clientSecret"])
+	assert.Equal(t, []string{"dex connector secrets (dex.connector.oidc.clientSecret)"}, changes)
+
+	// Disabling Dex removes the connector keys.
+	cr.Spec.SSO = nil
+	require.NoError(t, applyDexConnectorSecrets(context.TODO(), r.Client, cr, argocdSecret, &changes))
+	assert.Equal(t, map[string][]byte{"server.secretkey": []byte("key")}, argocdSecret.Data)
+}
+
+func TestReconcileArgoCD_dexConnectorSecretMapper(t *testing.T) {
+	cr := makeTestArgoCDWithDexConnectors(argoproj.ArgoCDDexConnector{
+		ID:   "gitlab",
+		Name: "GitLab",
+		Type: argoproj.ArgoCDDexConnectorTypeGitLab,
+		GitLab: &argoproj.ArgoCDDexGitLabConnector{
+			ClientID:        "argocd",
+			ClientSecretRef: secretKeySelector("gitlab-oauth", "secret"),
+		},
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	referenced := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "gitlab-oauth", Namespace: testNamespace}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}},
+	}, r.dexConnectorSecretMapper(context.TODO(), referenced))
+
+	other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: testNamespace}}
+	assert.Empty(t, r.dexConnectorSecretMapper(context.TODO(), other))
+}
@@ -20,6 +20,10 @@ import (
 
 	autoscaling "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -32,6 +36,26 @@ var (
 	tcup        int32 = 50
 )
 
+var kedaAPIFound = false
+
+// scaledObjectGVK is the GroupVersionKind of the KEDA ScaledObject.
+var scaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+
+// IsKEDAAPIAvailable returns true if the KEDA API is present.
+func IsKEDAAPIAvailable() bool {
+	return kedaAPIFound
+}
+
+// verifyKEDAAPI will verify that the KEDA API is present.
+func verifyKEDAAPI() error {
+	found, err := argoutil.VerifyAPI(scaledObjectGVK.Group, scaledObjectGVK.Version)
+	if err != nil {
+		return err
+	}
+	kedaAPIFound = found
+	return nil
+}
+
 func newHorizontalPodAutoscaler(cr *argoproj.ArgoCD) *autoscaling.HorizontalPodAutoscaler {
 	return &autoscaling.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
@@ -114,10 +138,198 @@ func (r *ReconcileArgoCD) reconcileServerHPA(cr *argoproj.ArgoCD) error {
 	return r.Create(context.TODO(), defaultHPA)
 }
 
+func newScaledObjectWithSuffix(suffix string, cr *argoproj.ArgoCD) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(scaledObjectGVK)
+	obj.SetName(nameWithSuffix(suffix, cr))
+	obj.SetNamespace(cr.Namespace)
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = obj.GetName()
+	obj.SetLabels(lbls)
+	return obj
+}
+
+// isRepoServerAutoscaled returns whether the replicas of the Argo CD Repo Server are managed by an autoscaler.
+func isRepoServerAutoscaled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Repo.IsEnabled() && !cr.Spec.Repo.IsRemote() && cr.Spec.Repo.Autoscale.IsEnabled()
+}
+
+// isApplicationSetAutoscaled returns whether the replicas of the ApplicationSet controller are managed by an autoscaler.
+func isApplicationSetAutoscaled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.IsEnabled() && cr.Spec.ApplicationSet.Autoscale.IsEnabled()
+}
+
+// getDeploymentHPASpec returns the HorizontalPodAutoscaler spec scaling the Deployment with the given name.
+func getDeploymentHPASpec(name string, autoscale *argoproj.ArgoCDAutoscaleSpec) autoscaling.HorizontalPodAutoscalerSpec {
+	spec := autoscaling.HorizontalPodAutoscalerSpec{
+		MaxReplicas:                    maxReplicas,
+		MinReplicas:                    &minReplicas,
+		TargetCPUUtilizationPercentage: &tcup,
+	}
+	if autoscale.HPA != nil {
+		spec = *autoscale.HPA.DeepCopy()
+	}
+	spec.ScaleTargetRef = autoscaling.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       name,
+	}
+	return spec
+}
+
+// getScaledObjectSpec returns the KEDA ScaledObject spec scaling the Deployment with the given name.
+func getScaledObjectSpec(name string, keda *argoproj.ArgoCDKEDASpec) (map[string]interface{}, error) {
+	type trigger struct {
+		Type              string            `json:"type"`
+		Name              string            `json:"name,omitempty"`
+		MetricType        string            `json:"metricType,omitempty"`
+		Metadata          map[string]string `json:"metadata"`
+		AuthenticationRef map[string]string `json:"authenticationRef,omitempty"`
+	}
+	spec := struct {
+		ScaleTargetRef  map[string]string `json:"scaleTargetRef"`
+		MinReplicaCount int32             `json:"minReplicaCount"`
+		MaxReplicaCount int32             `json:"maxReplicaCount"`
+		PollingInterval *int32            `json:"pollingInterval,omitempty"`
+		CooldownPeriod  *int32            `json:"cooldownPeriod,omitempty"`
+		Triggers        []trigger         `json:"triggers"`
+	}{
+		ScaleTargetRef:  map[string]string{"name": name},
+		MinReplicaCount: minReplicas,
+		MaxReplicaCount: maxReplicas,
+		PollingInterval: keda.PollingInterval,
+		CooldownPeriod:  keda.CooldownPeriod,
+	}
+	if keda.MinReplicaCount != nil {
+		spec.MinReplicaCount = *keda.MinReplicaCount
+	}
+	if keda.MaxReplicaCount != nil {
+		spec.MaxReplicaCount = *keda.MaxReplicaCount
+	}
+	for _, t := range keda.Triggers {
+		tr := trigger{Type: t.Type, Name: t.Name, MetricType: t.MetricType, Metadata: t.Metadata}
+		if t.AuthenticationRef != "" {
+			tr.AuthenticationRef = map[string]string{"name": t.AuthenticationRef}
+		}
+		spec.Triggers = append(spec.Triggers, tr)
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+}
+
+// reconcileDeploymentAutoscaler will ensure that the Deployment with the given suffix is scaled by a KEDA
+// ScaledObject when KEDA is requested and available, or by a HorizontalPodAutoscaler otherwise, and that the
+// autoscalers are removed when autoscaling is disabled.
+func (r *ReconcileArgoCD) reconcileDeploymentAutoscaler(cr *argoproj.ArgoCD, suffix string, enabled bool, autoscale *argoproj.ArgoCDAutoscaleSpec) error {
+	var hpaSpec *autoscaling.HorizontalPodAutoscalerSpec
+	var scaledObjectSpec map[string]interface{}
+	if enabled {
+		name := nameWithSuffix(suffix, cr)
+		if autoscale.KEDA != nil && IsKEDAAPIAvailable() {
+			spec, err := getScaledObjectSpec(name, autoscale.KEDA)
+			if err != nil {
+				return err
+			}
+			scaledObjectSpec = spec
+		} else {
+			if autoscale.KEDA != nil {
+				log.Info("KEDA API is not available, using a HorizontalPodAutoscaler", "name", name)
+			}
+			spec := getDeploymentHPASpec(name, autoscale)
+			hpaSpec = &spec
+		}
+	}
+
+	if err := r.reconcileDeploymentScaledObject(cr, suffix, scaledObjectSpec); err != nil {
+		return err
+	}
+	return r.reconcileDeploymentHPA(cr, suffix, hpaSpec)
+}
+
+// reconcileDeploymentHPA will ensure that the HorizontalPodAutoscaler with the given suffix has the given spec, or
+// is removed when the spec is nil.
+func (r *ReconcileArgoCD) reconcileDeploymentHPA(cr *argoproj.ArgoCD, suffix string, spec *autoscaling.HorizontalPodAutoscalerSpec) error {
+	hpa := newHorizontalPodAutoscalerWithSuffix(suffix, cr)
+	exists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, hpa.Name, hpa)
+	if err != nil {
+		return err
+	}
+
+	if spec == nil {
+		if exists {
+			argoutil.LogResourceDeletion(log, hpa, "autoscaling with a HorizontalPodAutoscaler is disabled")
+			return r.Delete(context.TODO(), hpa)
+		}
+		return nil
+	}
+
+	if exists {
+		if !reflect.DeepEqual(hpa.Spec, *spec) {
+			hpa.Spec = *spec
+			argoutil.LogResourceUpdate(log, hpa, "due to differences from ArgoCD CR")
+			return r.Update(context.TODO(), hpa)
+		}
+		return nil
+	}
+
+	hpa.Spec = *spec
+	if err := controllerutil.SetControllerReference(cr, hpa, r.Scheme); err != nil {
+		return err
+	}
+	argoutil.LogResourceCreation(log, hpa)
+	return r.Create(context.TODO(), hpa)
+}
+
+// reconcileDeploymentScaledObject will ensure that the KEDA ScaledObject with the given suffix has the given spec, or
+// is removed when the spec is nil.
+func (r *ReconcileArgoCD) reconcileDeploymentScaledObject(cr *argoproj.ArgoCD, suffix string, spec map[string]interface{}) error {
+	if !IsKEDAAPIAvailable() {
+		return nil
+	}
+
+	scaledObject := newScaledObjectWithSuffix(suffix, cr)
+	exists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, scaledObject.GetName(), scaledObject)
+	if err != nil {
+		return err
+	}
+
+	if spec == nil {
+		if exists {
+			argoutil.LogResourceDeletion(log, scaledObject, "autoscaling with KEDA is disabled")
+			return r.Delete(context.TODO(), scaledObject)
+		}
+		return nil
+	}
+
+	if exists {
+		if !reflect.DeepEqual(scaledObject.Object["spec"], spec) {
+			scaledObject.Object["spec"] = spec
+			argoutil.LogResourceUpdate(log, scaledObject, "due to differences from ArgoCD CR")
+			return r.Update(context.TODO(), scaledObject)
+		}
+		return nil
+	}
+
+	scaledObject.Object["spec"] = spec
+	if err := controllerutil.SetControllerReference(cr, scaledObject, r.Scheme); err != nil {
+		return err
+	}
+	argoutil.LogResourceCreation(log, scaledObject)
+	return r.Create(context.TODO(), scaledObject)
+}
+
 // reconcileAutoscalers will ensure that all HorizontalPodAutoscalers are present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileAutoscalers(cr *argoproj.ArgoCD) error {
 	if err := r.reconcileServerHPA(cr); err != nil {
 		return err
 	}
-	return nil
+
+	if err := r.reconcileDeploymentAutoscaler(cr, "repo-server", isRepoServerAutoscaled(cr), cr.Spec.Repo.Autoscale); err != nil {
+		return err
+	}
+
+	var appSetAutoscale *argoproj.ArgoCDAutoscaleSpec
+	if cr.Spec.ApplicationSet != nil {
+		appSetAutoscale = cr.Spec.ApplicationSet.Autoscale
+	}
+	return r.reconcileDeploymentAutoscaler(cr, "applicationset-controller", isApplicationSetAutoscaled(cr), appSetAutoscale)
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	autoscaling "k8s.io/api/autoscaling/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -100,3 +102,103 @@ func TestReconcileHPA(t *testing.T) {
 	assert.True(t, errors.IsNotFound(err))
 
 }
+
+func TestReconcileAutoscalers_repoServer(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Replicas = &min
+		a.Spec.Repo.Autoscale = &argoproj.ArgoCDAutoscaleSpec{
+			Enabled: true,
+			HPA: &autoscaling.HorizontalPodAutoscalerSpec{
+				MinReplicas: &min,
+				MaxReplicas: max,
+			},
+		}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileAutoscalers(a))
+	hpa := &autoscaling.HorizontalPodAutoscaler{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, hpa))
+	assert.Equal(t, autoscaling.HorizontalPodAutoscalerSpec{
+		MinReplicas: &min,
+		MaxReplicas: max,
+		ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "argocd-repo-server",
+		},
+	}, hpa.Spec)
+
+	// The replicas set by the autoscaler are not reverted by the repo server reconciler.
+	require.NoError(t, r.reconcileRepoDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Nil(t, deployment.Spec.Replicas)
+	deployment.Spec.Replicas = &max
+	require.NoError(t, r.Update(context.TODO(), deployment))
+	require.NoError(t, r.reconcileRepoDeployment(a, false))
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, max, *deployment.Spec.Replicas)
+
+	// Disabling autoscaling removes the HorizontalPodAutoscaler.
+	a.Spec.Repo.Autoscale.Enabled = false
+	require.NoError(t, r.reconcileAutoscalers(a))
+	err := r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, hpa)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileAutoscalers_applicationSetKEDA(t *testing.T) {
+	kedaAPIFound = true
+	defer func() {
+		kedaAPIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+			Autoscale: &argoproj.ArgoCDAutoscaleSpec{
+				Enabled: true,
+				KEDA: &argoproj.ArgoCDKEDASpec{
+					MaxReplicaCount: &max,
+					Triggers: []argoproj.ArgoCDKEDATrigger{{
+						Type:       "cpu",
+						MetricType: "Utilization",
+						Metadata:   map[string]string{"value": "60"},
+					}},
+				},
+			},
+		}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileAutoscalers(a))
+	scaledObject := newScaledObjectWithSuffix("applicationset-controller", a)
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: testNamespace}, scaledObject))
+	assert.Equal(t, map[string]interface{}{
+		"scaleTargetRef":  map[string]interface{}{"name": "argocd-applicationset-controller"},
+		"minReplicaCount": int64(minReplicas),
+		"maxReplicaCount": int64(max),
+		"triggers": []interface{}{map[string]interface{}{
+			"type":       "cpu",
+			"metricType": "Utilization",
+			"metadata":   map[string]interface{}{"value": "60"},
+		}},
+	}, scaledObject.Object["spec"])
+
+	hpa := &autoscaling.HorizontalPodAutoscaler{}
+	err := r.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: testNamespace}, hpa)
+	assert.True(t, errors.IsNotFound(err))
+
+	cmd, err := r.getArgoApplicationSetCommand(a)
+	require.NoError(t, err)
+	assert.Contains(t, cmd, "--enable-leader-election")
+
+	// Without the KEDA API, a HorizontalPodAutoscaler is used instead.
+	kedaAPIFound = false
+	require.NoError(t, r.reconcileAutoscalers(a))
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: testNamespace}, hpa))
+	assert.Equal(t, "argocd-applicationset-controller", hpa.Spec.ScaleTargetRef.Name)
+}
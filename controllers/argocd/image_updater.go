@@ -3,7 +3,6 @@ package argocd
 import (
 	"context"
 	"fmt"
-	"os"
 	"reflect"
 	"strings"
 
@@ -626,7 +625,7 @@ func (r *ReconcileArgoCD) reconcileImageUpdaterDeployment(cr *argoproj.ArgoCD, s
 		},
 	}
 
-	image := os.Getenv(common.ArgoCDImageUpdaterImageEnvName)
+	image := argoutil.GetOperatorSetting(common.ArgoCDImageUpdaterImageEnvName)
 	if image == "" {
 		image = argoutil.CombineImageTag(DefaultImageUpdaterImage, DefaultImageUpdaterTag)
 	}
@@ -0,0 +1,290 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// maxMutationWebhookResponseSize is the maximum size of the response of a mutation webhook.
+const maxMutationWebhookResponseSize = 1 << 20
+
+// mutationWebhookRequest is the body POSTed to a mutation webhook.
+type mutationWebhookRequest struct {
+	// Component is the Argo CD component the object belongs to, e.g. argocd-server.
+	Component string `json:"component"`
+	// ArgoCD is the ArgoCD the object is generated for.
+	ArgoCD mutationWebhookArgoCD `json:"argoCD"`
+	// Hint is the hint passed to the compiled-in hooks, e.g. the version of the cluster.
+	Hint string `json:"hint,omitempty"`
+	// Object is the object as generated by the operator.
+	Object json.RawMessage `json:"object"`
+}
+
+type mutationWebhookArgoCD struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// mutationWebhookResponse is the body returned by a mutation webhook.
+type mutationWebhookResponse struct {
+	// Patch is the RFC 6902 JSON patch applied to the object. The object is left unchanged when empty.
+	Patch json.RawMessage `json:"patch,omitempty"`
+}
+
+// mutationWebhookResult is the last result of a mutation webhook for a resource.
+type mutationWebhookResult struct {
+	// hash is the hash of the webhook and of the request it was sent.
+	hash string
+	// patch is the JSON patch the webhook returned, empty when it left the resource unchanged.
+	patch string
+}
+
+// applyMutationWebhooks sends the given object, generated for the given component, to the mutation webhooks of the
+// given ArgoCD, in order, and applies the JSON patches they return to it. A webhook is only called again when the
+// object it is sent or its spec changed, its last patch is applied otherwise. A mutation is reported by an Event on
+// the ArgoCD the first time it is applied to the object.
+//
+// The mutation webhooks are configured in the namespace of the ArgoCD, so they cannot mutate RBAC objects or objects
+// outside of that namespace, which would let them grant more than the ArgoCD is allowed to. For the same reason, they
+// are only called over plain http when the operator allows it.
+func (r *ReconcileArgoCD) applyMutationWebhooks(cr *argoproj.ArgoCD, obj client.Object, component, hint string) error {
+	if len(cr.Spec.MutationWebhooks) == 0 {
+		return nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return err
+	}
+	if gvk.Group == rbacv1.GroupName {
+		return fmt.Errorf("mutation webhooks cannot mutate the RBAC resource %s %s", gvk.Kind, obj.GetName())
+	}
+	if obj.GetNamespace() != cr.Namespace {
+		return fmt.Errorf("mutation webhooks cannot mutate %s %s outside of namespace %s", gvk.Kind, obj.GetName(), cr.Namespace)
+	}
+
+	for _, webhook := range cr.Spec.MutationWebhooks {
+		if len(webhook.Components) > 0 && !slices.Contains(webhook.Components, component) {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%s/%s/%s/%s", cr.Namespace, cr.Name, webhook.Name, gvk.Kind, obj.GetNamespace(), obj.GetName())
+
+		var patch string
+		var changed bool
+		err := validateMutationWebhookURL(webhook.URL)
+		if err == nil {
+			patch, changed, err = r.applyMutationWebhook(key, webhook, cr, obj, gvk, component, hint)
+		}
+		if err != nil {
+			err = fmt.Errorf("mutation webhook %s failed to mutate %s %s: %w", webhook.Name, gvk.Kind, obj.GetName(), err)
+			if webhook.FailurePolicy == argoproj.MutationWebhookFailurePolicyIgnore {
+				log.Error(err, "ignoring mutation webhook failure")
+				continue
+			}
+			return err
+		}
+		if !changed {
+			continue
+		}
+		message := fmt.Sprintf("mutation webhook %s patched %s %s: %s", webhook.Name, gvk.Kind, obj.GetName(), patch)
+		log.Info(message)
+		if err := argoutil.CreateEvent(r.Client, corev1.EventTypeNormal, "Mutated", message, "ResourceMutated", cr.ObjectMeta, cr.TypeMeta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMutationWebhookURL returns an error if the given URL of a mutation webhook does not use https, unless the
+// operator allows plain http.
+func validateMutationWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	switch {
+	case u.Scheme == "https":
+		return nil
+	case u.Scheme == "http" && argoutil.GetOperatorSetting(common.ArgoCDAllowInsecureMutationWebhooksEnvName) == "true":
+		return nil
+	case u.Scheme == "http":
+		return fmt.Errorf("the URL must use https, plain http is only allowed when insecureMutationWebhooksAllowed is set in the ArgoCDOperatorConfig")
+	}
+	return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+}
+
+// applyMutationWebhook sends the given object to the given mutation webhook, unless it was already sent to the same
+// webhook under the given key, and applies the JSON patch the webhook returned to the object. It returns the applied
+// patch, which is empty when the object is left unchanged, and whether it differs from the last one applied.
+func (r *ReconcileArgoCD) applyMutationWebhook(key string, webhook argoproj.ArgoCDMutationWebhookSpec, cr *argoproj.ArgoCD, obj client.Object, gvk schema.GroupVersionKind, component, hint string) (string, bool, error) {
+	// The object is sent with its kind, which typed objects do not carry.
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return "", false, err
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(original, &object); err != nil {
+		return "", false, err
+	}
+	object["apiVersion"], object["kind"] = gvk.GroupVersion().String(), gvk.Kind
+	if original, err = json.Marshal(object); err != nil {
+		return "", false, err
+	}
+
+	body, err := json.Marshal(mutationWebhookRequest{
+		Component: component,
+		ArgoCD:    mutationWebhookArgoCD{Name: cr.Name, Namespace: cr.Namespace},
+		Hint:      hint,
+		Object:    original,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	spec, err := json.Marshal(webhook)
+	if err != nil {
+		return "", false, err
+	}
+	sum := sha256.Sum256(append(append(spec, 0), body...))
+	hash := hex.EncodeToString(sum[:])
+
+	var patch string
+	previous, cached := r.mutationWebhookPatches.Load(key)
+	if cached && previous.(mutationWebhookResult).hash == hash {
+		patch = previous.(mutationWebhookResult).patch
+	} else {
+		response, err := postMutationWebhook(webhook, body)
+		if err != nil {
+			return "", false, err
+		}
+		if patch = string(response.Patch); patch == "null" || patch == "[]" {
+			patch = ""
+		}
+	}
+
+	if patch != "" {
+		if err := applyMutationPatch(obj, gvk, original, []byte(patch)); err != nil {
+			return "", false, err
+		}
+	}
+	r.mutationWebhookPatches.Store(key, mutationWebhookResult{hash: hash, patch: patch})
+	changed := patch != "" && (!cached || previous.(mutationWebhookResult).patch != patch)
+	return patch, changed, nil
+}
+
+// applyMutationPatch applies the given JSON patch to the given object, whose JSON encoding with its kind is given.
+func applyMutationPatch(obj client.Object, gvk schema.GroupVersionKind, original, patchJSON []byte) error {
+	patch, err := jsonpatch.DecodePatch(patchJSON)
+	if err != nil {
+		return fmt.Errorf("invalid JSON patch: %w", err)
+	}
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return fmt.Errorf("failed to apply JSON patch: %w", err)
+	}
+
+	mutated := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+	if err := json.Unmarshal(patched, mutated); err != nil {
+		return fmt.Errorf("failed to decode the patched object: %w", err)
+	}
+	if mutated.GetObjectKind().GroupVersionKind() != gvk || mutated.GetName() != obj.GetName() || mutated.GetNamespace() != obj.GetNamespace() {
+		return fmt.Errorf("the patch must not change the apiVersion, kind, name or namespace of the object")
+	}
+	// The object is passed by the reconciler, which goes on with the mutated object without its kind.
+	mutated.GetObjectKind().SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(mutated).Elem())
+	return nil
+}
+
+// postMutationWebhook POSTs the given body to the given mutation webhook and returns its response.
+func postMutationWebhook(webhook argoproj.ArgoCDMutationWebhookSpec, body []byte) (*mutationWebhookResponse, error) {
+	timeout := time.Duration(common.ArgoCDDefaultMutationWebhookTimeoutSeconds) * time.Second
+	if webhook.TimeoutSeconds != nil {
+		timeout = time.Duration(*webhook.TimeoutSeconds) * time.Second
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if webhook.CABundle != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(webhook.CABundle)) {
+			return nil, fmt.Errorf("the CA bundle holds no PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	httpClient := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+		// Redirects are not followed, since they could lead to a plain http endpoint.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// The body of a failed response is not reported, since it would disclose the response of any endpoint the operator
+	// can reach to whoever can edit the ArgoCD.
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMutationWebhookResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &mutationWebhookResponse{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return response, nil
+	}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return response, nil
+}
@@ -0,0 +1,309 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// newTestMutationWebhook returns a server recording the requests it receives and responding with the given body. Plain
+// http webhooks are allowed when the server does not use TLS.
+func newTestMutationWebhook(t *testing.T, tls bool, response string) (*httptest.Server, *[]mutationWebhookRequest) {
+	requests := &[]mutationWebhookRequest{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		request := mutationWebhookRequest{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+		*requests = append(*requests, request)
+		_, _ = w.Write([]byte(response))
+	})
+	var server *httptest.Server
+	if tls {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		t.Setenv(common.ArgoCDAllowInsecureMutationWebhooksEnvName, "true")
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func withMutationWebhooks(webhooks ...argoproj.ArgoCDMutationWebhookSpec) argoCDOpt {
+	return func(a *argoproj.ArgoCD) {
+		a.Spec.MutationWebhooks = webhooks
+	}
+}
+
+func listTestEvents(t *testing.T, cl client.Client, reason string) []corev1.Event {
+	events := &corev1.EventList{}
+	require.NoError(t, cl.List(context.TODO(), events))
+	var matching []corev1.Event
+	for _, event := range events.Items {
+		if event.Reason == reason {
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+func TestReconcileArgoCD_applyMutationWebhooks(t *testing.T) {
+	server, requests := newTestMutationWebhook(t, true, `{"patch":[{"op":"replace","path":"/spec/replicas","value":3}]}`)
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	cr := makeTestArgoCD(withMutationWebhooks(argoproj.ArgoCDMutationWebhookSpec{
+		Name:     "replicas",
+		URL:      server.URL,
+		CABundle: caBundle,
+	}))
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	deploy := makeTestDeployment()
+	require.NoError(t, r.applyMutationWebhooks(cr, deploy, common.ArgoCDServerComponent, "4.14"))
+
+	assert.Equal(t, ptr.To(int32(3)), deploy.Spec.Replicas)
+	assert.Empty(t, deploy.Kind, "the kind sent to the webhook is not kept on the typed object")
+	require.Len(t, *requests, 1)
+	request := (*requests)[0]
+	assert.Equal(t, common.ArgoCDServerComponent, request.Component)
+	assert.Equal(t, mutationWebhookArgoCD{Name: cr.Name, Namespace: cr.Namespace}, request.ArgoCD)
+	assert.Equal(t, "4.14", request.Hint)
+	object := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(request.Object, &object))
+	assert.Equal(t, "Deployment", object["kind"])
+	assert.Equal(t, "apps/v1", object["apiVersion"])
+
+	events := listTestEvents(t, cl, "ResourceMutated")
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0].Message, "mutation webhook replicas patched Deployment "+deploy.Name)
+
+	// The webhook is not called again for the same object, whose last patch is applied.
+	deploy = makeTestDeployment()
+	require.NoError(t, r.applyMutationWebhooks(cr, deploy, common.ArgoCDServerComponent, "4.14"))
+	assert.Equal(t, ptr.To(int32(3)), deploy.Spec.Replicas)
+	assert.Len(t, *requests, 1)
+
+	// The webhook is called again when the request changes, and the same mutation is only reported once.
+	deploy = makeTestDeployment()
+	require.NoError(t, r.applyMutationWebhooks(cr, deploy, common.ArgoCDServerComponent, ""))
+	assert.Equal(t, ptr.To(int32(3)), deploy.Spec.Replicas)
+	assert.Len(t, *requests, 2)
+	assert.Len(t, listTestEvents(t, cl, "ResourceMutated"), 1)
+}
+
+func TestReconcileArgoCD_applyMutationWebhooks_refusedObjects(t *testing.T) {
+	server, requests := newTestMutationWebhook(t, false, `{}`)
+
+	cr := makeTestArgoCD(withMutationWebhooks(argoproj.ArgoCDMutationWebhookSpec{
+		Name: "any",
+		URL:  server.URL,
+	}))
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	otherNamespace := makeTestDeployment()
+	otherNamespace.Namespace = "other"
+	for _, obj := range []client.Object{
+		newClusterRole(common.ArgoCDApplicationSetControllerComponent, nil, cr),
+		newRole(common.ArgoCDServerComponent, nil, cr),
+		otherNamespace,
+	} {
+		err := r.applyMutationWebhooks(cr, obj, common.ArgoCDServerComponent, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mutation webhooks cannot mutate")
+	}
+	assert.Empty(t, *requests)
+}
+
+func TestReconcileArgoCD_applyMutationWebhooks_components(t *testing.T) {
+	server, requests := newTestMutationWebhook(t, false, `{}`)
+
+	cr := makeTestArgoCD(withMutationWebhooks(argoproj.ArgoCDMutationWebhookSpec{
+		Name:       "repo-server",
+		URL:        server.URL,
+		Components: []string{common.ArgoCDRepoServerComponent},
+	}))
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.applyMutationWebhooks(cr, makeTestDeployment(), common.ArgoCDServerComponent, ""))
+	assert.Empty(t, *requests)
+
+	deploy := makeTestDeployment()
+	require.NoError(t, r.applyMutationWebhooks(cr, deploy, common.ArgoCDRepoServerComponent, ""))
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, makeTestDeployment(), deploy)
+	assert.Empty(t, listTestEvents(t, cl, "ResourceMutated"))
+}
+
+func TestReconcileArgoCD_applyMutationWebhooks_failurePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      string
+		failurePolicy argoproj.MutationWebhookFailurePolicy
+		wantErr       string
+	}{
+		{
+			name:     "invalid patch fails",
+			response: `{"patch":[{"op":"replace","path":"/spec/missing/field","value":1}]}`,
+			wantErr:  "mutation webhook policy failed to mutate Deployment argocd-application-controller: failed to apply JSON patch",
+		},
+		{
+			name:     "renaming the object fails",
+			response: `{"patch":[{"op":"replace","path":"/metadata/name","value":"renamed"}]}`,
+			wantErr:  "the patch must not change the apiVersion, kind, name or namespace of the object",
+		},
+		{
+			name:          "invalid patch is ignored",
+			response:      `{"patch":[{"op":"replace","path":"/spec/missing/field","value":1}]}`,
+			failurePolicy: argoproj.MutationWebhookFailurePolicyIgnore,
+		},
+		{
+			name:          "invalid response is ignored",
+			response:      `not json`,
+			failurePolicy: argoproj.MutationWebhookFailurePolicyIgnore,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, _ := newTestMutationWebhook(t, false, test.response)
+
+			cr := makeTestArgoCD(withMutationWebhooks(argoproj.ArgoCDMutationWebhookSpec{
+				Name:          "policy",
+				URL:           server.URL,
+				FailurePolicy: test.failurePolicy,
+			}))
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+			r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+			deploy := makeTestDeployment()
+			err := r.applyMutationWebhooks(cr, deploy, common.ArgoCDServerComponent, "")
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, makeTestDeployment(), deploy, "a failed mutation leaves the object unchanged")
+		})
+	}
+}
+
+func TestReconcileArgoCD_applyMutationWebhooks_untrustedCertificate(t *testing.T) {
+	server, requests := newTestMutationWebhook(t, true, `{}`)
+
+	cr := makeTestArgoCD(withMutationWebhooks(argoproj.ArgoCDMutationWebhookSpec{
+		Name:           "untrusted",
+		URL:            server.URL,
+		TimeoutSeconds: ptr.To(int32(1)),
+	}))
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	err := r.applyMutationWebhooks(cr, makeTestDeployment(), common.ArgoCDServerComponent, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate")
+	assert.Empty(t, *requests)
+}
+
+func TestReconcileArgoCD_applyMutationWebhooks_insecure(t *testing.T) {
+	server, requests := newTestMutationWebhook(t, false, `{}`)
+
+	cr := makeTestArgoCD(withMutationWebhooks(argoproj.ArgoCDMutationWebhookSpec{
+		Name: "insecure",
+		URL:  server.URL,
+	}))
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	// A plain http webhook is only called when the operator allows it.
+	t.Setenv(common.ArgoCDAllowInsecureMutationWebhooksEnvName, "false")
+	err := r.applyMutationWebhooks(cr, makeTestDeployment(), common.ArgoCDServerComponent, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the URL must use https")
+	assert.Empty(t, *requests)
+
+	t.Setenv(common.ArgoCDAllowInsecureMutationWebhooksEnvName, "true")
+	require.NoError(t, r.applyMutationWebhooks(cr, makeTestDeployment(), common.ArgoCDServerComponent, ""))
+	assert.Len(t, *requests, 1)
+}
+
+func TestReconcileArgoCD_applyMutationWebhooks_failedResponse(t *testing.T) {
+	t.Setenv(common.ArgoCDAllowInsecureMutationWebhooksEnvName, "true")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("internal secret"))
+	}))
+	t.Cleanup(server.Close)
+
+	cr := makeTestArgoCD(withMutationWebhooks(argoproj.ArgoCDMutationWebhookSpec{
+		Name: "forbidden",
+		URL:  server.URL,
+	}))
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	// The body of a failed response is not reported.
+	err := r.applyMutationWebhooks(cr, makeTestDeployment(), common.ArgoCDServerComponent, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403 Forbidden")
+	assert.NotContains(t, err.Error(), "internal secret")
+}
+
+func TestReconcileArgoCD_applyMutationWebhooks_applicationController(t *testing.T) {
+	server, requests := newTestMutationWebhook(t, false, `{"patch":[{"op":"add","path":"/metadata/labels/policy","value":"applied"}]}`)
+
+	cr := makeTestArgoCD(withMutationWebhooks(argoproj.ArgoCDMutationWebhookSpec{
+		Name:       "policy",
+		URL:        server.URL,
+		Components: []string{common.ArgoCDApplicationControllerComponent},
+	}))
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{cr}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileApplicationControllerStatefulSet(cr, false))
+
+	ss := &appsv1.StatefulSet{}
+	require.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: applicationControllerResourceName(cr), Namespace: cr.Namespace}, ss))
+	assert.Equal(t, "applied", ss.Labels["policy"])
+	require.Len(t, *requests, 1)
+	assert.Equal(t, common.ArgoCDApplicationControllerComponent, (*requests)[0].Component)
+}
@@ -3,7 +3,6 @@ package argocd
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/argoproj/argo-cd/v3/util/glob"
@@ -14,6 +13,7 @@ import (
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
 // reconcileNamespaceManagement ensures that ArgoCD managed namespaces are properly tracked
@@ -118,7 +118,7 @@ func matchesNamespaceManagementRules(allowedPatterns []string, namespace string)
 
 // Check if namespace management is explicitly enabled via Subscription
 func isNamespaceManagementEnabled() bool {
-	return os.Getenv(common.EnableManagedNamespace) == "true"
+	return argoutil.GetOperatorSetting(common.EnableManagedNamespace) == "true"
 }
 
 // If the EnableManagedNamespace feature is disabled, clean up the RBACs associated with the managed namespaces
@@ -0,0 +1,274 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// oidcConfig is the oidc.config property of argocd-cm rendered from .spec.oidc.
+type oidcConfig struct {
+	Name                     string                `yaml:"name"`
+	Issuer                   string                `yaml:"issuer"`
+	ClientID                 string                `yaml:"clientID"`
+	ClientSecret             string                `yaml:"clientSecret,omitempty"`
+	CLIClientID              string                `yaml:"cliClientID,omitempty"`
+	RequestedScopes          []string              `yaml:"requestedScopes,omitempty"`
+	RequestedIDTokenClaims   map[string]*oidcClaim `yaml:"requestedIDTokenClaims,omitempty"`
+	LogoutURL                string                `yaml:"logoutURL,omitempty"`
+	RootCA                   string                `yaml:"rootCA,omitempty"`
+	EnablePKCEAuthentication bool                  `yaml:"enablePKCEAuthentication,omitempty"`
+}
+
+type oidcClaim struct {
+	Essential bool     `yaml:"essential,omitempty"`
+	Value     string   `yaml:"value,omitempty"`
+	Values    []string `yaml:"values,omitempty"`
+}
+
+// getOIDCConfig will return the OIDC configuration for the given ArgoCD. When .spec.oidc is set, the configuration
+// is validated, together with the objects it references, and an error is returned if it is invalid.
+func (r *ReconcileArgoCD) getOIDCConfig(cr *argoproj.ArgoCD) (string, error) {
+	if cr.Spec.OIDC == nil {
+		config := common.ArgoCDDefaultOIDCConfig
+		if len(cr.Spec.OIDCConfig) > 0 {
+			config = cr.Spec.OIDCConfig
+		}
+		return config, nil
+	}
+
+	if err := validateOIDCSpec(cr.Spec.OIDC); err != nil {
+		return "", err
+	}
+	if _, _, err := r.getOIDCSecretValues(cr); err != nil {
+		return "", err
+	}
+	return renderOIDCConfig(cr.Spec.OIDC)
+}
+
+// validateOIDCSpec checks the URLs of the typed OIDC configuration.
+func validateOIDCSpec(spec *argoproj.ArgoCDOIDCSpec) error {
+	issuer, err := url.Parse(spec.Issuer)
+	if err != nil {
+		return fmt.Errorf("invalid issuer: %w", err)
+	}
+	if issuer.Scheme != "https" || issuer.Host == "" {
+		return fmt.Errorf("invalid issuer %q: must be an https URL", spec.Issuer)
+	}
+	if spec.LogoutURL != "" {
+		logoutURL, err := url.Parse(spec.LogoutURL)
+		if err != nil {
+			return fmt.Errorf("invalid logoutURL: %w", err)
+		}
+		if !logoutURL.IsAbs() {
+			return fmt.Errorf("invalid logoutURL %q: must be an absolute URL", spec.LogoutURL)
+		}
+	}
+	if spec.ClientSecretRef == nil && !spec.EnablePKCEAuthentication {
+		return errors.New("clientSecretRef must be set unless enablePKCEAuthentication is true")
+	}
+	return nil
+}
+
+// renderOIDCConfig renders the typed OIDC configuration into the oidc.config property of argocd-cm.
+// The client secret and the root CA reference their keys in argocd-secret.
+func renderOIDCConfig(spec *argoproj.ArgoCDOIDCSpec) (string, error) {
+	config := oidcConfig{
+		Name:                     spec.Name,
+		Issuer:                   spec.Issuer,
+		ClientID:                 spec.ClientID,
+		CLIClientID:              spec.CLIClientID,
+		RequestedScopes:          spec.RequestedScopes,
+		LogoutURL:                spec.LogoutURL,
+		EnablePKCEAuthentication: spec.EnablePKCEAuthentication,
+	}
+	if spec.ClientSecretRef != nil {
+		config.ClientSecret = "$" + common.ArgoCDKeyOIDCClientSecret
+	}
+	if spec.RootCA != nil {
+		config.RootCA = "$" + common.ArgoCDKeyOIDCRootCA
+	}
+	if len(spec.RequestedIDTokenClaims) > 0 {
+		config.RequestedIDTokenClaims = map[string]*oidcClaim{}
+		for name, claim := range spec.RequestedIDTokenClaims {
+			config.RequestedIDTokenClaims[name] = &oidcClaim{
+				Essential: claim.Essential,
+				Value:     claim.Value,
+				Values:    claim.Values,
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(config)
+	return string(out), err
+}
+
+// getOIDCSecretValues resolves the client secret and the root CA referenced by the typed OIDC configuration.
+// An error is returned if a referenced object or key does not exist, or if the root CA is not a PEM encoded certificate.
+func (r *ReconcileArgoCD) getOIDCSecretValues(cr *argoproj.ArgoCD) (clientSecret []byte, rootCA []byte, err error) {
+	spec := cr.Spec.OIDC
+
+	if ref := spec.ClientSecretRef; ref != nil {
+		clientSecret, err = r.getSecretKey(cr.Namespace, ref.Name, ref.Key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("clientSecretRef: %w", err)
+		}
+	}
+
+	if spec.RootCA != nil {
+		switch {
+		case spec.RootCA.SecretKeyRef != nil:
+			ref := spec.RootCA.SecretKeyRef
+			rootCA, err = r.getSecretKey(cr.Namespace, ref.Name, ref.Key)
+		case spec.RootCA.ConfigMapKeyRef != nil:
+			ref := spec.RootCA.ConfigMapKeyRef
+			rootCA, err = r.getConfigMapKey(cr.Namespace, ref.Name, ref.Key)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("rootCA: %w", err)
+		}
+		if err := validatePEMCertificates(rootCA); err != nil {
+			return nil, nil, fmt.Errorf("rootCA: %w", err)
+		}
+	}
+
+	return clientSecret, rootCA, nil
+}
+
+func (r *ReconcileArgoCD) getSecretKey(namespace, name, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("secret %q not found", name)
+		}
+		return nil, err
+	}
+	val, ok := secret.Data[key]
+	if !ok || len(val) == 0 {
+		return nil, fmt.Errorf("key %q missing or empty in Secret %q", key, name)
+	}
+	return val, nil
+}
+
+func (r *ReconcileArgoCD) getConfigMapKey(namespace, name, key string) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("configmap %q not found", name)
+		}
+		return nil, err
+	}
+	val, ok := cm.Data[key]
+	if !ok || val == "" {
+		return nil, fmt.Errorf("key %q missing or empty in ConfigMap %q", key, name)
+	}
+	return []byte(val), nil
+}
+
+// validatePEMCertificates returns an error unless data holds at least one PEM encoded certificate.
+func validatePEMCertificates(data []byte) error {
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("invalid certificate: %w", err)
+		}
+		found = true
+	}
+	if !found {
+		return errors.New("no PEM encoded certificate found")
+	}
+	return nil
+}
+
+// applyOIDCSecrets copies the client secret and the root CA of the typed OIDC configuration into argocd-secret.
+// It appends the change reasons to *changes when the secret is modified.
+// When .spec.oidc is absent, the secret is left unchanged so manually populated oidc.* keys are not removed.
+// When the referenced objects cannot be resolved the current values are kept, the error is reported in the
+// OIDCConfigValid condition.
+func (r *ReconcileArgoCD) applyOIDCSecrets(cr *argoproj.ArgoCD, argocdSecret *corev1.Secret, changes *[]string) {
+	if cr.Spec.OIDC == nil {
+		return
+	}
+	clientSecret, rootCA, err := r.getOIDCSecretValues(cr)
+	if err != nil {
+		log.Info(fmt.Sprintf("warning: unable to resolve the OIDC configuration of %s/%s, skipping sync: %v", cr.Namespace, cr.Name, err))
+		return
+	}
+
+	if argocdSecret.Data == nil {
+		argocdSecret.Data = make(map[string][]byte)
+	}
+	for key, val := range map[string][]byte{
+		common.ArgoCDKeyOIDCClientSecret: clientSecret,
+		common.ArgoCDKeyOIDCRootCA:       rootCA,
+	} {
+		if val == nil {
+			if _, ok := argocdSecret.Data[key]; ok {
+				delete(argocdSecret.Data, key)
+				*changes = append(*changes, key)
+			}
+			continue
+		}
+		if !bytes.Equal(argocdSecret.Data[key], val) {
+			argocdSecret.Data[key] = val
+			*changes = append(*changes, key)
+		}
+	}
+}
+
+// reconcileStatusOIDC will ensure that the OIDCConfigValid condition reflects the result of validating
+// the OIDC configuration declared on the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusOIDC(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	if cr.Spec.OIDC == nil {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionOIDCConfigValid)
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:   argoproj.ArgoCDConditionOIDCConfigValid,
+		Status: metav1.ConditionTrue,
+		Reason: argoproj.ArgoCDConditionReasonSuccess,
+	}
+	if _, err := r.getOIDCConfig(cr); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonInvalidOIDCConfig
+		condition.Message = err.Error()
+	}
+	argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+	return nil
+}
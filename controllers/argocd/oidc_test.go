@@ -0,0 +1,198 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func makeTestOIDCRootCA(t *testing.T) []byte {
+	t.Helper()
+	key, err := argoutil.NewPrivateKey()
+	require.NoError(t, err)
+	cert, err := argoutil.NewSelfSignedCACertificate("oidc-ca", key)
+	require.NoError(t, err)
+	return argoutil.EncodeCertificatePEM(cert)
+}
+
+func makeTestArgoCDWithOIDC() *argoproj.ArgoCD {
+	clientSecretRef := secretKeySelector("oidc", "clientSecret")
+	return makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.OIDC = &argoproj.ArgoCDOIDCSpec{
+			Name:            "Okta",
+			Issuer:          "https://example.okta.com",
+			ClientID:        "argocd",
+			ClientSecretRef: &clientSecretRef,
+			RequestedScopes: []string{"openid", "profile", "email", "groups"},
+			RequestedIDTokenClaims: map[string]argoproj.ArgoCDOIDCClaim{
+				"groups": {Essential: true},
+			},
+			RootCA: &argoproj.ArgoCDOIDCRootCASource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "oidc-ca"},
+					Key:                  "ca.crt",
+				},
+			},
+		}
+	})
+}
+
+func TestReconcileArgoCD_getOIDCConfig(t *testing.T) {
+	cr := makeTestArgoCDWithOIDC()
+	oidcSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: testNamespace},
+		Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+	}
+	caConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc-ca", Namespace: testNamespace},
+		Data:       map[string]string{"ca.crt": string(makeTestOIDCRootCA(t))},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, oidcSecret, caConfigMap}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	config, err := r.getOIDCConfig(cr)
+	require.NoError(t, err)
+
+	oidc := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(config), oidc))
+	assert.Equal(t, "Okta", oidc["name"])
+	assert.Equal(t, "https://example.okta.com", oidc["issuer"])
+	assert.Equal(t, "argocd", oidc["clientID"])
+	assert.Equal(t, "$oidc.clientSecret", oidc["clientSecret"])
+	assert.Equal(t, "$oidc.rootCA", oidc["rootCA"])
+	assert.Equal(t, []interface{}{"openid", "profile", "email", "groups"}, oidc["requestedScopes"])
+	assert.Equal(t, map[interface{}]interface{}{"groups": map[interface{}]interface{}{"essential": true}}, oidc["requestedIDTokenClaims"])
+
+	// The raw configuration is used when no typed configuration is set.
+	cr.Spec.OIDC = nil
+	cr.Spec.OIDCConfig = "name: raw"
+	config, err = r.getOIDCConfig(cr)
+	require.NoError(t, err)
+	assert.Equal(t, "name: raw", config)
+}
+
+func TestReconcileArgoCD_getOIDCConfig_invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(*argoproj.ArgoCD)
+		objects  []client.Object
+		expected string
+	}{
+		{
+			name:     "missing client secret",
+			objects:  []client.Object{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "oidc-ca", Namespace: testNamespace}}},
+			expected: `clientSecretRef: secret "oidc" not found`,
+		},
+		{
+			name: "root CA is not a certificate",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: testNamespace},
+					Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "oidc-ca", Namespace: testNamespace},
+					Data:       map[string]string{"ca.crt": "not a certificate"},
+				},
+			},
+			expected: "rootCA: no PEM encoded certificate found",
+		},
+		{
+			name: "insecure issuer",
+			mutate: func(cr *argoproj.ArgoCD) {
+				cr.Spec.OIDC.Issuer = "http://example.okta.com"
+			},
+			expected: `invalid issuer "http://example.okta.com": must be an https URL`,
+		},
+		{
+			name: "client secret required without PKCE",
+			mutate: func(cr *argoproj.ArgoCD) {
+				cr.Spec.OIDC.ClientSecretRef = nil
+			},
+			expected: "clientSecretRef must be set unless enablePKCEAuthentication is true",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCDWithOIDC()
+			if test.mutate != nil {
+				test.mutate(cr)
+			}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, append([]client.Object{cr}, test.objects...), []client.Object{}, []runtime.Object{})
+			r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+			_, err := r.getOIDCConfig(cr)
+			assert.EqualError(t, err, test.expected)
+
+			status := &argoproj.ArgoCDStatus{}
+			require.NoError(t, r.reconcileStatusOIDC(cr, status))
+			require.Len(t, status.Conditions, 1)
+			assert.Equal(t, argoproj.ArgoCDConditionOIDCConfigValid, status.Conditions[0].Type)
+			assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+			assert.Equal(t, test.expected, status.Conditions[0].Message)
+		})
+	}
+}
+
+func TestReconcileArgoCD_applyOIDCSecrets(t *testing.T) {
+	rootCA := makeTestOIDCRootCA(t)
+	cr := makeTestArgoCDWithOIDC()
+	cr.Spec.OIDC.RootCA = &argoproj.ArgoCDOIDCRootCASource{SecretKeyRef: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "oidc"},
+		Key:                  "ca.crt",
+	}}
+	oidcSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: testNamespace},
+		Data: map[string][]byte{
+			"clientSecret": []byte("s3cr3t"),
+			"ca.crt":       rootCA,
+		},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, oidcSecret}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	argocdSecret := &corev1.Secret{Data: map[string][]byte{"server.secretkey": []byte("key")}}
+	var changes []string
+	r.applyOIDCSecrets(cr, argocdSecret, &changes)
+	assert.Equal(t, map[string][]byte{
+		"oidc.clientSecret": []byte("s3cr3t"),
+		"oidc.rootCA":       rootCA,
+		"server.secretkey":  []byte("key"),
+	}, argocdSecret.Data)
+	assert.ElementsMatch(t, []string{"oidc.clientSecret", "oidc.rootCA"}, changes)
+
+	// Rotating the referenced Secret updates argocd-secret.
+	oidcSecret.Data["clientSecret"] = []byte("rotated")
+	require.NoError(t, r.Update(context.TODO(), oidcSecret))
+	changes = nil
+	r.applyOIDCSecrets(cr, argocdSecret, &changes)
+	assert.Equal(t, []byte("rotated"), argocdSecret.Data["oidc.clientSecret"])
+	assert.Equal(t, []string{"oidc.clientSecret"}, changes)
+
+	// Removing the root CA removes the key from argocd-secret.
+	cr.Spec.OIDC.RootCA = nil
+	changes = nil
+	r.applyOIDCSecrets(cr, argocdSecret, &changes)
+	assert.NotContains(t, argocdSecret.Data, "oidc.rootCA")
+	assert.Equal(t, []string{"oidc.rootCA"}, changes)
+
+	// The referenced Secret is watched.
+	key, ok := r.isUserManagedSecret(context.TODO(), oidcSecret)
+	assert.True(t, ok)
+	assert.Equal(t, cr.Name, key.Name)
+}
@@ -0,0 +1,202 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// sizingProfile holds the defaults applied by a sizing profile to the fields that are not set in the ArgoCD.
+type sizingProfile struct {
+	controllerOperationProcessors int32
+	controllerStatusProcessors    int32
+	controllerParallelismLimit    int32
+	controllerShards              int32
+	repoReplicas                  int32
+	repoExecTimeout               int
+	ha                            bool
+
+	controllerResources     corev1.ResourceRequirements
+	serverResources         corev1.ResourceRequirements
+	repoResources           corev1.ResourceRequirements
+	redisResources          corev1.ResourceRequirements
+	applicationSetResources corev1.ResourceRequirements
+}
+
+// profileResources returns the ResourceRequirements with the given requests and limits.
+func profileResources(requestCPU, requestMemory, limitCPU, limitMemory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(requestCPU),
+			corev1.ResourceMemory: resource.MustParse(requestMemory),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(limitCPU),
+			corev1.ResourceMemory: resource.MustParse(limitMemory),
+		},
+	}
+}
+
+// sizingProfiles are the operator-shipped sizing profiles.
+var sizingProfiles = map[string]sizingProfile{
+	common.ArgoCDProfileSmall: {
+		controllerOperationProcessors: 10,
+		controllerStatusProcessors:    20,
+		controllerParallelismLimit:    10,
+		repoReplicas:                  1,
+		repoExecTimeout:               90,
+		controllerResources:           profileResources("250m", "512Mi", "1", "2Gi"),
+		serverResources:               profileResources("100m", "128Mi", "500m", "256Mi"),
+		repoResources:                 profileResources("100m", "256Mi", "1", "1Gi"),
+		redisResources:                profileResources("100m", "128Mi", "500m", "256Mi"),
+		applicationSetResources:       profileResources("100m", "128Mi", "500m", "512Mi"),
+	},
+	common.ArgoCDProfileMedium: {
+		controllerOperationProcessors: 25,
+		controllerStatusProcessors:    50,
+		controllerParallelismLimit:    20,
+		repoReplicas:                  2,
+		repoExecTimeout:               180,
+		ha:                            true,
+		controllerResources:           profileResources("1", "2Gi", "2", "4Gi"),
+		serverResources:               profileResources("250m", "256Mi", "1", "512Mi"),
+		repoResources:                 profileResources("500m", "512Mi", "2", "2Gi"),
+		redisResources:                profileResources("250m", "256Mi", "1", "1Gi"),
+		applicationSetResources:       profileResources("250m", "256Mi", "1", "1Gi"),
+	},
+	common.ArgoCDProfileLarge: {
+		controllerOperationProcessors: 50,
+		controllerStatusProcessors:    100,
+		controllerParallelismLimit:    50,
+		controllerShards:              3,
+		repoReplicas:                  3,
+		repoExecTimeout:               300,
+		ha:                            true,
+		controllerResources:           profileResources("2", "4Gi", "4", "8Gi"),
+		serverResources:               profileResources("500m", "512Mi", "2", "1Gi"),
+		repoResources:                 profileResources("1", "1Gi", "4", "4Gi"),
+		redisResources:                profileResources("500m", "512Mi", "2", "2Gi"),
+		applicationSetResources:       profileResources("500m", "512Mi", "2", "2Gi"),
+	},
+}
+
+// applySizingProfile will set the defaults of the sizing profile of the given ArgoCD on the fields that are not set
+// explicitly. Only the in-memory ArgoCD is modified, the profile defaults are never persisted to the cluster.
+func applySizingProfile(cr *argoproj.ArgoCD) {
+	profile, ok := sizingProfiles[cr.Spec.Profile]
+	if !ok {
+		return
+	}
+	// The features the profile enables can only be turned off by its overrides.
+	overrides := cr.Spec.ProfileOverrides
+	if overrides == nil {
+		overrides = &argoproj.ArgoCDProfileOverridesSpec{}
+	}
+
+	controller := &cr.Spec.Controller
+	if controller.Processors.Operation == 0 {
+		controller.Processors.Operation = profile.controllerOperationProcessors
+	}
+	if controller.Processors.Status == 0 {
+		controller.Processors.Status = profile.controllerStatusProcessors
+	}
+	if controller.ParallelismLimit == 0 {
+		controller.ParallelismLimit = profile.controllerParallelismLimit
+	}
+	if controller.Resources == nil {
+		controller.Resources = profile.controllerResources.DeepCopy()
+	}
+
+	// Sharding is only enabled by the profile when none of the sharding options are set.
+	sharding := &controller.Sharding
+	//lint:ignore SA1019 known to be deprecated
+	if profile.controllerShards > 0 && ptr.Deref(overrides.ControllerSharding, true) && !sharding.Enabled && sharding.Replicas == 0 && sharding.DynamicScalingEnabled == nil { //nolint:staticcheck // SA1019: honor deprecated field for backward compatibility
+		sharding.Enabled = true
+		sharding.Replicas = profile.controllerShards
+	}
+
+	if cr.Spec.Server.Resources == nil {
+		cr.Spec.Server.Resources = profile.serverResources.DeepCopy()
+	}
+
+	if cr.Spec.Repo.Replicas == nil {
+		replicas := profile.repoReplicas
+		cr.Spec.Repo.Replicas = &replicas
+	}
+	if cr.Spec.Repo.ExecTimeout == nil {
+		execTimeout := profile.repoExecTimeout
+		cr.Spec.Repo.ExecTimeout = &execTimeout
+	}
+	if cr.Spec.Repo.Resources == nil {
+		cr.Spec.Repo.Resources = profile.repoResources.DeepCopy()
+	}
+
+	if cr.Spec.Redis.Resources == nil {
+		cr.Spec.Redis.Resources = profile.redisResources.DeepCopy()
+	}
+	if profile.ha && ptr.Deref(overrides.HA, true) {
+		cr.Spec.HA.Enabled = true
+	}
+
+	if cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.Resources == nil {
+		cr.Spec.ApplicationSet.Resources = profile.applicationSetResources.DeepCopy()
+	}
+}
+
+// reconcileStatusProfile will ensure that the values resolved from the sizing profile are reported in the ArgoCD status.
+func (r *ReconcileArgoCD) reconcileStatusProfile(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	if cr.Spec.Profile == "" {
+		argocdStatus.Profile = nil
+		return nil
+	}
+
+	// The defaults are already applied when the resources were reconciled, applying them again is a no-op.
+	resolved := cr.DeepCopy()
+	applySizingProfile(resolved)
+
+	status := &argoproj.ArgoCDProfileStatus{
+		Name:                          resolved.Spec.Profile,
+		ControllerOperationProcessors: getArgoServerOperationProcessors(resolved),
+		ControllerStatusProcessors:    getArgoServerStatusProcessors(resolved),
+		ControllerParallelismLimit:    getArgoControllerParallelismLimit(resolved),
+		RepoReplicas:                  getArgoCDRepoServerReplicas(resolved),
+		RepoExecTimeout:               resolved.Spec.Repo.ExecTimeout,
+		HA:                            resolved.Spec.HA.Enabled,
+		Resources: []argoproj.ArgoCDComponentResources{
+			{Component: "application-controller", Resources: getArgoApplicationControllerResources(resolved)},
+			{Component: "server", Resources: getArgoServerResources(resolved)},
+			{Component: "repo-server", Resources: getArgoRepoResources(resolved)},
+			{Component: "redis", Resources: argoutil.GetRedisResources(resolved)},
+		},
+	}
+	if resolved.Spec.Controller.Sharding.Enabled {
+		status.ControllerShards = resolved.Spec.Controller.Sharding.Replicas
+	}
+	if resolved.Spec.ApplicationSet != nil {
+		status.Resources = append(status.Resources, argoproj.ArgoCDComponentResources{
+			Component: "applicationset-controller",
+			Resources: getApplicationSetResources(resolved),
+		})
+	}
+
+	argocdStatus.Profile = status
+	return nil
+}
@@ -0,0 +1,114 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestApplySizingProfile(t *testing.T) {
+	t.Run("large profile applies defaults", func(t *testing.T) {
+		a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.Profile = common.ArgoCDProfileLarge
+		})
+		applySizingProfile(a)
+
+		assert.Equal(t, int32(50), a.Spec.Controller.Processors.Operation)
+		assert.Equal(t, int32(100), a.Spec.Controller.Processors.Status)
+		assert.Equal(t, int32(50), a.Spec.Controller.ParallelismLimit)
+		assert.True(t, a.Spec.Controller.Sharding.Enabled)
+		assert.Equal(t, int32(3), a.Spec.Controller.Sharding.Replicas)
+		assert.Equal(t, int32(3), *a.Spec.Repo.Replicas)
+		assert.Equal(t, 300, *a.Spec.Repo.ExecTimeout)
+		assert.True(t, a.Spec.HA.Enabled)
+		require.NotNil(t, a.Spec.Controller.Resources)
+		assert.True(t, resource.MustParse("8Gi").Equal(a.Spec.Controller.Resources.Limits[corev1.ResourceMemory]))
+	})
+
+	t.Run("explicit fields override the profile", func(t *testing.T) {
+		replicas := int32(5)
+		resources := &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")}}
+		a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.Profile = common.ArgoCDProfileMedium
+			a.Spec.Controller.Processors.Status = 80
+			a.Spec.Controller.Resources = resources
+			a.Spec.Repo.Replicas = &replicas
+		})
+		applySizingProfile(a)
+
+		assert.Equal(t, int32(25), a.Spec.Controller.Processors.Operation)
+		assert.Equal(t, int32(80), a.Spec.Controller.Processors.Status)
+		assert.Equal(t, resources, a.Spec.Controller.Resources)
+		assert.Equal(t, int32(5), *a.Spec.Repo.Replicas)
+		assert.False(t, a.Spec.Controller.Sharding.Enabled)
+	})
+
+	t.Run("overrides turn off the features enabled by the profile", func(t *testing.T) {
+		a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.Profile = common.ArgoCDProfileLarge
+			a.Spec.ProfileOverrides = &argoproj.ArgoCDProfileOverridesSpec{HA: ptr.To(false), ControllerSharding: ptr.To(false)}
+		})
+		applySizingProfile(a)
+
+		assert.False(t, a.Spec.HA.Enabled)
+		assert.False(t, a.Spec.Controller.Sharding.Enabled)
+		assert.Equal(t, int32(50), a.Spec.Controller.ParallelismLimit)
+	})
+
+	t.Run("false booleans do not override the profile", func(t *testing.T) {
+		a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.Profile = common.ArgoCDProfileLarge
+			a.Spec.ProfileOverrides = &argoproj.ArgoCDProfileOverridesSpec{HA: ptr.To(true)}
+		})
+		applySizingProfile(a)
+
+		assert.True(t, a.Spec.HA.Enabled)
+		assert.True(t, a.Spec.Controller.Sharding.Enabled)
+		assert.Equal(t, int32(3), a.Spec.Controller.Sharding.Replicas)
+	})
+
+	t.Run("custom profile applies no defaults", func(t *testing.T) {
+		a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.Profile = common.ArgoCDProfileCustom
+		})
+		expected := a.DeepCopy()
+		applySizingProfile(a)
+		assert.Equal(t, expected, a)
+	})
+}
+
+func TestReconcileArgoCD_reconcileStatusProfile(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Profile = common.ArgoCDProfileSmall
+		a.Spec.Controller.ParallelismLimit = 15
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusProfile(a, status))
+	require.NotNil(t, status.Profile)
+	assert.Equal(t, common.ArgoCDProfileSmall, status.Profile.Name)
+	assert.Equal(t, int32(10), status.Profile.ControllerOperationProcessors)
+	assert.Equal(t, int32(15), status.Profile.ControllerParallelismLimit)
+	assert.Equal(t, int32(1), *status.Profile.RepoReplicas)
+	assert.Equal(t, 90, *status.Profile.RepoExecTimeout)
+	assert.False(t, status.Profile.HA)
+	assert.Len(t, status.Profile.Resources, 4)
+
+	// The profile is not reported when not set.
+	a.Spec.Profile = ""
+	require.NoError(t, r.reconcileStatusProfile(a, status))
+	assert.Nil(t, status.Profile)
+}
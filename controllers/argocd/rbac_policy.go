@@ -0,0 +1,195 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// rbacPolicyOverlayKey returns the argocd-rbac-cm key of the given policy overlay.
+func rbacPolicyOverlayKey(name string) string {
+	return fmt.Sprintf("policy.%s.csv", name)
+}
+
+// isRBACPolicyDeclared returns true if the ArgoCD CR declares any policy that the operator writes to argocd-rbac-cm.
+func isRBACPolicyDeclared(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.RBAC.Policy != nil || len(cr.Spec.RBAC.Roles) > 0 || len(cr.Spec.RBAC.PolicyOverlays) > 0
+}
+
+// renderRBACRoles renders structured roles into Argo CD policy CSV lines.
+func renderRBACRoles(roles []argoproj.ArgoCDRBACRole) string {
+	var lines []string
+	for _, role := range roles {
+		subject := "role:" + role.Name
+		for _, p := range role.Permissions {
+			object := p.Object
+			if object == "" {
+				object = "*"
+			}
+			effect := p.Effect
+			if effect == "" {
+				effect = "allow"
+			}
+			lines = append(lines, fmt.Sprintf("p, %s, %s, %s, %s, %s", subject, p.Resource, p.Action, object, effect))
+		}
+		for _, group := range role.Groups {
+			lines = append(lines, fmt.Sprintf("g, %s, %s", group, subject))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// getRBACPolicies returns the policy keys of argocd-rbac-cm declared on the ArgoCD CR: policy.csv, built from
+// spec.rbac.policy and spec.rbac.roles, and one policy.<name>.csv key per overlay. Every policy is validated
+// against the Casbin model used by Argo CD; an error is returned if any of them is invalid or cannot be read.
+func (r *ReconcileArgoCD) getRBACPolicies(cr *argoproj.ArgoCD) (map[string]string, error) {
+	policies := map[string]string{}
+
+	if cr.Spec.RBAC.Policy != nil || len(cr.Spec.RBAC.Roles) > 0 {
+		policies[common.ArgoCDKeyRBACPolicyCSV] = getRBACPolicy(cr)
+	}
+
+	for _, overlay := range cr.Spec.RBAC.PolicyOverlays {
+		key := overlay.ConfigMapRef.Key
+		if key == "" {
+			key = common.ArgoCDKeyRBACPolicyCSV
+		}
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: overlay.ConfigMapRef.Name, Namespace: cr.Namespace}, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("policy overlay %q: ConfigMap %q not found", overlay.Name, overlay.ConfigMapRef.Name)
+			}
+			return nil, err
+		}
+		policy, ok := cm.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("policy overlay %q: key %q not found in ConfigMap %q", overlay.Name, key, overlay.ConfigMapRef.Name)
+		}
+		policies[rbacPolicyOverlayKey(overlay.Name)] = policy
+	}
+
+	keys := make([]string, 0, len(policies))
+	for k := range policies {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := validateRBACPolicy(policies[k]); err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	return policies, nil
+}
+
+// validateRBACPolicy validates each line of the policy CSV with the Casbin model used by Argo CD,
+// so that the error identifies the offending line.
+func validateRBACPolicy(policy string) error {
+	for _, line := range strings.Split(policy, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := rbac.ValidatePolicy(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRBACPolicies writes the declared policies to the data of argocd-rbac-cm and removes the overlay keys
+// that are no longer declared. The overlay keys written by the operator are tracked in an annotation so that
+// overlay keys added by other means are left alone. Returns the list of changes.
+func applyRBACPolicies(cm *corev1.ConfigMap, policies map[string]string) []string {
+	var changes []string
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+
+	for k, v := range policies {
+		if cur, ok := cm.Data[k]; !ok || cur != v {
+			cm.Data[k] = v
+			changes = append(changes, k)
+		}
+	}
+
+	var overlayKeys []string
+	for k := range policies {
+		if k != common.ArgoCDKeyRBACPolicyCSV {
+			overlayKeys = append(overlayKeys, k)
+		}
+	}
+	sort.Strings(overlayKeys)
+
+	if previous, ok := cm.Annotations[common.AnnotationRBACPolicyOverlays]; ok && previous != "" {
+		for _, k := range strings.Split(previous, ",") {
+			if _, declared := policies[k]; !declared {
+				if _, exists := cm.Data[k]; exists {
+					delete(cm.Data, k)
+					changes = append(changes, k)
+				}
+			}
+		}
+	}
+
+	annotation := strings.Join(overlayKeys, ",")
+	if cm.Annotations[common.AnnotationRBACPolicyOverlays] != annotation {
+		if annotation == "" {
+			delete(cm.Annotations, common.AnnotationRBACPolicyOverlays)
+		} else {
+			if cm.Annotations == nil {
+				cm.Annotations = map[string]string{}
+			}
+			cm.Annotations[common.AnnotationRBACPolicyOverlays] = annotation
+		}
+		changes = append(changes, "rbac policy overlays annotation")
+	}
+
+	sort.Strings(changes)
+	return changes
+}
+
+// reconcileStatusRBACPolicy will ensure that the RBACPolicyValid condition reflects the result of validating
+// the RBAC policy declared on the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusRBACPolicy(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	if !isRBACPolicyDeclared(cr) {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionRBACPolicyValid)
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:   argoproj.ArgoCDConditionRBACPolicyValid,
+		Status: metav1.ConditionTrue,
+		Reason: argoproj.ArgoCDConditionReasonSuccess,
+	}
+	if _, err := r.getRBACPolicies(cr); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonInvalidRBACPolicy
+		condition.Message = err.Error()
+	}
+	argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+	return nil
+}
@@ -0,0 +1,139 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func getTestRBACConfigMap(t *testing.T, r *ReconcileArgoCD) *corev1.ConfigMap {
+	t.Helper()
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: testNamespace}, cm))
+	return cm
+}
+
+func TestRenderRBACRoles(t *testing.T) {
+	roles := []argoproj.ArgoCDRBACRole{
+		{
+			Name: "deployer",
+			Permissions: []argoproj.ArgoCDRBACPermission{
+				{Resource: "applications", Action: "sync", Object: "team-a/*"},
+				{Resource: "applications", Action: "delete", Effect: "deny"},
+			},
+			Groups: []string{"team-a", "ops"},
+		},
+	}
+
+	assert.Equal(t, "p, role:deployer, applications, sync, team-a/*, allow\n"+
+		"p, role:deployer, applications, delete, *, deny\n"+
+		"g, team-a, role:deployer\n"+
+		"g, ops, role:deployer", renderRBACRoles(roles))
+}
+
+func TestReconcileArgoCD_reconcileRBAC_structuredRolesAndOverlays(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Policy = ptr.To("g, admins, role:admin")
+		a.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{
+			{
+				Name:        "viewer",
+				Permissions: []argoproj.ArgoCDRBACPermission{{Resource: "applications", Action: "get"}},
+				Groups:      []string{"everyone"},
+			},
+		}
+		a.Spec.RBAC.PolicyOverlays = []argoproj.ArgoCDRBACPolicyOverlay{
+			{Name: "team-a", ConfigMapRef: argoproj.ArgoCDRBACPolicyConfigMapRef{Name: "team-a-rbac"}},
+		}
+	})
+	teamA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a-rbac", Namespace: testNamespace},
+		Data:       map[string]string{"policy.csv": "p, role:team-a, applications, *, team-a/*, allow"},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, teamA}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileRBAC(cr))
+
+	cm := getTestRBACConfigMap(t, r)
+	assert.Equal(t, "g, admins, role:admin\np, role:viewer, applications, get, *, allow\ng, everyone, role:viewer", cm.Data["policy.csv"])
+	assert.Equal(t, "p, role:team-a, applications, *, team-a/*, allow", cm.Data["policy.team-a.csv"])
+
+	// Keys added by other means are kept when an overlay is removed.
+	cm.Data["policy.manual.csv"] = "g, manual, role:readonly"
+	require.NoError(t, r.Update(context.TODO(), cm))
+	cr.Spec.RBAC.PolicyOverlays = nil
+	require.NoError(t, r.reconcileRBAC(cr))
+
+	cm = getTestRBACConfigMap(t, r)
+	assert.NotContains(t, cm.Data, "policy.team-a.csv")
+	assert.NotContains(t, cm.Annotations, common.AnnotationRBACPolicyOverlays)
+	assert.Equal(t, "g, manual, role:readonly", cm.Data["policy.manual.csv"])
+}
+
+func TestReconcileArgoCD_reconcileRBAC_invalidPolicyIsNotWritten(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Policy = ptr.To("g, admins, role:admin")
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+	require.NoError(t, r.reconcileRBAC(cr))
+
+	cr.Spec.RBAC.Policy = ptr.To("g, admins, role:admin\np, role:typo, applications")
+	require.NoError(t, r.reconcileRBAC(cr))
+
+	cm := getTestRBACConfigMap(t, r)
+	assert.Equal(t, "g, admins, role:admin", cm.Data["policy.csv"])
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusRBACPolicy(cr, status))
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, argoproj.ArgoCDConditionRBACPolicyValid, status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonInvalidRBACPolicy, status.Conditions[0].Reason)
+	assert.Contains(t, status.Conditions[0].Message, "policy.csv")
+}
+
+func TestReconcileArgoCD_reconcileStatusRBACPolicy(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.PolicyOverlays = []argoproj.ArgoCDRBACPolicyOverlay{
+			{Name: "missing", ConfigMapRef: argoproj.ArgoCDRBACPolicyConfigMapRef{Name: "does-not-exist"}},
+		}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusRBACPolicy(cr, status))
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+	assert.Contains(t, status.Conditions[0].Message, `ConfigMap "does-not-exist" not found`)
+
+	cr.Spec.RBAC.PolicyOverlays = nil
+	cr.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{{Name: "viewer", Groups: []string{"everyone"}}}
+	status = &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusRBACPolicy(cr, status))
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+
+	cr.Spec.RBAC.Roles = nil
+	cr.Status.Conditions = []metav1.Condition{{Type: argoproj.ArgoCDConditionRBACPolicyValid}}
+	status = &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusRBACPolicy(cr, status))
+	assert.Empty(t, status.Conditions)
+	assert.Empty(t, cr.Status.Conditions)
+}
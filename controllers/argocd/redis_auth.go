@@ -0,0 +1,300 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// A rotation of the Redis password is rolled out in three steps to avoid authentication errors in the clients:
+//
+//  1. The password is regenerated and Redis is rolled out accepting both the previous and the new password.
+//  2. Once Redis has rolled out, the new password is released to the argocd components, which are rolled out.
+//  3. Once the argocd components have rolled out, Redis is rolled out again accepting only the new password.
+//
+// In HA mode, the Redis replicas and Sentinels authenticate with each other using the password, so the first step
+// is split in two: Redis is first rolled out accepting the new password while still using the previous one, and only
+// then rolled out using the new password. Each rollout is then a rolling update, as every pod accepts the password
+// used by the pods that are restarted.
+//
+// The state of a rotation is derived from the Redis password Secret: the pending password, the rotation time, the
+// rotation released to the clients and whether users.acl still accepts the previous password.
+
+// redisPendingPasswordKey is the key of the Redis password Secret holding the new password of a rotation in HA mode,
+// until Redis accepts it on all the pods.
+const redisPendingPasswordKey = "pending-auth"
+
+// getRedisPasswordRotationInterval returns the interval at which the Redis password is rotated, or zero when the
+// password is not rotated periodically.
+func getRedisPasswordRotationInterval(cr *argoproj.ArgoCD) time.Duration {
+	if cr.Spec.Redis.Auth == nil || cr.Spec.Redis.Auth.RotationInterval == nil {
+		return 0
+	}
+	return cr.Spec.Redis.Auth.RotationInterval.Duration
+}
+
+// isRedisPasswordRotationDue returns whether the Redis password of the given ArgoCD must be rotated now, either on
+// demand or because the rotation interval has elapsed. Otherwise, it returns the time left until the next rotation.
+func isRedisPasswordRotationDue(cr *argoproj.ArgoCD, secret *corev1.Secret, now time.Time) (bool, time.Duration) {
+	if v := cr.Annotations[common.AnnotationRotateRedisPassword]; v != "" && v != secret.Annotations[common.AnnotationRotateRedisPassword] {
+		return true, 0
+	}
+
+	interval := getRedisPasswordRotationInterval(cr)
+	if interval <= 0 {
+		return false, 0
+	}
+	last := secret.CreationTimestamp.Time
+	if rotatedAt, err := time.Parse(time.RFC3339Nano, secret.Annotations[common.AnnotationRedisPasswordRotatedAt]); err == nil {
+		last = rotatedAt
+	}
+	remaining := last.Add(interval).Sub(now)
+	if remaining <= 0 {
+		return true, 0
+	}
+	return false, remaining
+}
+
+// reconcileRedisPasswordRotation will rotate the password in the given Redis password Secret when due, and advance
+// a rotation in progress once the workloads have rolled out.
+func (r *ReconcileArgoCD) reconcileRedisPasswordRotation(cr *argoproj.ArgoCD, secret *corev1.Secret) error {
+	r.redisPasswordRequeueAfter.Delete(cr.Namespace)
+	if !cr.Spec.Redis.IsEnabled() || cr.Spec.Redis.IsRemote() {
+		return nil
+	}
+
+	if pending := secret.Data[redisPendingPasswordKey]; len(pending) > 0 {
+		rolledOut, err := r.isRedisRolledOut(cr, getRedisAuthChecksum(secret))
+		if err != nil || !rolledOut {
+			return err
+		}
+		setRedisPassword(secret, pending)
+		argoutil.LogResourceUpdate(log, secret, "switching Redis to the rotated password")
+		return r.Update(context.TODO(), secret)
+	}
+
+	rotatedAt := secret.Annotations[common.AnnotationRedisPasswordRotatedAt]
+	if rotatedAt != "" && secret.Annotations[common.AnnotationRedisAuthClientsRevision] != rotatedAt {
+		rolledOut, err := r.isRedisRolledOut(cr, getRedisAuthChecksum(secret))
+		if err != nil || !rolledOut {
+			return err
+		}
+		secret.Annotations[common.AnnotationRedisAuthClientsRevision] = rotatedAt
+		argoutil.LogResourceUpdate(log, secret, "releasing the rotated Redis password to the argocd components")
+		return r.Update(context.TODO(), secret)
+	}
+
+	usersACL := argoutil.GetRedisUsersACL(secret.Data["auth"])
+	if rotatedAt != "" && !bytes.Equal(secret.Data["users.acl"], usersACL) {
+		rolledOut, err := r.areRedisClientsRolledOut(cr, rotatedAt)
+		if err != nil || !rolledOut {
+			return err
+		}
+		secret.Data["users.acl"] = usersACL
+		argoutil.LogResourceUpdate(log, secret, "revoking the previous Redis password")
+		return r.Update(context.TODO(), secret)
+	}
+
+	due, requeueAfter := isRedisPasswordRotationDue(cr, secret, time.Now())
+	if !due {
+		if requeueAfter > 0 {
+			r.redisPasswordRequeueAfter.Store(cr.Namespace, requeueAfter)
+		}
+		return nil
+	}
+	return r.rotateRedisPassword(cr, secret)
+}
+
+// rotateRedisPassword will generate a new Redis password in the given Secret, which Redis accepts alongside the
+// previous one until all clients have been rolled out. In HA mode, the new password is kept pending until all the
+// Redis pods accept it.
+func (r *ReconcileArgoCD) rotateRedisPassword(cr *argoproj.ArgoCD, secret *corev1.Secret) error {
+	password, err := generateRedisAdminPassword()
+	if err != nil {
+		return err
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	if v := cr.Annotations[common.AnnotationRotateRedisPassword]; v != "" {
+		secret.Annotations[common.AnnotationRotateRedisPassword] = v
+	}
+	if cr.Spec.HA.Enabled {
+		secret.Data["users.acl"] = argoutil.GetRedisUsersACL(secret.Data["auth"], password)
+		secret.Data[redisPendingPasswordKey] = password
+		argoutil.LogResourceUpdate(log, secret, "adding the rotated password to the passwords accepted by Redis")
+		return r.Update(context.TODO(), secret)
+	}
+
+	setRedisPassword(secret, password)
+	argoutil.LogResourceUpdate(log, secret, "rotating the Redis password")
+	return r.Update(context.TODO(), secret)
+}
+
+// setRedisPassword sets the given password as the Redis password in the given Secret, which Redis accepts alongside
+// the previous one until all clients have been rolled out, and records the time of the rotation.
+func setRedisPassword(secret *corev1.Secret, password []byte) {
+	data := argoutil.GetRedisSecretData(password)
+	data["users.acl"] = argoutil.GetRedisUsersACL(secret.Data["auth"], password)
+	secret.Data = data
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[common.AnnotationRedisPasswordRotatedAt] = time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// getRedisAuthChecksum returns a checksum of the passwords accepted by Redis, or an empty string when the password
+// of the given Secret has never been rotated.
+func getRedisAuthChecksum(secret *corev1.Secret) string {
+	if secret.Annotations[common.AnnotationRedisPasswordRotatedAt] == "" && len(secret.Data[redisPendingPasswordKey]) == 0 {
+		return ""
+	}
+	checksum := newObjectChecksum()
+	checksum.sprintf("auth:%s", secret.Data["auth"])
+	checksum.sprintf("acl:%s", secret.Data["users.acl"])
+	return checksum.hexSum()
+}
+
+// setRedisAuthChecksum sets the checksum of the passwords accepted by Redis on the given Redis pod template, so that
+// Redis is rolled out when they change.
+func (r *ReconcileArgoCD) setRedisAuthChecksum(cr *argoproj.ArgoCD, template *corev1.PodTemplateSpec) error {
+	secret := argoutil.NewSecretWithSuffix(cr, "redis-initial-password")
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, secret.Name, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if checksum := getRedisAuthChecksum(secret); checksum != "" {
+		if template.Annotations == nil {
+			template.Annotations = make(map[string]string)
+		}
+		template.Annotations[common.ArgoCDRedisAuthChecksum] = checksum
+	}
+	return nil
+}
+
+// isRedisRolledOut returns whether all the Redis workloads of the given ArgoCD run with the given passwords checksum.
+func (r *ReconcileArgoCD) isRedisRolledOut(cr *argoproj.ArgoCD, checksum string) (bool, error) {
+	if !cr.Spec.HA.Enabled {
+		return r.isDeploymentRolledOut(newDeploymentWithSuffix("redis", "redis", cr), common.ArgoCDRedisAuthChecksum, checksum)
+	}
+
+	rolledOut, err := r.isDeploymentRolledOut(newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr), common.ArgoCDRedisAuthChecksum, checksum)
+	if err != nil || !rolledOut {
+		return false, err
+	}
+	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, ss.Name, ss); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return isStatefulSetRolledOut(ss, common.ArgoCDRedisAuthChecksum, checksum), nil
+}
+
+// areRedisClientsRolledOut returns whether all the workloads of the given ArgoCD mounting the Redis password, other
+// than Redis itself, run with the given rotation of the password.
+func (r *ReconcileArgoCD) areRedisClientsRolledOut(cr *argoproj.ArgoCD, revision string) (bool, error) {
+	redisWorkloads := map[string]bool{
+		nameWithSuffix("redis", cr):            true,
+		nameWithSuffix("redis-ha-haproxy", cr): true,
+		nameWithSuffix("redis-ha-server", cr):  true,
+	}
+	isClient := func(obj client.Object, template corev1.PodTemplateSpec) bool {
+		if redisWorkloads[obj.GetName()] || !metav1.IsControlledBy(obj, cr) {
+			return false
+		}
+		for _, v := range template.Spec.Volumes {
+			if v.Name == argoutil.RedisAuthVolumeName {
+				return true
+			}
+		}
+		return false
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(context.TODO(), deployments, client.InNamespace(cr.Namespace)); err != nil {
+		return false, err
+	}
+	for i := range deployments.Items {
+		deploy := &deployments.Items[i]
+		if isClient(deploy, deploy.Spec.Template) && !isDeploymentRolledOut(deploy, common.ArgoCDRedisAuthRevision, revision) {
+			return false, nil
+		}
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(context.TODO(), statefulSets, client.InNamespace(cr.Namespace)); err != nil {
+		return false, err
+	}
+	for i := range statefulSets.Items {
+		ss := &statefulSets.Items[i]
+		if isClient(ss, ss.Spec.Template) && !isStatefulSetRolledOut(ss, common.ArgoCDRedisAuthRevision, revision) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isDeploymentRolledOut fetches the given Deployment and returns whether all of its replicas are ready and run a
+// pod template with the given annotation value.
+func (r *ReconcileArgoCD) isDeploymentRolledOut(deploy *appsv1.Deployment, key, value string) (bool, error) {
+	if err := argoutil.FetchObject(r.Client, deploy.Namespace, deploy.Name, deploy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return isDeploymentRolledOut(deploy, key, value), nil
+}
+
+func isDeploymentRolledOut(deploy *appsv1.Deployment, key, value string) bool {
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	return deploy.Spec.Template.Annotations[key] == value &&
+		deploy.Status.ObservedGeneration >= deploy.Generation &&
+		deploy.Status.Replicas == replicas &&
+		deploy.Status.UpdatedReplicas == replicas &&
+		deploy.Status.ReadyReplicas == replicas
+}
+
+func isStatefulSetRolledOut(ss *appsv1.StatefulSet, key, value string) bool {
+	replicas := int32(1)
+	if ss.Spec.Replicas != nil {
+		replicas = *ss.Spec.Replicas
+	}
+	return ss.Spec.Template.Annotations[key] == value &&
+		ss.Status.ObservedGeneration >= ss.Generation &&
+		ss.Status.UpdateRevision == ss.Status.CurrentRevision &&
+		ss.Status.UpdatedReplicas == replicas &&
+		ss.Status.ReadyReplicas == replicas
+}
@@ -0,0 +1,218 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func TestReconcileArgoCD_reconcileRedisPasswordRotation(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Annotations = map[string]string{common.AnnotationRotateRedisPassword: "1"}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	secret := &corev1.Secret{}
+	fetchSecret := func() {
+		require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-initial-password", Namespace: a.Namespace}, secret))
+	}
+	markRolledOut := func(name string) *appsv1.Deployment {
+		deploy := &appsv1.Deployment{}
+		require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, deploy))
+		deploy.Status = appsv1.DeploymentStatus{ObservedGeneration: deploy.Generation, Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1}
+		require.NoError(t, r.Status().Update(context.TODO(), deploy))
+		return deploy
+	}
+
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	previous := secret.Data["auth"]
+	require.NoError(t, r.reconcileRedisDeployment(a, false))
+	require.NoError(t, r.reconcileServerDeployment(a, false))
+
+	// The password is rotated and Redis accepts both the previous and the new password.
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	password := secret.Data["auth"]
+	assert.NotEqual(t, previous, password)
+	assert.Equal(t, password, secret.Data[common.ArgoCDKeyAdminPassword])
+	assert.Equal(t, argoutil.GetRedisUsersACL(previous, password), secret.Data["users.acl"])
+	assert.Equal(t, "1", secret.Annotations[common.AnnotationRotateRedisPassword])
+	rotatedAt := secret.Annotations[common.AnnotationRedisPasswordRotatedAt]
+	assert.NotEmpty(t, rotatedAt)
+
+	// Redis is rolled out first, the clients are not released until it has rolled out.
+	require.NoError(t, r.reconcileRedisDeployment(a, false))
+	redis := markRolledOut("argocd-redis")
+	assert.Equal(t, getRedisAuthChecksum(secret), redis.Spec.Template.Annotations[common.ArgoCDRedisAuthChecksum])
+	require.NoError(t, r.reconcileServerDeployment(a, false))
+	server := markRolledOut("argocd-server")
+	assert.NotContains(t, server.Spec.Template.Annotations, common.ArgoCDRedisAuthRevision)
+
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	assert.Equal(t, rotatedAt, secret.Annotations[common.AnnotationRedisAuthClientsRevision])
+
+	// The previous password is revoked once the clients have rolled out.
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	assert.Equal(t, argoutil.GetRedisUsersACL(previous, password), secret.Data["users.acl"])
+
+	require.NoError(t, r.reconcileServerDeployment(a, false))
+	server = markRolledOut("argocd-server")
+	assert.Equal(t, rotatedAt, server.Spec.Template.Annotations[common.ArgoCDRedisAuthRevision])
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	assert.Equal(t, argoutil.GetRedisUsersACL(password), secret.Data["users.acl"])
+
+	require.NoError(t, r.reconcileRedisDeployment(a, false))
+	redis = markRolledOut("argocd-redis")
+	assert.Equal(t, getRedisAuthChecksum(secret), redis.Spec.Template.Annotations[common.ArgoCDRedisAuthChecksum])
+
+	// The same on-demand request is not handled twice.
+	resourceVersion := secret.ResourceVersion
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	assert.Equal(t, resourceVersion, secret.ResourceVersion)
+}
+
+func TestReconcileArgoCD_reconcileRedisPasswordRotation_HA(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Annotations = map[string]string{common.AnnotationRotateRedisPassword: "1"}
+		a.Spec.HA.Enabled = true
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	secret := &corev1.Secret{}
+	fetchSecret := func() {
+		require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-initial-password", Namespace: a.Namespace}, secret))
+	}
+	rollOutRedis := func() *appsv1.StatefulSet {
+		require.NoError(t, r.reconcileRedisStatefulSet(a))
+		require.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+		haproxy := &appsv1.Deployment{}
+		require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-haproxy", Namespace: a.Namespace}, haproxy))
+		haproxy.Status = appsv1.DeploymentStatus{ObservedGeneration: haproxy.Generation, Replicas: *haproxy.Spec.Replicas, UpdatedReplicas: *haproxy.Spec.Replicas, ReadyReplicas: *haproxy.Spec.Replicas}
+		require.NoError(t, r.Status().Update(context.TODO(), haproxy))
+		ss := &appsv1.StatefulSet{}
+		require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: a.Namespace}, ss))
+		ss.Status = appsv1.StatefulSetStatus{ObservedGeneration: ss.Generation, Replicas: *ss.Spec.Replicas, UpdatedReplicas: *ss.Spec.Replicas, ReadyReplicas: *ss.Spec.Replicas}
+		require.NoError(t, r.Status().Update(context.TODO(), ss))
+		return ss
+	}
+
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	previous := secret.Data["auth"]
+	ss := rollOutRedis()
+	uid := ss.UID
+
+	// Redis first accepts the new password while still using the previous one.
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	password := secret.Data[redisPendingPasswordKey]
+	require.NotEmpty(t, password)
+	assert.Equal(t, previous, secret.Data["auth"])
+	assert.Equal(t, argoutil.GetRedisUsersACL(previous, password), secret.Data["users.acl"])
+	assert.Empty(t, secret.Annotations[common.AnnotationRedisPasswordRotatedAt])
+
+	// The password is not switched until the Redis pods are rolled out, rather than recreated.
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	assert.Equal(t, previous, secret.Data["auth"])
+	ss = rollOutRedis()
+	assert.Equal(t, uid, ss.UID)
+	assert.Equal(t, getRedisAuthChecksum(secret), ss.Spec.Template.Annotations[common.ArgoCDRedisAuthChecksum])
+
+	// Redis then uses the new password, and still accepts the previous one for the clients.
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	assert.Equal(t, password, secret.Data["auth"])
+	assert.NotContains(t, secret.Data, redisPendingPasswordKey)
+	assert.Equal(t, argoutil.GetRedisUsersACL(previous, password), secret.Data["users.acl"])
+	rotatedAt := secret.Annotations[common.AnnotationRedisPasswordRotatedAt]
+	assert.NotEmpty(t, rotatedAt)
+
+	ss = rollOutRedis()
+	assert.Equal(t, uid, ss.UID)
+	require.NoError(t, r.reconcileRedisInitialPasswordSecret(a))
+	fetchSecret()
+	assert.Equal(t, rotatedAt, secret.Annotations[common.AnnotationRedisAuthClientsRevision])
+}
+
+func TestIsRedisPasswordRotationDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name              string
+		interval          *metav1.Duration
+		annotations       map[string]string
+		secretAnnotations map[string]string
+		due               bool
+		requeueAfter      time.Duration
+	}{
+		{
+			name: "no rotation configured",
+		},
+		{
+			name:         "interval not elapsed since creation",
+			interval:     &metav1.Duration{Duration: 3 * time.Hour},
+			requeueAfter: time.Hour,
+		},
+		{
+			name:              "interval elapsed since last rotation",
+			interval:          &metav1.Duration{Duration: time.Hour},
+			secretAnnotations: map[string]string{common.AnnotationRedisPasswordRotatedAt: now.Add(-time.Hour).Format(time.RFC3339Nano)},
+			due:               true,
+		},
+		{
+			name:        "on-demand rotation requested",
+			annotations: map[string]string{common.AnnotationRotateRedisPassword: "2"},
+			secretAnnotations: map[string]string{
+				common.AnnotationRotateRedisPassword: "1",
+			},
+			due: true,
+		},
+		{
+			name:        "on-demand rotation already handled",
+			annotations: map[string]string{common.AnnotationRotateRedisPassword: "1"},
+			secretAnnotations: map[string]string{
+				common.AnnotationRotateRedisPassword: "1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Annotations = test.annotations
+				a.Spec.Redis.Auth = &argoproj.ArgoCDRedisAuthSpec{RotationInterval: test.interval}
+			})
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+				Annotations:       test.secretAnnotations,
+			}}
+
+			due, requeueAfter := isRedisPasswordRotationDue(a, secret, now)
+			assert.Equal(t, test.due, due)
+			assert.Equal(t, test.requeueAfter, requeueAfter)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v3/cmpserver/plugin"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	repoPluginConfigFile      = "plugin.yaml"
+	repoPluginConfigMountPath = "/home/argocd/cmp-server/config"
+)
+
+// getRepoPluginsConfigMapName returns the name of the ConfigMap holding the inline plugin.yaml files of the repo plugins.
+func getRepoPluginsConfigMapName(cr *argoproj.ArgoCD) string {
+	return nameWithSuffix("repo-server-plugins", cr)
+}
+
+// getRepoPluginConfigMapKey returns the key of the plugin.yaml file of the given inline plugin in the generated ConfigMap.
+func getRepoPluginConfigMapKey(p argoproj.ArgoCDRepoPlugin) string {
+	return p.Name + ".yaml"
+}
+
+func getRepoPluginConfigVolumeName(p argoproj.ArgoCDRepoPlugin) string {
+	return "cmp-" + p.Name + "-config"
+}
+
+func getRepoPluginTmpVolumeName(p argoproj.ArgoCDRepoPlugin) string {
+	return "cmp-" + p.Name + "-tmp"
+}
+
+// repoPluginNameMaxLength is the maximum length of a repo plugin name, so that the names of its volumes, e.g.
+// cmp-<name>-config, remain valid DNS-1123 labels.
+const repoPluginNameMaxLength = validation.DNS1123LabelMaxLength - len("cmp--config")
+
+// validateRepoPluginName checks that the given plugin name can be used as the name of a sidecar container and in the
+// names of its volumes.
+func validateRepoPluginName(name string) error {
+	if msgs := validation.IsDNS1123Label(name); len(msgs) > 0 {
+		return fmt.Errorf("plugin %q: name %s", name, strings.Join(msgs, ", "))
+	}
+	if len(name) > repoPluginNameMaxLength {
+		return fmt.Errorf("plugin %q: name must be no more than %d characters", name, repoPluginNameMaxLength)
+	}
+	return nil
+}
+
+// getValidRepoPlugins returns the repo plugins of the given ArgoCD that can be run as sidecars, and the errors of
+// the others. Plugin names must be valid, unique and must not collide with the repo server containers, and inline
+// plugin configurations must be valid ConfigManagementPlugin definitions.
+func getValidRepoPlugins(cr *argoproj.ArgoCD) ([]argoproj.ArgoCDRepoPlugin, []error) {
+	names := map[string]string{"argocd-repo-server": "the repo server container"}
+	for _, c := range cr.Spec.Repo.SidecarContainers {
+		names[c.Name] = "a sidecar container"
+	}
+
+	var plugins []argoproj.ArgoCDRepoPlugin
+	var errs []error
+	for _, p := range cr.Spec.Repo.Plugins {
+		if err := validateRepoPluginName(p.Name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if owner, ok := names[p.Name]; ok {
+			errs = append(errs, fmt.Errorf("plugin %q: name is already used by %s", p.Name, owner))
+			continue
+		}
+		names[p.Name] = "another plugin"
+
+		if p.Configuration != "" {
+			config := plugin.PluginConfig{}
+			if err := yaml.Unmarshal([]byte(p.Configuration), &config); err != nil {
+				errs = append(errs, fmt.Errorf("plugin %q: %w", p.Name, err))
+				continue
+			}
+			if err := plugin.ValidatePluginConfig(config); err != nil {
+				errs = append(errs, fmt.Errorf("plugin %q: %w", p.Name, err))
+				continue
+			}
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, errs
+}
+
+// validateRepoPlugins returns the errors of the repo plugins of the given ArgoCD that cannot be run as sidecars.
+func validateRepoPlugins(cr *argoproj.ArgoCD) error {
+	_, errs := getValidRepoPlugins(cr)
+	return errors.Join(errs...)
+}
+
+// getRepoPluginContainers returns the cmp-server sidecar containers of the valid repo plugins.
+func getRepoPluginContainers(cr *argoproj.ArgoCD) []corev1.Container {
+	var containers []corev1.Container
+	plugins, _ := getValidRepoPlugins(cr)
+	for _, p := range plugins {
+		image := p.Image
+		if image == "" {
+			image = getRepoServerContainerImage(cr)
+		}
+		resources := getArgoRepoResources(cr)
+		if p.Resources != nil {
+			resources = *p.Resources
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:            p.Name,
+			Image:           image,
+			ImagePullPolicy: argoutil.GetImagePullPolicy(cr.Spec.ImagePullPolicy),
+			Command:         []string{"/var/run/argocd/argocd-cmp-server"},
+			Env:             argoutil.EnvMerge(p.Env, proxyEnvVars(), false),
+			Resources:       resources,
+			SecurityContext: argoutil.DefaultSecurityContext(),
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "var-files",
+					MountPath: "/var/run/argocd",
+				},
+				{
+					Name:      "plugins",
+					MountPath: "/home/argocd/cmp-server/plugins",
+				},
+				{
+					Name:      getRepoPluginConfigVolumeName(p),
+					MountPath: repoPluginConfigMountPath,
+				},
+				{
+					Name:      getRepoPluginTmpVolumeName(p),
+					MountPath: "/tmp",
+				},
+			},
+		})
+	}
+	return containers
+}
+
+// getRepoPluginVolumes returns the plugin.yaml and /tmp volumes of the valid repo plugin sidecar containers.
+func getRepoPluginVolumes(cr *argoproj.ArgoCD) []corev1.Volume {
+	var volumes []corev1.Volume
+	plugins, _ := getValidRepoPlugins(cr)
+	for _, p := range plugins {
+		source := &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: getRepoPluginsConfigMapName(cr)},
+			Items:                []corev1.KeyToPath{{Key: getRepoPluginConfigMapKey(p), Path: repoPluginConfigFile}},
+		}
+		if p.ConfigMapRef != nil {
+			source.Name = p.ConfigMapRef.Name
+			source.Items[0].Key = p.ConfigMapRef.Key
+		}
+
+		volumes = append(volumes,
+			corev1.Volume{
+				Name:         getRepoPluginConfigVolumeName(p),
+				VolumeSource: corev1.VolumeSource{ConfigMap: source},
+			},
+			corev1.Volume{
+				Name:         getRepoPluginTmpVolumeName(p),
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			},
+		)
+	}
+	return volumes
+}
+
+// repoPluginsChecksum returns a checksum of the plugin configurations, including the content of the referenced
+// ConfigMaps, so that a change rolls out the repo server. The cmp-server only reads plugin.yaml on startup.
+func (r *ReconcileArgoCD) repoPluginsChecksum(cr *argoproj.ArgoCD) string {
+	checksum := newObjectChecksum()
+	plugins, _ := getValidRepoPlugins(cr)
+	for _, p := range plugins {
+		checksum.sprintf("plugin:%s:%s", p.Name, p.Configuration)
+		if p.ConfigMapRef == nil {
+			continue
+		}
+		var cm corev1.ConfigMap
+		err := r.Get(context.TODO(), types.NamespacedName{Namespace: cr.Namespace, Name: p.ConfigMapRef.Name}, &cm)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "failed querying ConfigMap for repo plugin checksum", "name", p.ConfigMapRef.Name, "namespace", cr.Namespace)
+		}
+		checksum.sprintf("cm:%s:%s", p.ConfigMapRef.Key, cm.Data[p.ConfigMapRef.Key])
+	}
+	return checksum.hexSum()
+}
+
+// addRepoPlugins adds the sidecar containers and volumes of the repo plugins to the given repo server pod spec.
+func (r *ReconcileArgoCD) addRepoPlugins(cr *argoproj.ArgoCD, podTemplate *corev1.PodTemplateSpec) {
+	if plugins, _ := getValidRepoPlugins(cr); len(plugins) == 0 {
+		return
+	}
+	podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, getRepoPluginContainers(cr)...)
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, getRepoPluginVolumes(cr)...)
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = make(map[string]string)
+	}
+	podTemplate.Annotations[common.ArgoCDRepoPluginsChecksum] = r.repoPluginsChecksum(cr)
+}
+
+// reconcileRepoPluginsConfigMap will ensure that the ConfigMap holding the inline plugin.yaml files of the
+// repo plugins is present, and removes it when no plugin is configured inline.
+func (r *ReconcileArgoCD) reconcileRepoPluginsConfigMap(cr *argoproj.ArgoCD) error {
+	cm := newConfigMapWithName(getRepoPluginsConfigMapName(cr), cr)
+	cm.Data = map[string]string{}
+	plugins, _ := getValidRepoPlugins(cr)
+	for _, p := range plugins {
+		if p.Configuration != "" {
+			cm.Data[getRepoPluginConfigMapKey(p)] = p.Configuration
+		}
+	}
+
+	existing := &corev1.ConfigMap{}
+	exists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, existing)
+	if err != nil {
+		return err
+	}
+
+	if len(cm.Data) == 0 || !cr.Spec.Repo.IsEnabled() || cr.Spec.Repo.IsRemote() {
+		if exists {
+			argoutil.LogResourceDeletion(log, existing, "no repo plugin is configured inline")
+			return r.Delete(context.TODO(), existing)
+		}
+		return nil
+	}
+
+	if !exists {
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		argoutil.LogResourceCreation(log, cm)
+		return r.Create(context.TODO(), cm)
+	}
+
+	if !reflect.DeepEqual(cm.Data, existing.Data) {
+		existing.Data = cm.Data
+		argoutil.LogResourceUpdate(log, existing, "updating", "data")
+		return r.Update(context.TODO(), existing)
+	}
+	return nil
+}
+
+// reconcileStatusRepoPlugins will ensure that the RepoPluginsValid condition reflects the result of validating
+// the repo plugins declared on the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusRepoPlugins(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	if len(cr.Spec.Repo.Plugins) == 0 {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionRepoPluginsValid)
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:   argoproj.ArgoCDConditionRepoPluginsValid,
+		Status: metav1.ConditionTrue,
+		Reason: argoproj.ArgoCDConditionReasonSuccess,
+	}
+	if err := validateRepoPlugins(cr); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonInvalidRepoPlugins
+		condition.Message = err.Error()
+	}
+	argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+	return nil
+}
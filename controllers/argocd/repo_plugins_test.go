@@ -0,0 +1,208 @@
+package argocd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const testRepoPluginConfiguration = `apiVersion: argoproj.io/v1alpha1
+kind: ConfigManagementPlugin
+metadata:
+  name: cdk8s
+spec:
+  version: v1.0
+  generate:
+    command: [cdk8s, synth]
+`
+
+func TestReconcileArgoCD_reconcileRepoDeployment_plugins(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Plugins = []argoproj.ArgoCDRepoPlugin{
+			{
+				Name:          "cdk8s",
+				Image:         "cdk8s:latest",
+				Configuration: testRepoPluginConfiguration,
+				Env:           []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			},
+			{
+				Name: "tanka",
+				ConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "tanka-plugin"},
+					Key:                  "plugin.yaml",
+				},
+			},
+		}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileRepoPluginsConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-plugins", Namespace: a.Namespace}, cm))
+	assert.Equal(t, map[string]string{"cdk8s.yaml": testRepoPluginConfiguration}, cm.Data)
+
+	require.NoError(t, r.reconcileRepoDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, deployment))
+
+	containers := deployment.Spec.Template.Spec.Containers
+	require.Len(t, containers, 3)
+	cdk8s := containers[1]
+	assert.Equal(t, "cdk8s", cdk8s.Name)
+	assert.Equal(t, "cdk8s:latest", cdk8s.Image)
+	assert.Equal(t, []string{"/var/run/argocd/argocd-cmp-server"}, cdk8s.Command)
+	assert.Contains(t, cdk8s.Env, corev1.EnvVar{Name: "FOO", Value: "bar"})
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "var-files", MountPath: "/var/run/argocd"},
+		{Name: "plugins", MountPath: "/home/argocd/cmp-server/plugins"},
+		{Name: "cmp-cdk8s-config", MountPath: "/home/argocd/cmp-server/config"},
+		{Name: "cmp-cdk8s-tmp", MountPath: "/tmp"},
+	}, cdk8s.VolumeMounts)
+	assert.True(t, *cdk8s.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, getRepoServerContainerImage(a), containers[2].Image)
+
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "cmp-tanka-config",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "tanka-plugin"},
+			Items:                []corev1.KeyToPath{{Key: "plugin.yaml", Path: "plugin.yaml"}},
+		}},
+	})
+	checksum := deployment.Spec.Template.Annotations[common.ArgoCDRepoPluginsChecksum]
+	assert.NotEmpty(t, checksum)
+
+	// Changing a referenced ConfigMap rolls out the repo server.
+	require.NoError(t, r.Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tanka-plugin", Namespace: a.Namespace},
+		Data:       map[string]string{"plugin.yaml": "kind: ConfigManagementPlugin"},
+	}))
+	require.NoError(t, r.reconcileRepoDeployment(a, false))
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, deployment))
+	assert.NotEqual(t, checksum, deployment.Spec.Template.Annotations[common.ArgoCDRepoPluginsChecksum])
+
+	// Removing the plugins removes the sidecars and the generated ConfigMap.
+	a.Spec.Repo.Plugins = nil
+	require.NoError(t, r.reconcileRepoPluginsConfigMap(a))
+	require.NoError(t, r.reconcileRepoDeployment(a, false))
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	assert.NotContains(t, deployment.Spec.Template.Annotations, common.ArgoCDRepoPluginsChecksum)
+	err := r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-plugins", Namespace: a.Namespace}, cm)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestValidateRepoPlugins(t *testing.T) {
+	tests := []struct {
+		name     string
+		repo     argoproj.ArgoCDRepoSpec
+		expected string
+	}{
+		{
+			name: "valid",
+			repo: argoproj.ArgoCDRepoSpec{Plugins: []argoproj.ArgoCDRepoPlugin{{Name: "cdk8s", Configuration: testRepoPluginConfiguration}}},
+		},
+		{
+			name: "duplicate plugin",
+			repo: argoproj.ArgoCDRepoSpec{Plugins: []argoproj.ArgoCDRepoPlugin{
+				{Name: "cdk8s", Configuration: testRepoPluginConfiguration},
+				{Name: "cdk8s", Configuration: testRepoPluginConfiguration},
+			}},
+			expected: `plugin "cdk8s": name is already used by another plugin`,
+		},
+		{
+			name: "sidecar container conflict",
+			repo: argoproj.ArgoCDRepoSpec{
+				SidecarContainers: []corev1.Container{{Name: "cdk8s"}},
+				Plugins:           []argoproj.ArgoCDRepoPlugin{{Name: "cdk8s", Configuration: testRepoPluginConfiguration}},
+			},
+			expected: `plugin "cdk8s": name is already used by a sidecar container`,
+		},
+		{
+			name:     "invalid configuration",
+			repo:     argoproj.ArgoCDRepoSpec{Plugins: []argoproj.ArgoCDRepoPlugin{{Name: "cdk8s", Configuration: "kind: Plugin\nmetadata:\n  name: cdk8s\n"}}},
+			expected: `plugin "cdk8s": invalid plugin configuration file. kind should be ConfigManagementPlugin, found Plugin`,
+		},
+		{
+			name:     "invalid name",
+			repo:     argoproj.ArgoCDRepoSpec{Plugins: []argoproj.ArgoCDRepoPlugin{{Name: "CDK8s", Configuration: testRepoPluginConfiguration}}},
+			expected: `plugin "CDK8s": name a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`,
+		},
+		{
+			name:     "name too long for the volume names",
+			repo:     argoproj.ArgoCDRepoSpec{Plugins: []argoproj.ArgoCDRepoPlugin{{Name: strings.Repeat("a", 53), Configuration: testRepoPluginConfiguration}}},
+			expected: `plugin "` + strings.Repeat("a", 53) + `": name must be no more than 52 characters`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.Repo = test.repo
+			})
+			status := &argoproj.ArgoCDStatus{}
+			r := &ReconcileArgoCD{}
+			require.NoError(t, r.reconcileStatusRepoPlugins(a, status))
+			require.Len(t, status.Conditions, 1)
+
+			err := validateRepoPlugins(a)
+			if test.expected == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+				return
+			}
+			assert.EqualError(t, err, test.expected)
+			assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+			assert.Equal(t, test.expected, status.Conditions[0].Message)
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_invalidPlugins(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Plugins = []argoproj.ArgoCDRepoPlugin{
+			{Name: "cdk8s", Configuration: testRepoPluginConfiguration},
+			{Name: "tanka", Configuration: "kind: Plugin\nmetadata:\n  name: tanka\n"},
+		}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	// Only the invalid plugin is left out of the repo server.
+	require.NoError(t, r.reconcileRepoPluginsConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-plugins", Namespace: a.Namespace}, cm))
+	assert.Equal(t, map[string]string{"cdk8s.yaml": testRepoPluginConfiguration}, cm.Data)
+
+	require.NoError(t, r.reconcileRepoDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, deployment))
+	containers := deployment.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	assert.Equal(t, "cdk8s", containers[1].Name)
+	for _, v := range deployment.Spec.Template.Spec.Volumes {
+		assert.NotContains(t, v.Name, "tanka")
+	}
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusRepoPlugins(a, status))
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+	assert.Contains(t, status.Conditions[0].Message, `plugin "tanka"`)
+}
@@ -45,9 +45,9 @@ import (
 
 // getArgoCDRepoServerReplicas will return the size value for the argocd-repo-server replica count if it
 // has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
-// replicas value is < 0.
+// replicas value is < 0. If Autoscale is enabled, the value for replicas in the argocd CR will be ignored.
 func getArgoCDRepoServerReplicas(cr *argocdoperatorv1beta1.ArgoCD) *int32 {
-	if cr.Spec.Repo.Replicas != nil && *cr.Spec.Repo.Replicas >= 0 {
+	if !isRepoServerAutoscaled(cr) && cr.Spec.Repo.Replicas != nil && *cr.Spec.Repo.Replicas >= 0 {
 		return cr.Spec.Repo.Replicas
 	}
 
@@ -61,12 +61,14 @@ func getArgoRepoCommand(cr *argocdoperatorv1beta1.ArgoCD, useTLSForRedis bool) [
 	cmd = append(cmd, "uid_entrypoint.sh")
 	cmd = append(cmd, "argocd-repo-server")
 
-	if cr.Spec.Redis.IsEnabled() {
+	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.External != nil {
+		cmd = append(cmd, argoutil.GetRedisExternalArgs(cr)...)
+	} else if cr.Spec.Redis.IsEnabled() {
 		cmd = append(cmd, "--redis", argoutil.GetRedisServerAddress(cr))
 	} else {
 		log.Info("Redis is Disabled. Skipping adding Redis configuration to Repo Server.")
 	}
-	if useTLSForRedis {
+	if useTLSForRedis && cr.Spec.Redis.External == nil {
 		cmd = append(cmd, "--redis-use-tls")
 		if isRedisTLSVerificationDisabled(cr) {
 			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
@@ -99,6 +101,11 @@ func getRepoServerAddress(cr *argocdoperatorv1beta1.ArgoCD) string {
 
 // reconcileRepoDeployment will ensure the Deployment resource is present for the ArgoCD Repo component.
 func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.ArgoCD, useTLSForRedis bool) error {
+	// Invalid repo plugins are left out of the Deployment, the RepoPluginsValid condition reports them.
+	if err := validateRepoPlugins(cr); err != nil {
+		log.Error(err, "invalid repo plugins are not added to the repo server deployment")
+	}
+
 	deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	automountToken := false
 	if cr.Spec.Repo.MountSAToken {
@@ -116,7 +123,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.Argo
 
 	// Environment specified in the CR take precedence over everything else
 	repoEnv = argoutil.EnvMerge(repoEnv, proxyEnvVars(), false)
-	repoEnv = argoutil.EnvMerge(repoEnv, argoutil.GetRedisAuthEnv(), false)
+	repoEnv = argoutil.EnvMerge(repoEnv, argoutil.GetRedisAuthEnv(cr), false)
 	if cr.Spec.Repo.ExecTimeout != nil {
 		repoEnv = argoutil.EnvMerge(repoEnv, []corev1.EnvVar{{Name: "ARGOCD_EXEC_TIMEOUT", Value: fmt.Sprintf("%ds", *cr.Spec.Repo.ExecTimeout)}}, true)
 	}
@@ -177,6 +184,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.Argo
 	}
 
 	redisAuthVolume, redisAuthMount := argoutil.MountRedisAuthToArgo(cr)
+	redisExternalTLSVolumes, redisExternalTLSMounts := argoutil.MountRedisExternalTLSToArgo(cr)
 
 	repoServerVolumeMounts := []corev1.VolumeMount{
 		{
@@ -209,6 +217,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.Argo
 		},
 		redisAuthMount,
 	}
+	repoServerVolumeMounts = append(repoServerVolumeMounts, redisExternalTLSMounts...)
 
 	if !volumeMountOverridesTmpVolume {
 
@@ -350,6 +359,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.Argo
 		},
 		redisAuthVolume,
 	}
+	repoServerVolumes = append(repoServerVolumes, redisExternalTLSVolumes...)
 
 	// If the user is not used a custom /tmp mount, then just use the default
 	if !volumeMountOverridesTmpVolume {
@@ -366,6 +376,8 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.Argo
 	}
 	deploy.Spec.Template.Spec.Volumes = repoServerVolumes
 
+	r.addRepoPlugins(cr, &deploy.Spec.Template)
+
 	r.injectCATrustToContainers(cr, deploy)
 
 	if replicas := getArgoCDRepoServerReplicas(cr); replicas != nil {
@@ -377,6 +389,9 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.Argo
 			deploy.Spec.Template.Annotations[key] = value
 		}
 	}
+	if _, err := argoutil.SetRedisAuthRevision(r.Client, cr, &deploy.Spec.Template); err != nil {
+		return err
+	}
 
 	if cr.Spec.Repo.Labels != nil {
 		for key, value := range cr.Spec.Repo.Labels {
@@ -389,6 +404,9 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.Argo
 		log.Error(err, "ArgoCD Repo Server reconciler hook failed")
 		return err
 	}
+	if err := r.applyMutationWebhooks(cr, deploy, common.ArgoCDRepoServerComponent, ""); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	deplExists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
@@ -473,8 +491,10 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argocdoperatorv1beta1.Argo
 			changes = append(changes, "init containers")
 		}
 		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
-			existing.Spec.Replicas = deploy.Spec.Replicas
-			changes = append(changes, "replicas")
+			if !isRepoServerAutoscaled(cr) {
+				existing.Spec.Replicas = deploy.Spec.Replicas
+				changes = append(changes, "replicas")
+			}
 		}
 
 		if deploy.Spec.Template.Spec.AutomountServiceAccountToken != existing.Spec.Template.Spec.AutomountServiceAccountToken {
@@ -0,0 +1,223 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// repositorySecretReservedKeys are the keys of a generated repository Secret that are derived from the
+// ArgoCD CR. Keys with these names in a referenced credentials Secret are ignored.
+var repositorySecretReservedKeys = []string{"name", "url", "type", "project", "proxy", "insecure"}
+
+// repositorySecretEntry is the common form of a spec.repositories or spec.repositoryCredentialTemplates entry.
+type repositorySecretEntry struct {
+	name           string
+	secretType     string
+	suffix         string
+	fields         map[string]string
+	credentialsRef *corev1.LocalObjectReference
+}
+
+// reconcileRepositories renders spec.repositories and spec.repositoryCredentialTemplates into labeled
+// Argo CD repository Secrets, records the result of each entry in the status and removes Secrets of
+// entries that are no longer declared.
+func (r *ReconcileArgoCD) reconcileRepositories(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	desired := map[string]bool{}
+
+	for _, repo := range cr.Spec.Repositories {
+		entry := repositorySecretEntry{
+			name:       repo.Name,
+			secretType: common.ArgoCDSecretTypeRepository,
+			suffix:     common.ArgoCDRepositorySecretSuffix,
+			fields: map[string]string{
+				"name":    repo.Name,
+				"url":     repo.URL,
+				"type":    repositoryTypeOrDefault(repo.Type),
+				"project": repo.Project,
+				"proxy":   repo.Proxy,
+			},
+			credentialsRef: repo.CredentialsSecretRef,
+		}
+		if repo.Insecure {
+			entry.fields["insecure"] = "true"
+		}
+
+		status, err := r.reconcileRepositorySecret(cr, entry)
+		if err != nil {
+			return err
+		}
+		desired[status.SecretName] = true
+		argocdStatus.Repositories = append(argocdStatus.Repositories, status)
+	}
+
+	for _, tmpl := range cr.Spec.RepositoryCredentialTemplates {
+		credentialsRef := tmpl.CredentialsSecretRef
+		entry := repositorySecretEntry{
+			name:       tmpl.Name,
+			secretType: common.ArgoCDSecretTypeRepoCreds,
+			suffix:     common.ArgoCDRepoCredsSecretSuffix,
+			fields: map[string]string{
+				"url":   tmpl.URL,
+				"type":  repositoryTypeOrDefault(tmpl.Type),
+				"proxy": tmpl.Proxy,
+			},
+			credentialsRef: &credentialsRef,
+		}
+
+		status, err := r.reconcileRepositorySecret(cr, entry)
+		if err != nil {
+			return err
+		}
+		desired[status.SecretName] = true
+		argocdStatus.RepositoryCredentialTemplates = append(argocdStatus.RepositoryCredentialTemplates, status)
+	}
+
+	return r.pruneRepositorySecrets(cr, desired)
+}
+
+// reconcileRepositorySecret creates or updates the Secret for a single entry. Problems with the entry itself,
+// such as a missing credentials Secret, are reported in the returned status; only API errors are returned.
+func (r *ReconcileArgoCD) reconcileRepositorySecret(cr *argoproj.ArgoCD, entry repositorySecretEntry) (argoproj.ArgoCDRepositoryStatus, error) {
+	secret := argoutil.NewSecretWithSuffix(cr, fmt.Sprintf("%s-%s", entry.suffix, entry.name))
+	status := argoproj.ArgoCDRepositoryStatus{
+		Name:       entry.name,
+		SecretName: secret.Name,
+		Phase:      argoproj.ArgoCDRepositoryPhaseSynced,
+	}
+
+	data := map[string][]byte{}
+	if entry.credentialsRef != nil && entry.credentialsRef.Name != "" {
+		credentials := &corev1.Secret{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: entry.credentialsRef.Name, Namespace: cr.Namespace}, credentials); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return status, err
+			}
+			log.Info(fmt.Sprintf("warning: credentials Secret %s/%s for repository entry %q not found", cr.Namespace, entry.credentialsRef.Name, entry.name))
+			status.Phase = argoproj.ArgoCDRepositoryPhaseFailed
+			status.Message = fmt.Sprintf("credentials Secret %q not found", entry.credentialsRef.Name)
+			return status, nil
+		}
+		for k, v := range credentials.Data {
+			data[k] = append([]byte(nil), v...)
+		}
+	}
+	for _, k := range repositorySecretReservedKeys {
+		delete(data, k)
+	}
+	for k, v := range entry.fields {
+		if v != "" {
+			data[k] = []byte(v)
+		}
+	}
+
+	secret.Labels[common.ArgoCDSecretTypeLabel] = entry.secretType
+	secret.Data = data
+
+	existing := &corev1.Secret{}
+	found, err := argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, existing)
+	if err != nil {
+		return status, err
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+			return status, err
+		}
+		argoutil.LogResourceCreation(log, secret)
+		return status, r.Create(context.TODO(), secret)
+	}
+
+	if !metav1.IsControlledBy(existing, cr) {
+		status.Phase = argoproj.ArgoCDRepositoryPhaseFailed
+		status.Message = fmt.Sprintf("Secret %q already exists and is not managed by this Argo CD instance", secret.Name)
+		return status, nil
+	}
+
+	var changes []string
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	labelsChanged := false
+	for k, v := range secret.Labels {
+		if existing.Labels[k] != v {
+			existing.Labels[k] = v
+			labelsChanged = true
+		}
+	}
+	if labelsChanged {
+		changes = append(changes, "labels")
+	}
+	if !reflect.DeepEqual(existing.Data, secret.Data) {
+		existing.Data = secret.Data
+		changes = append(changes, "data")
+	}
+	if len(changes) == 0 {
+		return status, nil
+	}
+
+	argoutil.LogResourceUpdate(log, existing, "updating", strings.Join(changes, ", "))
+	return status, r.Update(context.TODO(), existing)
+}
+
+// pruneRepositorySecrets deletes the repository Secrets owned by the ArgoCD CR whose entries were removed from spec.
+func (r *ReconcileArgoCD) pruneRepositorySecrets(cr *argoproj.ArgoCD, desired map[string]bool) error {
+	secrets := &corev1.SecretList{}
+	selector := client.MatchingLabels{
+		common.ArgoCDKeyManagedBy: cr.Name,
+		common.ArgoCDKeyPartOf:    common.ArgoCDAppName,
+	}
+	if err := r.List(context.TODO(), secrets, client.InNamespace(cr.Namespace), selector); err != nil {
+		return err
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		secretType := secret.Labels[common.ArgoCDSecretTypeLabel]
+		if secretType != common.ArgoCDSecretTypeRepository && secretType != common.ArgoCDSecretTypeRepoCreds {
+			continue
+		}
+		if desired[secret.Name] || !metav1.IsControlledBy(secret, cr) {
+			continue
+		}
+		argoutil.LogResourceDeletion(log, secret, "repository entry was removed from the ArgoCD CR")
+		if err := r.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// repositoryTypeOrDefault returns the repository type, defaulting to git.
+func repositoryTypeOrDefault(t argoproj.ArgoCDRepositoryType) string {
+	if t == "" {
+		return string(argoproj.ArgoCDRepositoryTypeGit)
+	}
+	return string(t)
+}
@@ -0,0 +1,217 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileRepositories(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepositorySpec{
+			{
+				Name:                 "charts",
+				URL:                  "https://charts.example.com",
+				Type:                 argoproj.ArgoCDRepositoryTypeHelm,
+				Project:              "platform",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "charts-creds"},
+			},
+			{
+				Name:     "public",
+				URL:      "https://github.com/example/public.git",
+				Insecure: true,
+			},
+		}
+		a.Spec.RepositoryCredentialTemplates = []argoproj.ArgoCDRepositoryCredentialTemplateSpec{
+			{
+				Name:                 "github",
+				URL:                  "https://github.com/example",
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "github-creds"},
+			},
+		}
+	})
+	chartsCreds := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "charts-creds", Namespace: testNamespace},
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("s3cr3t"),
+			"url":      []byte("https://ignored.example.com"),
+		},
+	}
+	githubCreds := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-creds", Namespace: testNamespace},
+		Data:       map[string][]byte{"sshPrivateKey": []byte("key")},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, chartsCreds, githubCreds}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileRepositories(cr, status))
+
+	charts := &corev1.Secret{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-charts", Namespace: testNamespace}, charts))
+	assert.Equal(t, common.ArgoCDSecretTypeRepository, charts.Labels[common.ArgoCDSecretTypeLabel])
+	assert.Equal(t, map[string][]byte{
+		"name":     []byte("charts"),
+		"url":      []byte("https://charts.example.com"),
+		"type":     []byte("helm"),
+		"project":  []byte("platform"),
+		"username": []byte("admin"),
+		"password": []byte("s3cr3t"),
+	}, charts.Data)
+	assert.True(t, metav1.IsControlledBy(charts, cr))
+
+	public := &corev1.Secret{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-public", Namespace: testNamespace}, public))
+	assert.Equal(t, []byte("git"), public.Data["type"])
+	assert.Equal(t, []byte("true"), public.Data["insecure"])
+
+	github := &corev1.Secret{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-github", Namespace: testNamespace}, github))
+	assert.Equal(t, common.ArgoCDSecretTypeRepoCreds, github.Labels[common.ArgoCDSecretTypeLabel])
+	assert.Equal(t, []byte("https://github.com/example"), github.Data["url"])
+	assert.Equal(t, []byte("key"), github.Data["sshPrivateKey"])
+
+	assert.Equal(t, []argoproj.ArgoCDRepositoryStatus{
+		{Name: "charts", SecretName: "argocd-repository-charts", Phase: argoproj.ArgoCDRepositoryPhaseSynced},
+		{Name: "public", SecretName: "argocd-repository-public", Phase: argoproj.ArgoCDRepositoryPhaseSynced},
+	}, status.Repositories)
+	assert.Equal(t, []argoproj.ArgoCDRepositoryStatus{
+		{Name: "github", SecretName: "argocd-repo-creds-github", Phase: argoproj.ArgoCDRepositoryPhaseSynced},
+	}, status.RepositoryCredentialTemplates)
+}
+
+func TestReconcileArgoCD_reconcileRepositories_updatesCredentials(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepositorySpec{
+			{
+				Name:                 "private",
+				URL:                  "https://git.example.com/private.git",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "private-creds"},
+			},
+		}
+	})
+	creds := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "private-creds", Namespace: testNamespace},
+		Data:       map[string][]byte{"password": []byte("old")},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, creds}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+	require.NoError(t, r.reconcileRepositories(cr, &argoproj.ArgoCDStatus{}))
+
+	creds.Data["password"] = []byte("new")
+	require.NoError(t, r.Update(context.TODO(), creds))
+	require.NoError(t, r.reconcileRepositories(cr, &argoproj.ArgoCDStatus{}))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-private", Namespace: testNamespace}, secret))
+	assert.Equal(t, []byte("new"), secret.Data["password"])
+}
+
+func TestReconcileArgoCD_reconcileRepositories_reportsEntryErrors(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepositorySpec{
+			{
+				Name:                 "missing",
+				URL:                  "https://git.example.com/missing.git",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "does-not-exist"},
+			},
+			{
+				Name: "taken",
+				URL:  "https://git.example.com/taken.git",
+			},
+		}
+	})
+	unmanaged := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-repository-taken", Namespace: testNamespace},
+		Data:       map[string][]byte{"url": []byte("https://elsewhere.example.com")},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, unmanaged}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileRepositories(cr, status))
+
+	require.Len(t, status.Repositories, 2)
+	assert.Equal(t, argoproj.ArgoCDRepositoryPhaseFailed, status.Repositories[0].Phase)
+	assert.Contains(t, status.Repositories[0].Message, `"does-not-exist" not found`)
+	assert.Equal(t, argoproj.ArgoCDRepositoryPhaseFailed, status.Repositories[1].Phase)
+	assert.Contains(t, status.Repositories[1].Message, "not managed by this Argo CD instance")
+
+	err := r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-missing", Namespace: testNamespace}, &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	untouched := &corev1.Secret{}
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-taken", Namespace: testNamespace}, untouched))
+	assert.Equal(t, []byte("https://elsewhere.example.com"), untouched.Data["url"])
+}
+
+func TestReconcileArgoCD_reconcileRepositories_prunesRemovedEntries(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepositorySpec{
+			{Name: "keep", URL: "https://git.example.com/keep.git"},
+			{Name: "drop", URL: "https://git.example.com/drop.git"},
+		}
+	})
+	userRepo := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "user-repository",
+			Namespace: testNamespace,
+			Labels:    map[string]string{common.ArgoCDSecretTypeLabel: common.ArgoCDSecretTypeRepository},
+		},
+	}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, userRepo}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+	require.NoError(t, r.reconcileRepositories(cr, &argoproj.ArgoCDStatus{}))
+
+	cr.Spec.Repositories = cr.Spec.Repositories[:1]
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileRepositories(cr, status))
+
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-keep", Namespace: testNamespace}, &corev1.Secret{}))
+	err := r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repository-drop", Namespace: testNamespace}, &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "user-repository", Namespace: testNamespace}, &corev1.Secret{}))
+	assert.Len(t, status.Repositories, 1)
+}
+
+func TestReconcileArgoCD_repositoryCredentialsSecretMapper(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepositorySpec{
+			{Name: "charts", URL: "https://charts.example.com", CredentialsSecretRef: &corev1.LocalObjectReference{Name: "charts-creds"}},
+			{Name: "public", URL: "https://github.com/example/public.git"},
+		}
+		a.Spec.RepositoryCredentialTemplates = []argoproj.ArgoCDRepositoryCredentialTemplateSpec{
+			{Name: "github", URL: "https://github.com/example", CredentialsSecretRef: corev1.LocalObjectReference{Name: "github-creds"}},
+		}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cr}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}}
+	for _, name := range []string{"charts-creds", "github-creds"} {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace}}
+		assert.Equal(t, want, r.repositoryCredentialsSecretMapper(context.TODO(), secret))
+	}
+
+	other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: testNamespace}}
+	assert.Empty(t, r.repositoryCredentialsSecretMapper(context.TODO(), other))
+}
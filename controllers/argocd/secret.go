@@ -213,6 +213,14 @@ func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	var dexConnectorChanges []string
+	if err := applyDexConnectorSecrets(context.TODO(), r.Client, cr, secret, &dexConnectorChanges); err != nil {
+		return err
+	}
+
+	var oidcChanges []string
+	r.applyOIDCSecrets(cr, secret, &oidcChanges)
+
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
 		return err
 	}
@@ -395,6 +403,12 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoproj.ArgoCD, secre
 		return err
 	}
 
+	if err := applyDexConnectorSecrets(context.TODO(), r.Client, cr, secret, &changes); err != nil {
+		return err
+	}
+
+	r.applyOIDCSecrets(cr, secret, &changes)
+
 	if len(changes) > 0 {
 		argoutil.LogResourceUpdate(log, secret, "updating", strings.Join(changes, ", "))
 		if err := r.Update(context.TODO(), secret); err != nil {
@@ -993,7 +1007,7 @@ func (r *ReconcileArgoCD) reconcileRedisInitialPasswordSecret(cr *argoproj.ArgoC
 		_, hasUsername := secret.Data["auth_username"]
 		_, hasAcl := secret.Data["users.acl"]
 		if hasPwd && hasAuth && hasUsername && hasAcl {
-			return nil // Healthy - keep it
+			return r.reconcileRedisPasswordRotation(cr, secret) // Healthy - keep it, unless the password must be rotated
 		}
 	}
 
@@ -126,6 +126,9 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		}
 	}
 	ss.Spec.Template.Labels[common.ArgoCDKeyName] = nameWithSuffix("redis-ha", cr)
+	if err := r.setRedisAuthChecksum(cr, &ss.Spec.Template); err != nil {
+		return err
+	}
 
 	ss.Spec.Template.Spec.Affinity = &corev1.Affinity{
 		PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -412,6 +415,9 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 	if err := applyReconcilerHook(cr, ss, ""); err != nil {
 		return err
 	}
+	if err := r.applyMutationWebhooks(cr, ss, common.ArgoCDRedisHAComponent, ""); err != nil {
+		return err
+	}
 
 	existing := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
 	ssExists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
@@ -534,7 +540,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		return nil // StatefulSet found, do nothing
 	}
 
-	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
+	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.IsRemote() {
 		log.Info("Custom Redis Endpoint. Skipping starting redis.")
 		return nil
 	}
@@ -677,13 +683,14 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	controllerEnv = argoutil.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr, replicas), true)
 	// Let user specify their own environment first
 	controllerEnv = argoutil.EnvMerge(controllerEnv, proxyEnvVars(), false)
-	controllerEnv = argoutil.EnvMerge(controllerEnv, argoutil.GetRedisAuthEnv(), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, argoutil.GetRedisAuthEnv(cr), false)
 
 	if cr.Spec.Controller.InitContainers != nil {
 		ss.Spec.Template.Spec.InitContainers = append(ss.Spec.Template.Spec.InitContainers, cr.Spec.Controller.InitContainers...)
 	}
 
 	redisAuthVolume, redisAuthMount := argoutil.MountRedisAuthToArgo(cr)
+	redisExternalTLSVolumes, redisExternalTLSMounts := argoutil.MountRedisExternalTLSToArgo(cr)
 
 	controllerVolumeMounts := []corev1.VolumeMount{
 		{
@@ -708,6 +715,7 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		},
 		redisAuthMount,
 	}
+	controllerVolumeMounts = append(controllerVolumeMounts, redisExternalTLSMounts...)
 
 	if cr.Spec.Controller.VolumeMounts != nil {
 		controllerVolumeMounts = append(controllerVolumeMounts, cr.Spec.Controller.VolumeMounts...)
@@ -801,6 +809,7 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		},
 		redisAuthVolume,
 	}
+	controllerVolumes = append(controllerVolumes, redisExternalTLSVolumes...)
 
 	if cr.Spec.Controller.Volumes != nil {
 		controllerVolumes = append(controllerVolumes, cr.Spec.Controller.Volumes...)
@@ -878,6 +887,9 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			ss.Spec.Template.Annotations[key] = value
 		}
 	}
+	if _, err := argoutil.SetRedisAuthRevision(r.Client, cr, &ss.Spec.Template); err != nil {
+		return err
+	}
 
 	if cr.Spec.Controller.Labels != nil {
 		for key, value := range cr.Spec.Controller.Labels {
@@ -885,6 +897,10 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		}
 	}
 
+	if err := r.applyMutationWebhooks(cr, ss, common.ArgoCDApplicationControllerComponent, ""); err != nil {
+		return err
+	}
+
 	existing := newStatefulSetWithName(applicationControllerResourceName(cr), "application-controller", cr)
 	ssExists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
 	if err != nil {
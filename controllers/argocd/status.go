@@ -16,8 +16,12 @@ package argocd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"reflect"
 	"strings"
+	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +32,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent/agent"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
@@ -38,6 +44,8 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoproj.ArgoCD, argocdStatus *arg
 	// - These functions should ONLY modify the values in 'argocdStatus' param.
 	// - Updating the actual K8s object is handled elsewhere
 
+	argocdStatus.ObservedGeneration = cr.Generation
+
 	if argocdStatus.ApplicationController == "" { // Don't override app controller status if it was already set elsewhere
 
 		if err := r.reconcileStatusApplicationController(cr, argocdStatus); err != nil {
@@ -69,6 +77,54 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoproj.ArgoCD, argocdStatus *arg
 		}
 	}
 
+	if err := r.reconcileStatusRBACPolicy(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusOIDC(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusRepoPlugins(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusRedisConfig(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusResourceRecommendations(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusVerticalPodAutoscalers(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusProfile(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusTemplates(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusAgentPKI(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusAgents(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusAgentNamespacePolicies(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusAgentModeMigration(cr, argocdStatus); err != nil {
+		return err
+	}
+
 	if argocdStatus.Phase == "" { // We don't want to override a phase that was already set
 		if err := r.reconcileStatusHost(cr, argocdStatus); err != nil {
 			return err
@@ -224,7 +280,7 @@ func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoproj.ArgoCD, argocdStatus
 
 	appControllerAvailable := (!cr.Spec.Controller.IsEnabled() && argocdStatus.ApplicationController == "Unknown") || argocdStatus.ApplicationController == "Running"
 
-	redisAvailable := (!cr.Spec.Redis.IsEnabled() && argocdStatus.Redis == "Unknown") || argocdStatus.Redis == "Running" || (cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "")
+	redisAvailable := (!cr.Spec.Redis.IsEnabled() && argocdStatus.Redis == "Unknown") || argocdStatus.Redis == "Running" || (cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.IsRemote())
 
 	repoServerAvailable := (!cr.Spec.Repo.IsEnabled() && argocdStatus.Repo == "Unknown") || argocdStatus.Repo == "Running"
 
@@ -247,10 +303,87 @@ func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoproj.ArgoCD, argocdStatus
 	return nil
 }
 
+// validateRedisConfig checks that the external Redis of the given ArgoCD can be used by the Argo CD components.
+func validateRedisConfig(cr *argoproj.ArgoCD) error {
+	var errs []error
+	if cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
+		errs = append(errs, errors.New("spec.redis.remote and spec.redis.external cannot both be set"))
+	}
+	if isPrincipalEnabled(cr) {
+		errs = append(errs, argocdagent.ValidatePrincipalRedis(cr))
+	}
+	if cr.Spec.ArgoCDAgent != nil && cr.Spec.ArgoCDAgent.Agent != nil && cr.Spec.ArgoCDAgent.Agent.IsEnabled() {
+		errs = append(errs, agent.ValidateAgentRedis(cr))
+	}
+	return errors.Join(errs...)
+}
+
+// redisExternalProbeTimeout bounds the time spent probing the external Redis of an ArgoCD on each reconciliation.
+const redisExternalProbeTimeout = 3 * time.Second
+
+// dialRedisExternal checks that the given external Redis endpoint accepts TCP connections.
+var dialRedisExternal = func(ctx context.Context, address string) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// getRedisExternalStatus will return the status of the external Redis of the given ArgoCD. The Redis is considered
+// running when its address, or one of its Sentinel endpoints, accepts connections within redisExternalProbeTimeout.
+func getRedisExternalStatus(cr *argoproj.ArgoCD) string {
+	external := cr.Spec.Redis.External
+	addresses := []string{external.Address}
+	if external.Sentinel != nil {
+		addresses = external.Sentinel.Addresses
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), redisExternalProbeTimeout)
+	defer cancel()
+	var errs []error
+	for _, address := range addresses {
+		err := dialRedisExternal(ctx, address)
+		if err == nil {
+			return "Running"
+		}
+		errs = append(errs, err)
+	}
+	log.Info(fmt.Sprintf("external Redis of %s/%s is unreachable: %v", cr.Namespace, cr.Name, errors.Join(errs...)))
+	return "Failed"
+}
+
+// reconcileStatusRedisConfig will ensure that the RedisConfigValid condition reflects the result of validating the
+// external Redis declared on the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusRedisConfig(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	if cr.Spec.Redis.External == nil {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionRedisConfigValid)
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:   argoproj.ArgoCDConditionRedisConfigValid,
+		Status: metav1.ConditionTrue,
+		Reason: argoproj.ArgoCDConditionReasonSuccess,
+	}
+	if err := validateRedisConfig(cr); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonInvalidRedisConfig
+		condition.Message = err.Error()
+	}
+	argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+	return nil
+}
+
 // reconcileStatusRedis will ensure that the Redis status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
 	status := "Unknown"
 
+	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.External != nil {
+		argocdStatus.Redis = getRedisExternalStatus(cr)
+		return nil
+	}
+
 	if !cr.Spec.HA.Enabled {
 		deploy := newDeploymentWithSuffix("redis", "redis", cr)
 		deplExists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy)
@@ -455,3 +588,73 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD, argocdStatus
 
 	return nil
 }
+
+// reconcileStatusAgentPKI will report the expiry of the certificates and the JWT signing key used by the Argo CD Agent
+// principal of the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusAgentPKI(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	statuses, err := argocdagent.GetPrincipalPKIStatus(r.Client, cr)
+	if err != nil {
+		return err
+	}
+	argocdStatus.AgentPKI = statuses
+	return nil
+}
+
+// reconcileStatusAgents will report the connections of the agents to the Argo CD Agent principal of the given ArgoCD,
+// and whether some of them are degraded.
+func (r *ReconcileArgoCD) reconcileStatusAgents(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	if r.AgentConnectionReader == nil || !isPrincipalEnabled(cr) {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionAgentsDegraded)
+		return nil
+	}
+
+	status, condition, err := argocdagent.GetAgentsStatus(context.TODO(), r.Client, cr, r.AgentConnectionReader)
+	if err != nil {
+		return err
+	}
+	argocdStatus.Agents = status
+	argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+	return nil
+}
+
+// reconcileStatusAgentNamespacePolicies will ensure that the AgentNamespacePoliciesValid condition reflects the result
+// of validating the namespace policies of the principal of the given ArgoCD, and report the namespaces each agent is
+// allowed to use.
+func (r *ReconcileArgoCD) reconcileStatusAgentNamespacePolicies(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	if !isPrincipalEnabled(cr) || cr.Spec.ArgoCDAgent.Principal.Namespace == nil || len(cr.Spec.ArgoCDAgent.Principal.Namespace.Policies) == 0 {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionAgentNamespacePoliciesValid)
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:   argoproj.ArgoCDConditionAgentNamespacePoliciesValid,
+		Status: metav1.ConditionTrue,
+		Reason: argoproj.ArgoCDConditionReasonSuccess,
+	}
+	if err := argocdagent.ValidatePrincipalNamespacePolicies(cr); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonInvalidAgentNamespacePolicies
+		condition.Message = err.Error()
+		argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+		return nil
+	}
+	argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+
+	statuses, err := argocdagent.GetAgentNamespacesStatus(context.TODO(), r.Client, cr)
+	if err != nil {
+		return err
+	}
+	argocdStatus.AgentNamespaces = statuses
+	return nil
+}
+
+// reconcileStatusAgentModeMigration will report the progress of the last migration of the Argo CD Agent of the given
+// ArgoCD between the managed and autonomous modes.
+func (r *ReconcileArgoCD) reconcileStatusAgentModeMigration(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	status, err := agent.GetAgentModeMigrationStatus(r.Client, string(argoproj.AgentComponentTypeAgent), cr)
+	if err != nil {
+		return err
+	}
+	argocdStatus.AgentModeMigration = status
+	return nil
+}
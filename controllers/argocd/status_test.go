@@ -2,18 +2,25 @@ package argocd
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 
 	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -271,3 +278,224 @@ func TestReconcileArgoCD_reconcileStatusApplicationSetController(t *testing.T) {
 	assert.NoError(t, r.reconcileStatusApplicationSetController(a, &argocdStatus))
 	assert.Equal(t, "Pending", argocdStatus.ApplicationSetController)
 }
+
+func TestReconcileArgoCD_reconcileStatusRedis_external(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Redis.External = &argoproj.ArgoCDRedisExternalSpec{
+			Sentinel: &argoproj.ArgoCDRedisSentinelSpec{Addresses: []string{"sentinel-0:26379", "sentinel-1:26379"}},
+		}
+	})
+	r := &ReconcileArgoCD{}
+
+	reachable := map[string]bool{}
+	dial := dialRedisExternal
+	dialRedisExternal = func(ctx context.Context, address string) error {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok, "the probe is bounded")
+		if reachable[address] {
+			return nil
+		}
+		return fmt.Errorf("dial tcp %s: connection refused", address)
+	}
+	t.Cleanup(func() { dialRedisExternal = dial })
+
+	status := &argoproj.ArgoCDStatus{}
+	assert.NoError(t, r.reconcileStatusRedis(a, status))
+	assert.Equal(t, "Failed", status.Redis)
+
+	reachable["sentinel-1:26379"] = true
+	assert.NoError(t, r.reconcileStatusRedis(a, status))
+	assert.Equal(t, "Running", status.Redis)
+}
+
+func TestReconcileArgoCD_reconcileStatusRedisConfig(t *testing.T) {
+	sentinel := &argoproj.ArgoCDRedisExternalSpec{
+		Sentinel: &argoproj.ArgoCDRedisSentinelSpec{Addresses: []string{"sentinel-0:26379"}},
+	}
+	tests := []struct {
+		name     string
+		spec     argoproj.ArgoCDSpec
+		expected string
+	}{
+		{
+			name: "sentinel",
+			spec: argoproj.ArgoCDSpec{Redis: argoproj.ArgoCDRedisSpec{External: sentinel}},
+		},
+		{
+			name: "remote and external",
+			spec: argoproj.ArgoCDSpec{Redis: argoproj.ArgoCDRedisSpec{
+				Remote:   ptr.To("redis:6379"),
+				External: &argoproj.ArgoCDRedisExternalSpec{Address: "redis.example.com:6379"},
+			}},
+			expected: "spec.redis.remote and spec.redis.external cannot both be set",
+		},
+		{
+			name: "sentinel with the principal",
+			spec: argoproj.ArgoCDSpec{
+				Redis:       argoproj.ArgoCDRedisSpec{External: sentinel},
+				ArgoCDAgent: &argoproj.ArgoCDAgentSpec{Principal: &argoproj.PrincipalSpec{Enabled: ptr.To(true)}},
+			},
+			expected: "the principal does not support Redis Sentinel, set spec.argoCDAgent.principal.redis.serverAddress to the address of the Redis server",
+		},
+		{
+			name: "sentinel with the principal and a server address",
+			spec: argoproj.ArgoCDSpec{
+				Redis: argoproj.ArgoCDRedisSpec{External: sentinel},
+				ArgoCDAgent: &argoproj.ArgoCDAgentSpec{Principal: &argoproj.PrincipalSpec{
+					Enabled: ptr.To(true),
+					Redis:   &argoproj.PrincipalRedisSpec{ServerAddress: "redis.example.com:6379"},
+				}},
+			},
+		},
+		{
+			name: "sentinel with the agent",
+			spec: argoproj.ArgoCDSpec{
+				Redis:       argoproj.ArgoCDRedisSpec{External: sentinel},
+				ArgoCDAgent: &argoproj.ArgoCDAgentSpec{Agent: &argoproj.AgentSpec{Enabled: ptr.To(true)}},
+			},
+			expected: "the agent does not support Redis Sentinel, set spec.argoCDAgent.agent.redis.serverAddress to the address of the Redis server",
+		},
+		{
+			name: "tls with the principal",
+			spec: argoproj.ArgoCDSpec{
+				Redis: argoproj.ArgoCDRedisSpec{External: &argoproj.ArgoCDRedisExternalSpec{
+					Address: "redis.example.com:6380",
+					TLS:     &argoproj.ArgoCDRedisExternalTLSSpec{},
+				}},
+				ArgoCDAgent: &argoproj.ArgoCDAgentSpec{Principal: &argoproj.PrincipalSpec{Enabled: ptr.To(true)}},
+			},
+			expected: "the principal does not support TLS to Redis, remove spec.redis.external.tls or disable the principal",
+		},
+		{
+			name: "tls with the agent",
+			spec: argoproj.ArgoCDSpec{
+				Redis: argoproj.ArgoCDRedisSpec{External: &argoproj.ArgoCDRedisExternalSpec{
+					Address: "redis.example.com:6380",
+					TLS:     &argoproj.ArgoCDRedisExternalTLSSpec{},
+				}},
+				ArgoCDAgent: &argoproj.ArgoCDAgentSpec{Agent: &argoproj.AgentSpec{Enabled: ptr.To(true)}},
+			},
+			expected: "the agent does not support TLS to Redis, remove spec.redis.external.tls or disable the agent",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec = test.spec
+			})
+			status := &argoproj.ArgoCDStatus{}
+			require.NoError(t, (&ReconcileArgoCD{}).reconcileStatusRedisConfig(a, status))
+			require.Len(t, status.Conditions, 1)
+			if test.expected == "" {
+				assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+				return
+			}
+			assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+			assert.Equal(t, argoproj.ArgoCDConditionReasonInvalidRedisConfig, status.Conditions[0].Reason)
+			assert.Equal(t, test.expected, status.Conditions[0].Message)
+		})
+	}
+}
+
+type fakeAgentConnectionReader map[string]argocdagent.AgentConnection
+
+func (f fakeAgentConnectionReader) GetAgentConnections(ctx context.Context, cr *argoproj.ArgoCD) (*argocdagent.AgentConnections, error) {
+	connections := &argocdagent.AgentConnections{Agents: f}
+	for _, connection := range f {
+		if connection.Connected {
+			connections.Connected++
+		}
+	}
+	return connections, nil
+}
+
+func TestReconcileArgoCD_reconcileStatusAgents(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ArgoCDAgent = &argoproj.ArgoCDAgentSpec{Principal: &argoproj.PrincipalSpec{Enabled: ptr.To(true)}}
+		a.Status.Conditions = []metav1.Condition{{Type: argoproj.ArgoCDConditionAgentsDegraded, Status: metav1.ConditionTrue}}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+	for _, name := range []string{"workload-1", "workload-2"} {
+		assert.NoError(t, cl.Create(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-" + name,
+			Namespace: a.Namespace,
+			Labels: map[string]string{
+				common.ArgoCDSecretTypeLabel: "cluster",
+				common.ArgoCDAgentNameLabel:  name,
+			},
+		}}))
+	}
+
+	// The connections are not reported without a reader.
+	status := &argoproj.ArgoCDStatus{}
+	assert.NoError(t, r.reconcileStatusAgents(a, status))
+	assert.Nil(t, status.Agents)
+	assert.Empty(t, status.Conditions)
+	assert.Empty(t, a.Status.Conditions)
+
+	lastSeen := time.Now().Truncate(time.Second)
+	r.AgentConnectionReader = fakeAgentConnectionReader{
+		"workload-1": {Connected: true, Mode: "managed", Version: "v0.8.1", LastSeen: &lastSeen},
+	}
+	status = &argoproj.ArgoCDStatus{}
+	assert.NoError(t, r.reconcileStatusAgents(a, status))
+	assert.NotNil(t, status.Agents)
+	assert.Equal(t, int32(1), status.Agents.Connected)
+	assert.Equal(t, int32(2), status.Agents.Total)
+	assert.Equal(t, []argoproj.ArgoCDAgentStatus{
+		{Name: "workload-1", Connected: true, Mode: "managed", Version: "v0.8.1", LastSeen: &metav1.Time{Time: lastSeen}},
+		{Name: "workload-2"},
+	}, status.Agents.Items)
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, argoproj.ArgoCDConditionAgentsDegraded, status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonAgentsDisconnected, status.Conditions[0].Reason)
+}
+
+func TestReconcileArgoCD_reconcileStatusAgentNamespacePolicies(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ArgoCDAgent = &argoproj.ArgoCDAgentSpec{Principal: &argoproj.PrincipalSpec{
+			Enabled: ptr.To(true),
+			Namespace: &argoproj.PrincipalNamespaceSpec{Policies: []argoproj.PrincipalNamespacePolicy{
+				{Name: "teams", Agents: []string{"team-*"}, CreateNamespace: true},
+			}},
+		}}
+	})
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "cluster-team-a",
+		Namespace: a.Namespace,
+		Labels: map[string]string{
+			common.ArgoCDSecretTypeLabel: "cluster",
+			common.ArgoCDAgentNameLabel:  "team-a",
+		},
+	}}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a, secret}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	status := &argoproj.ArgoCDStatus{}
+	assert.NoError(t, r.reconcileStatusAgentNamespacePolicies(a, status))
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+	assert.Equal(t, []argoproj.ArgoCDAgentNamespacesStatus{
+		{Name: "team-a", Policies: []string{"teams"}, CreateNamespace: "team-a"},
+	}, status.AgentNamespaces)
+
+	a.Spec.ArgoCDAgent.Principal.Namespace.Policies[0].Agents = []string{"Team_A"}
+	status = &argoproj.ArgoCDStatus{}
+	assert.NoError(t, r.reconcileStatusAgentNamespacePolicies(a, status))
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonInvalidAgentNamespacePolicies, status.Conditions[0].Reason)
+	assert.Nil(t, status.AgentNamespaces)
+
+	a.Spec.ArgoCDAgent.Principal.Namespace = nil
+	a.Status.Conditions = []metav1.Condition{{Type: argoproj.ArgoCDConditionAgentNamespacePoliciesValid, Status: metav1.ConditionFalse}}
+	status = &argoproj.ArgoCDStatus{}
+	assert.NoError(t, r.reconcileStatusAgentNamespacePolicies(a, status))
+	assert.Empty(t, status.Conditions)
+	assert.Empty(t, a.Status.Conditions)
+}
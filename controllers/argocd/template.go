@@ -0,0 +1,245 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// getArgoCDTemplates returns the ArgoCDTemplates applied to the given ArgoCD, in order of precedence. The referenced
+// ArgoCDTemplate comes first, followed by the ArgoCDTemplates selecting the ArgoCD by highest priority and then name.
+// A missing referenced ArgoCDTemplate and the ArgoCDTemplates with an invalid selector are skipped, the reasons they
+// were skipped are returned so that they can be reported.
+func getArgoCDTemplates(ctx context.Context, c client.Client, cr *argoproj.ArgoCD) ([]argoproj.ArgoCDTemplate, []string, error) {
+	var templates []argoproj.ArgoCDTemplate
+	var skipped []string
+
+	if cr.Spec.Template != "" {
+		referenced := argoproj.ArgoCDTemplate{}
+		if err := c.Get(ctx, types.NamespacedName{Name: cr.Spec.Template}, &referenced); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, nil, fmt.Errorf("failed to get ArgoCDTemplate %s referenced by ArgoCD %s/%s: %w", cr.Spec.Template, cr.Namespace, cr.Name, err)
+			}
+			skipped = append(skipped, fmt.Sprintf("ArgoCDTemplate %s referenced by spec.template not found", cr.Spec.Template))
+		} else {
+			templates = append(templates, referenced)
+		}
+	}
+
+	templateList := &argoproj.ArgoCDTemplateList{}
+	if err := c.List(ctx, templateList); err != nil {
+		return nil, nil, fmt.Errorf("failed to list ArgoCDTemplates: %w", err)
+	}
+
+	var selected []argoproj.ArgoCDTemplate
+	for _, template := range templateList.Items {
+		if template.Name == cr.Spec.Template {
+			continue
+		}
+		matches, err := argoCDTemplateSelects(&template, cr)
+		if err != nil {
+			skipped = append(skipped, err.Error())
+			continue
+		}
+		if matches {
+			selected = append(selected, template)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Spec.Priority != selected[j].Spec.Priority {
+			return selected[i].Spec.Priority > selected[j].Spec.Priority
+		}
+		return selected[i].Name < selected[j].Name
+	})
+
+	return append(templates, selected...), skipped, nil
+}
+
+// argoCDTemplateSelects returns whether the selector of the given ArgoCDTemplate matches the labels of the ArgoCD.
+func argoCDTemplateSelects(template *argoproj.ArgoCDTemplate, cr *argoproj.ArgoCD) (bool, error) {
+	if template.Spec.Selector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(template.Spec.Selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector in ArgoCDTemplate %s: %w", template.Name, err)
+	}
+	return selector.Matches(labels.Set(cr.Labels)), nil
+}
+
+// applyArgoCDTemplates will set the defaults of the ArgoCDTemplates of the given ArgoCD on the fields that are not set
+// explicitly. Only the in-memory ArgoCD is modified, the defaults are never persisted to the cluster.
+func (r *ReconcileArgoCD) applyArgoCDTemplates(ctx context.Context, cr *argoproj.ArgoCD) error {
+	templates, _, err := getArgoCDTemplates(ctx, r.Client, cr)
+	if err != nil {
+		return err
+	}
+
+	// The templates are applied in order of precedence, each of them only sets the fields left unset by the previous.
+	for i := range templates {
+		applyArgoCDTemplateDefaults(cr, &templates[i].Spec.Defaults)
+	}
+	return nil
+}
+
+// applyArgoCDTemplateDefaults will set the given defaults on the fields of the ArgoCD that are not set.
+func applyArgoCDTemplateDefaults(cr *argoproj.ArgoCD, defaults *argoproj.ArgoCDTemplateDefaults) {
+	if cr.Spec.Image == "" {
+		cr.Spec.Image = defaults.Image
+	}
+	if cr.Spec.Version == "" {
+		cr.Spec.Version = defaults.Version
+	}
+	if cr.Spec.NodePlacement == nil && defaults.NodePlacement != nil {
+		cr.Spec.NodePlacement = defaults.NodePlacement.DeepCopy()
+	}
+	if cr.Spec.Banner == nil && defaults.Banner != nil {
+		cr.Spec.Banner = defaults.Banner.DeepCopy()
+	}
+
+	if resources := defaults.Resources; resources != nil {
+		if cr.Spec.Controller.Resources == nil && resources.Controller != nil {
+			cr.Spec.Controller.Resources = resources.Controller.DeepCopy()
+		}
+		if cr.Spec.Server.Resources == nil && resources.Server != nil {
+			cr.Spec.Server.Resources = resources.Server.DeepCopy()
+		}
+		if cr.Spec.Repo.Resources == nil && resources.Repo != nil {
+			cr.Spec.Repo.Resources = resources.Repo.DeepCopy()
+		}
+		if cr.Spec.Redis.Resources == nil && resources.Redis != nil {
+			cr.Spec.Redis.Resources = resources.Redis.DeepCopy()
+		}
+		// The ApplicationSet controller is only defaulted, never enabled, by a template.
+		if cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.Resources == nil && resources.ApplicationSet != nil {
+			cr.Spec.ApplicationSet.Resources = resources.ApplicationSet.DeepCopy()
+		}
+	}
+
+	if rbac := defaults.RBAC; rbac != nil {
+		if cr.Spec.RBAC.DefaultPolicy == nil && rbac.DefaultPolicy != nil {
+			defaultPolicy := *rbac.DefaultPolicy
+			cr.Spec.RBAC.DefaultPolicy = &defaultPolicy
+		}
+		if cr.Spec.RBAC.Policy == nil && rbac.Policy != nil {
+			policy := *rbac.Policy
+			cr.Spec.RBAC.Policy = &policy
+		}
+		if cr.Spec.RBAC.Scopes == nil && rbac.Scopes != nil {
+			scopes := *rbac.Scopes
+			cr.Spec.RBAC.Scopes = &scopes
+		}
+		if cr.Spec.RBAC.PolicyMatcherMode == nil && rbac.PolicyMatcherMode != nil {
+			policyMatcherMode := *rbac.PolicyMatcherMode
+			cr.Spec.RBAC.PolicyMatcherMode = &policyMatcherMode
+		}
+		if len(cr.Spec.RBAC.Roles) == 0 && len(rbac.Roles) > 0 {
+			cr.Spec.RBAC.Roles = rbac.DeepCopy().Roles
+		}
+		if len(cr.Spec.RBAC.PolicyOverlays) == 0 && len(rbac.PolicyOverlays) > 0 {
+			cr.Spec.RBAC.PolicyOverlays = rbac.DeepCopy().PolicyOverlays
+		}
+	}
+
+	for key, value := range defaults.ExtraConfig {
+		if _, ok := cr.Spec.ExtraConfig[key]; ok {
+			continue
+		}
+		if cr.Spec.ExtraConfig == nil {
+			cr.Spec.ExtraConfig = map[string]string{}
+		}
+		cr.Spec.ExtraConfig[key] = value
+	}
+}
+
+// reconcileStatusTemplates will ensure that the ArgoCDTemplates applied to the ArgoCD, and the ones that were skipped,
+// are reported in the ArgoCD status.
+func (r *ReconcileArgoCD) reconcileStatusTemplates(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	if err := r.reconcileArgoCDTemplateStatuses(context.TODO()); err != nil {
+		return err
+	}
+
+	templates, skipped, err := getArgoCDTemplates(context.TODO(), r.Client, cr)
+	if err != nil {
+		argocdStatus.Templates = nil
+		return err
+	}
+
+	var names []string
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+	argocdStatus.Templates = names
+
+	if len(skipped) == 0 {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionTemplatesValid)
+		return nil
+	}
+	argocdStatus.Conditions = append(argocdStatus.Conditions, metav1.Condition{
+		Type:    argoproj.ArgoCDConditionTemplatesValid,
+		Status:  metav1.ConditionFalse,
+		Reason:  argoproj.ArgoCDConditionReasonInvalidTemplates,
+		Message: strings.Join(skipped, "; "),
+	})
+	return nil
+}
+
+// reconcileArgoCDTemplateStatuses will ensure that the validity of the selector of each ArgoCDTemplate is reported in
+// the ArgoCDTemplate status. The ArgoCDTemplates are only updated when their status changes.
+func (r *ReconcileArgoCD) reconcileArgoCDTemplateStatuses(ctx context.Context) error {
+	templateList := &argoproj.ArgoCDTemplateList{}
+	if err := r.List(ctx, templateList); err != nil {
+		return fmt.Errorf("failed to list ArgoCDTemplates: %w", err)
+	}
+
+	for i := range templateList.Items {
+		template := &templateList.Items[i]
+		status := template.Status.DeepCopy()
+
+		condition := metav1.Condition{
+			Type:               argoproj.ArgoCDTemplateConditionSelectorValid,
+			Status:             metav1.ConditionTrue,
+			Reason:             argoproj.ArgoCDConditionReasonSuccess,
+			ObservedGeneration: template.Generation,
+		}
+		if _, err := argoCDTemplateSelects(template, &argoproj.ArgoCD{}); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = argoproj.ArgoCDTemplateConditionReasonInvalidSelector
+			condition.Message = err.Error()
+		}
+		meta.SetStatusCondition(&status.Conditions, condition)
+
+		if !reflect.DeepEqual(template.Status, *status) {
+			template.Status = *status
+			if err := r.Client.Status().Update(ctx, template); err != nil {
+				return fmt.Errorf("failed to update the status of ArgoCDTemplate %s: %w", template.Name, err)
+			}
+		}
+	}
+	return nil
+}
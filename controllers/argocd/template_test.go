@@ -0,0 +1,192 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestArgoCDTemplate(name string, priority int32, selector *metav1.LabelSelector, defaults argoproj.ArgoCDTemplateDefaults) *argoproj.ArgoCDTemplate {
+	return &argoproj.ArgoCDTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: argoproj.ArgoCDTemplateSpec{
+			Selector: selector,
+			Priority: priority,
+			Defaults: defaults,
+		},
+	}
+}
+
+func TestReconcileArgoCD_applyArgoCDTemplates(t *testing.T) {
+	teamSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}
+	referenced := makeTestArgoCDTemplate("referenced", 0, nil, argoproj.ArgoCDTemplateDefaults{
+		Version: "v3.0.0",
+	})
+	high := makeTestArgoCDTemplate("high", 10, teamSelector, argoproj.ArgoCDTemplateDefaults{
+		Version: "v2.0.0",
+		RBAC:    &argoproj.ArgoCDRBACSpec{DefaultPolicy: ptr.To("role:readonly")},
+		ExtraConfig: map[string]string{
+			"admin.enabled": "false",
+		},
+	})
+	low := makeTestArgoCDTemplate("low", 1, &metav1.LabelSelector{}, argoproj.ArgoCDTemplateDefaults{
+		Image:  "registry.example.com/argocd",
+		RBAC:   &argoproj.ArgoCDRBACSpec{DefaultPolicy: ptr.To("role:admin"), Scopes: ptr.To("[groups]")},
+		Banner: &argoproj.Banner{Content: "managed by the platform team"},
+		Resources: &argoproj.ArgoCDTemplateResources{
+			Controller: &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}},
+			Server:     &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+		},
+		ExtraConfig: map[string]string{
+			"admin.enabled":           "true",
+			"users.anonymous.enabled": "false",
+		},
+	})
+	unselected := makeTestArgoCDTemplate("unselected", 100, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "other"}}, argoproj.ArgoCDTemplateDefaults{
+		Image: "registry.example.com/other",
+	})
+
+	serverResources := &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")}}
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Labels = map[string]string{"team": "platform"}
+		a.Spec.Template = "referenced"
+		a.Spec.Server.Resources = serverResources
+		a.Spec.ExtraConfig = map[string]string{"users.anonymous.enabled": "true"}
+	})
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a, referenced, high, low, unselected}, []client.Object{a, referenced, high, low, unselected}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	templates, skipped, err := getArgoCDTemplates(context.TODO(), r.Client, a)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	var names []string
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+	assert.Equal(t, []string{"referenced", "high", "low"}, names)
+
+	require.NoError(t, r.applyArgoCDTemplates(context.TODO(), a))
+
+	// The referenced template takes precedence over the selecting ones, which are ordered by priority.
+	assert.Equal(t, "v3.0.0", a.Spec.Version)
+	assert.Equal(t, "registry.example.com/argocd", a.Spec.Image)
+	assert.Equal(t, "role:readonly", *a.Spec.RBAC.DefaultPolicy)
+	assert.Equal(t, "[groups]", *a.Spec.RBAC.Scopes)
+	assert.Equal(t, "managed by the platform team", a.Spec.Banner.Content)
+	assert.Equal(t, "false", a.Spec.ExtraConfig["admin.enabled"])
+
+	// The fields set in the ArgoCD are never overridden.
+	assert.Equal(t, serverResources, a.Spec.Server.Resources)
+	assert.Equal(t, "true", a.Spec.ExtraConfig["users.anonymous.enabled"])
+	require.NotNil(t, a.Spec.Controller.Resources)
+	assert.True(t, resource.MustParse("4Gi").Equal(a.Spec.Controller.Resources.Limits[corev1.ResourceMemory]))
+
+	// The template defaults are never persisted.
+	persisted := &argoproj.ArgoCD{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(a), persisted))
+	assert.Empty(t, persisted.Spec.Image)
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusTemplates(a, status))
+	assert.Equal(t, []string{"referenced", "high", "low"}, status.Templates)
+}
+
+func TestReconcileArgoCD_applyArgoCDTemplates_invalidTemplates(t *testing.T) {
+	invalid := makeTestArgoCDTemplate("invalid", 10, &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}},
+	}, argoproj.ArgoCDTemplateDefaults{
+		Image: "registry.example.com/invalid",
+	})
+	valid := makeTestArgoCDTemplate("valid", 0, &metav1.LabelSelector{}, argoproj.ArgoCDTemplateDefaults{
+		Image: "registry.example.com/argocd",
+	})
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Template = "missing"
+	})
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a, invalid, valid}, []client.Object{a, invalid, valid}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	// The missing and invalid templates are skipped, the valid ones are still applied.
+	require.NoError(t, r.applyArgoCDTemplates(context.TODO(), a))
+	assert.Equal(t, "registry.example.com/argocd", a.Spec.Image)
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusTemplates(a, status))
+	assert.Equal(t, []string{"valid"}, status.Templates)
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, argoproj.ArgoCDConditionTemplatesValid, status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonInvalidTemplates, status.Conditions[0].Reason)
+	assert.Contains(t, status.Conditions[0].Message, "ArgoCDTemplate missing referenced by spec.template not found")
+	assert.Contains(t, status.Conditions[0].Message, "invalid selector in ArgoCDTemplate invalid")
+
+	// The validity of the selectors is reported on the templates.
+	for name, expected := range map[string]metav1.ConditionStatus{"invalid": metav1.ConditionFalse, "valid": metav1.ConditionTrue} {
+		template := &argoproj.ArgoCDTemplate{}
+		require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: name}, template))
+		condition := meta.FindStatusCondition(template.Status.Conditions, argoproj.ArgoCDTemplateConditionSelectorValid)
+		require.NotNil(t, condition, name)
+		assert.Equal(t, expected, condition.Status, name)
+	}
+
+	// The condition is dropped once the templates are fixed.
+	a.Spec.Template = "valid"
+	require.NoError(t, cl.Delete(context.TODO(), invalid))
+	status = &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusTemplates(a, status))
+	assert.Equal(t, []string{"valid"}, status.Templates)
+	assert.Empty(t, status.Conditions)
+}
+
+func TestReconcileArgoCD_argoCDTemplateMapper(t *testing.T) {
+	template := makeTestArgoCDTemplate("platform", 0, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}, argoproj.ArgoCDTemplateDefaults{})
+	referencing := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "referencing"
+		a.Spec.Template = "platform"
+	})
+	selected := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "selected"
+		a.Labels = map[string]string{"team": "platform"}
+	})
+	previouslyApplied := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "previously-applied"
+		a.Status.Templates = []string{"platform"}
+	})
+	skipping := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "skipping"
+		a.Status.Conditions = []metav1.Condition{{
+			Type:   argoproj.ArgoCDConditionTemplatesValid,
+			Status: metav1.ConditionFalse,
+			Reason: argoproj.ArgoCDConditionReasonInvalidTemplates,
+		}}
+	})
+	unrelated := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "unrelated"
+	})
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{template, referencing, selected, previouslyApplied, skipping, unrelated}, []client.Object{}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	var names []string
+	for _, request := range r.argoCDTemplateMapper(context.TODO(), template) {
+		names = append(names, request.Name)
+	}
+	assert.ElementsMatch(t, []string{"referencing", "selected", "previously-applied", "skipping"}, names)
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/go-logr/logr"
 
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -109,6 +110,8 @@ func makeTestReconcilerClient(sch *runtime.Scheme, resObjs, subresObjs []client.
 
 func makeTestReconcilerScheme(sOpts ...SchemeOpt) *runtime.Scheme {
 	s := scheme.Scheme
+	// AppProjects are reconciled for every instance, so the type is always needed.
+	_ = argocdv1alpha1.AddToScheme(s)
 	for _, opt := range sOpts {
 		_ = opt(s)
 	}
@@ -22,14 +22,15 @@ import (
 	"encoding/base64"
 	"fmt"
 	"hash"
-	"os"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/util/glob"
 	"github.com/distribution/reference"
 	"github.com/go-logr/logr"
@@ -56,6 +57,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
@@ -178,13 +180,15 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 		"--operation-processors", fmt.Sprint(getArgoServerOperationProcessors(cr)),
 	}
 
-	if cr.Spec.Redis.IsEnabled() {
+	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.External != nil {
+		cmd = append(cmd, argoutil.GetRedisExternalArgs(cr)...)
+	} else if cr.Spec.Redis.IsEnabled() {
 		cmd = append(cmd, "--redis", argoutil.GetRedisServerAddress(cr))
 	} else {
 		log.Info("Redis is Disabled. Skipping adding Redis configuration to Application Controller.")
 	}
 
-	if useTLSForRedis {
+	if useTLSForRedis && cr.Spec.Redis.External == nil {
 		cmd = append(cmd, "--redis-use-tls")
 		if isRedisTLSVerificationDisabled(cr) {
 			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
@@ -238,7 +242,7 @@ func GetImageAndTag(envVar, containerSpecImage, containerSpecVersion, commonSpec
 	tag := common.ArgoCDDefaultArgoVersion
 
 	// Check if environment variable is set
-	envVal := os.Getenv(envVar)
+	envVal := argoutil.GetOperatorSetting(envVar)
 
 	// If no spec values are provided and env var is set, use env var as-is
 	if envVal != "" && containerSpecImage == "" && commonSpecImage == "" &&
@@ -447,6 +451,16 @@ func InspectCluster() error {
 		imageUpdaterAPIFound = false
 	}
 
+	if err := verifyKEDAAPI(); err != nil {
+		log.Error(err, "could not verify KEDA API, disabling feature")
+		kedaAPIFound = false
+	}
+
+	if err := verifyVPAAPI(); err != nil {
+		log.Error(err, "could not verify VerticalPodAutoscaler API, disabling feature")
+		vpaAPIFound = false
+	}
+
 	if err := verifyVersionAPI(); err != nil {
 		return err
 	}
@@ -560,6 +574,16 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD, argocdStatus *
 		return err
 	}
 
+	log.Info("reconciling repositories")
+	if err := r.reconcileRepositories(cr, argocdStatus); err != nil {
+		return err
+	}
+
+	log.Info("reconciling projects")
+	if err := r.reconcileAppProjects(cr); err != nil {
+		return err
+	}
+
 	useTLSForRedis := r.redisShouldUseTLS(cr)
 
 	log.Info("reconciling config maps")
@@ -592,6 +616,11 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD, argocdStatus *
 		return err
 	}
 
+	log.Info("reconciling vertical pod autoscalers")
+	if err := r.reconcileVerticalPodAutoscalers(cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling ingresses")
 	if err := r.reconcileIngresses(cr); err != nil {
 		return err
@@ -693,6 +722,11 @@ func (r *ReconcileArgoCD) deleteClusterResources(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	// The Roles of the principal in the namespaces of its namespace policies are not owned by the ArgoCD.
+	if err := argocdagent.DeletePrincipalNamespaceRoles(r.Client, cr); err != nil {
+		return fmt.Errorf("failed to delete the principal namespace roles for %s: %w", cr.Name, err)
+	}
+
 	return nil
 }
 
@@ -771,7 +805,7 @@ func removeString(slice []string, s string) []string {
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper, nmMapper, systemCATrustMapper handler.MapFunc) *builder.Builder {
+func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper, nmMapper, systemCATrustMapper, referencedConfigMapMapper, dexConnectorSecretMapper, repositoryCredentialsSecretMapper, operatorConfigMapper, argoCDTemplateMapper handler.MapFunc) *builder.Builder {
 
 	// Add new predicate to delete Notifications Resources. The predicate watches the Argo CD CR for changes to the `.spec.Notifications.Enabled`
 	// field. When a change is detected that results in notifications being disabled, we trigger deletion of notifications resources
@@ -818,6 +852,7 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 	bldr.Owns(&v1.Role{})
 	bldr.Owns(&v1.RoleBinding{})
 	bldr.Owns(&v1alpha1.NotificationsConfiguration{})
+	bldr.Owns(&argocdv1alpha1.AppProject{})
 
 	bldr.Watches(&argoproj.NamespaceManagement{}, handler.EnqueueRequestsFromMapFunc(nmMapper), builder.WithPredicates(r.namespaceManagementFilterPredicate()))
 
@@ -829,6 +864,21 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 		Name: common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName,
 	}}, handler.EnqueueRequestsFromMapFunc(applicationSetGitlabSCMTLSConfigMapMapper))
 
+	// Watch for ConfigMaps holding RBAC policy overlays or the OIDC root CA
+	bldr.Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(referencedConfigMapMapper))
+
+	// Watch for Secrets referenced by typed Dex connectors
+	bldr.Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(dexConnectorSecretMapper))
+
+	// Watch for Secrets referenced as credentials by declared repositories and credential templates
+	bldr.Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(repositoryCredentialsSecretMapper))
+
+	// Watch for changes to the operator-wide settings
+	bldr.Watches(&argoproj.ArgoCDOperatorConfig{}, handler.EnqueueRequestsFromMapFunc(operatorConfigMapper))
+
+	// Watch for changes to the ArgoCDTemplates so that the instances they apply to are reconciled
+	bldr.Watches(&argoproj.ArgoCDTemplate{}, handler.EnqueueRequestsFromMapFunc(argoCDTemplateMapper))
+
 	// Watch for secrets of type TLS that might be created by external processes
 	bldr.Watches(&corev1.Secret{Type: corev1.SecretTypeTLS}, handler.EnqueueRequestsFromMapFunc(tlsSecretMapper))
 
@@ -858,6 +908,20 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 		bldr.Owns(&monitoringv1.ServiceMonitor{})
 	}
 
+	if IsKEDAAPIAvailable() {
+		// Watch KEDA ScaledObject sub-resources owned by ArgoCD instances.
+		scaledObject := &unstructured.Unstructured{}
+		scaledObject.SetGroupVersionKind(scaledObjectGVK)
+		bldr.Owns(scaledObject)
+	}
+
+	if IsVPAAPIAvailable() {
+		// Watch VerticalPodAutoscaler sub-resources owned by ArgoCD instances.
+		vpa := &unstructured.Unstructured{}
+		vpa.SetGroupVersionKind(vpaGVK)
+		bldr.Owns(vpa)
+	}
+
 	systemCATrustHandler := handler.EnqueueRequestsFromMapFunc(systemCATrustMapper)
 	bldr.Watches(&corev1.Secret{}, systemCATrustHandler)
 	bldr.Watches(&corev1.ConfigMap{}, systemCATrustHandler)
@@ -1411,6 +1475,13 @@ func addKubernetesData(source map[string]string, live map[string]string) {
 func updateStatusAndConditionsOfArgoCD(ctx context.Context, condition metav1.Condition, cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus, k8sClient client.Client, log logr.Logger) error {
 	changed, newConditions := insertOrUpdateConditionsInSlice(condition, cr.Status.Conditions)
 
+	// Conditions set while reconciling the status (e.g. RBACPolicyValid) are merged as well
+	for _, c := range slices.Clone(argocdStatus.Conditions) {
+		var conditionChanged bool
+		conditionChanged, newConditions = insertOrUpdateConditionsInSlice(c, newConditions)
+		changed = changed || conditionChanged
+	}
+
 	// get the latest version of argocd instance
 	if err := k8sClient.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -1420,11 +1491,18 @@ func updateStatusAndConditionsOfArgoCD(ctx context.Context, condition metav1.Con
 		return err
 	}
 
+	// Conditions may also have been removed
+	if len(cr.Status.Conditions) != len(newConditions) {
+		changed = true
+	}
+
 	// Determine if any of the values in the status field changed
 	crStatusClone := cr.Status.DeepCopy()
 	crStatusClone.Conditions = nil // Remove Conditions since we don't want to compare it, we only want to compare the other fields
+	argocdStatusClone := argocdStatus.DeepCopy()
+	argocdStatusClone.Conditions = nil
 
-	if !reflect.DeepEqual(crStatusClone, argocdStatus) {
+	if !reflect.DeepEqual(crStatusClone, argocdStatusClone) {
 		changed = true
 	}
 
@@ -1606,11 +1684,16 @@ func appendUniqueArgs(cmd []string, extraArgs []string) []string {
 	return result
 }
 
+// isPrincipalEnabled returns whether the Argo CD Agent principal is enabled for the given ArgoCD.
+func isPrincipalEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.ArgoCDAgent != nil && cr.Spec.ArgoCDAgent.Principal != nil && cr.Spec.ArgoCDAgent.Principal.IsEnabled()
+}
+
 // reconcileArgoCDAgent will reconcile all ArgoCD Agent resources.
 func (r *ReconcileArgoCD) reconcileArgoCDAgent(cr *argoproj.ArgoCD) error {
 	log.Info("reconciling ArgoCD Agent resources")
 
-	principalEnabled := cr.Spec.ArgoCDAgent != nil && cr.Spec.ArgoCDAgent.Principal != nil && cr.Spec.ArgoCDAgent.Principal.IsEnabled()
+	principalEnabled := isPrincipalEnabled(cr)
 	agentEnabled := cr.Spec.ArgoCDAgent != nil && cr.Spec.ArgoCDAgent.Agent != nil && cr.Spec.ArgoCDAgent.Agent.IsEnabled()
 
 	if principalEnabled && agentEnabled {
@@ -1647,6 +1730,11 @@ func (r *ReconcileArgoCD) reconcileArgoCDAgent(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	log.Info("reconciling ArgoCD Agent's Principal namespace roles")
+	if err := argocdagent.ReconcilePrincipalNamespaceRoles(r.Client, compName, sa, cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling ArgoCD Agent's Principal service")
 	if err := argocdagent.ReconcilePrincipalService(r.Client, compName, cr, r.Scheme); err != nil {
 		return err
@@ -1689,6 +1777,16 @@ func (r *ReconcileArgoCD) reconcileArgoCDAgent(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	log.Info("reconciling ArgoCD Agent's Principal PKI")
+	r.agentPKIRequeueAfter.Delete(cr.Namespace)
+	requeueAfter, err := argocdagent.ReconcilePrincipalPKI(r.Client, compName, cr, r.Scheme)
+	if err != nil {
+		return err
+	}
+	if requeueAfter > 0 {
+		r.agentPKIRequeueAfter.Store(cr.Namespace, requeueAfter)
+	}
+
 	log.Info("reconciling ArgoCD Agent's Principal deployment")
 	if err := argocdagent.ReconcilePrincipalDeployment(r.Client, compName, sa.Name, cr, r.Scheme, r.CentralTLSConfigProfile); err != nil {
 		return err
@@ -1740,6 +1838,11 @@ func (r *ReconcileArgoCD) reconcileArgoCDAgent(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	log.Info("reconciling ArgoCD Agent's Agent mode migration")
+	if err := agent.ReconcileAgentModeMigration(r.Client, agentCompName, cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling ArgoCD Agent's Agent deployment")
 	if err := agent.ReconcileAgentDeployment(r.Client, agentCompName, agentSa.Name, cr, r.Scheme); err != nil {
 		return err
@@ -0,0 +1,361 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+var vpaAPIFound = false
+
+// vpaGVK is the GroupVersionKind of the VerticalPodAutoscaler.
+var vpaGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+// IsVPAAPIAvailable returns true if the VerticalPodAutoscaler API is present.
+func IsVPAAPIAvailable() bool {
+	return vpaAPIFound
+}
+
+// verifyVPAAPI will verify that the VerticalPodAutoscaler API is present.
+func verifyVPAAPI() error {
+	found, err := argoutil.VerifyAPI(vpaGVK.Group, vpaGVK.Version)
+	if err != nil {
+		return err
+	}
+	vpaAPIFound = found
+	return nil
+}
+
+// vpaComponent describes a component that can be right-sized by a VerticalPodAutoscaler.
+type vpaComponent struct {
+	// name is the name of the component, used as the VerticalPodAutoscaler suffix and in the ArgoCD status.
+	name string
+	// enabled is whether the component is managed by the operator.
+	enabled bool
+	// vpa is the VerticalPodAutoscaler options of the component.
+	vpa *argoproj.ArgoCDVPASpec
+	// kind is the kind of the workload running the component.
+	kind string
+	// targetName is the name of the workload running the component.
+	targetName string
+	// resourceScaled is whether the replicas of the component are scaled on their CPU or memory usage.
+	resourceScaled bool
+}
+
+// getVPAComponents returns the components of the given ArgoCD that can be right-sized by a VerticalPodAutoscaler.
+func getVPAComponents(cr *argoproj.ArgoCD) []vpaComponent {
+	components := []vpaComponent{
+		{
+			name:       "application-controller",
+			enabled:    cr.Spec.Controller.IsEnabled(),
+			vpa:        cr.Spec.Controller.VPA,
+			kind:       "StatefulSet",
+			targetName: applicationControllerResourceName(cr),
+		},
+		{
+			name:       "server",
+			enabled:    cr.Spec.Server.IsEnabled(),
+			vpa:        cr.Spec.Server.VPA,
+			kind:       "Deployment",
+			targetName: nameWithSuffix("server", cr),
+			// The HorizontalPodAutoscaler of the server always targets its CPU utilization.
+			resourceScaled: cr.Spec.Server.Autoscale.Enabled,
+		},
+		{
+			name:           "repo-server",
+			enabled:        cr.Spec.Repo.IsEnabled() && !cr.Spec.Repo.IsRemote(),
+			vpa:            cr.Spec.Repo.VPA,
+			kind:           "Deployment",
+			targetName:     nameWithSuffix("repo-server", cr),
+			resourceScaled: isRepoServerAutoscaled(cr) && isAutoscaledOnResources(cr.Spec.Repo.Autoscale),
+		},
+	}
+
+	appSet := vpaComponent{
+		name:       "applicationset-controller",
+		kind:       "Deployment",
+		targetName: nameWithSuffix("applicationset-controller", cr),
+	}
+	if cr.Spec.ApplicationSet != nil {
+		appSet.enabled = cr.Spec.ApplicationSet.IsEnabled()
+		appSet.vpa = cr.Spec.ApplicationSet.VPA
+		appSet.resourceScaled = isApplicationSetAutoscaled(cr) && isAutoscaledOnResources(cr.Spec.ApplicationSet.Autoscale)
+	}
+	components = append(components, appSet)
+
+	redis := vpaComponent{
+		name:       "redis",
+		enabled:    cr.Spec.Redis.IsEnabled() && !cr.Spec.Redis.IsRemote(),
+		vpa:        cr.Spec.Redis.VPA,
+		kind:       "Deployment",
+		targetName: nameWithSuffix("redis", cr),
+	}
+	if cr.Spec.HA.Enabled {
+		redis.kind = "StatefulSet"
+		redis.targetName = redisHAStatefulSetName(cr)
+	}
+	return append(components, redis)
+}
+
+// isAutoscaledOnResources returns whether the given autoscale options scale a component on its CPU or memory usage,
+// i.e. with a HorizontalPodAutoscaler or with a KEDA cpu or memory trigger.
+func isAutoscaledOnResources(autoscale *argoproj.ArgoCDAutoscaleSpec) bool {
+	if autoscale.KEDA == nil || !IsKEDAAPIAvailable() {
+		return true
+	}
+	for _, t := range autoscale.KEDA.Triggers {
+		if t.Type == "cpu" || t.Type == "memory" {
+			return true
+		}
+	}
+	return false
+}
+
+// validateVerticalPodAutoscaler checks that the VerticalPodAutoscaler of the given component does not apply its
+// recommendations to pods whose replicas are scaled on the same resources, as both autoscalers would then react to
+// the changes of each other.
+func validateVerticalPodAutoscaler(component vpaComponent) error {
+	if !component.resourceScaled {
+		return nil
+	}
+	switch component.vpa.UpdateMode {
+	case "Recreate", "InPlaceOrRecreate", "Auto":
+		return fmt.Errorf("%s: the VerticalPodAutoscaler in %s mode cannot be combined with autoscaling on CPU or memory, use the Off or Initial mode", component.name, component.vpa.UpdateMode)
+	}
+	return nil
+}
+
+func newVerticalPodAutoscalerWithSuffix(suffix string, cr *argoproj.ArgoCD) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(vpaGVK)
+	obj.SetName(nameWithSuffix(suffix, cr))
+	obj.SetNamespace(cr.Namespace)
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = obj.GetName()
+	obj.SetLabels(lbls)
+	return obj
+}
+
+// getVerticalPodAutoscalerSpec returns the VerticalPodAutoscaler spec right-sizing the given component.
+func getVerticalPodAutoscalerSpec(component vpaComponent) (map[string]interface{}, error) {
+	type containerPolicy struct {
+		ContainerName string              `json:"containerName"`
+		MinAllowed    corev1.ResourceList `json:"minAllowed,omitempty"`
+		MaxAllowed    corev1.ResourceList `json:"maxAllowed,omitempty"`
+	}
+	type resourcePolicy struct {
+		ContainerPolicies []containerPolicy `json:"containerPolicies"`
+	}
+	spec := struct {
+		TargetRef      map[string]string `json:"targetRef"`
+		UpdatePolicy   map[string]string `json:"updatePolicy"`
+		ResourcePolicy *resourcePolicy   `json:"resourcePolicy,omitempty"`
+	}{
+		TargetRef: map[string]string{
+			"apiVersion": "apps/v1",
+			"kind":       component.kind,
+			"name":       component.targetName,
+		},
+		UpdatePolicy: map[string]string{"updateMode": "Off"},
+	}
+	if component.vpa.UpdateMode != "" {
+		spec.UpdatePolicy["updateMode"] = component.vpa.UpdateMode
+	}
+	if len(component.vpa.MinAllowed) > 0 || len(component.vpa.MaxAllowed) > 0 {
+		spec.ResourcePolicy = &resourcePolicy{
+			ContainerPolicies: []containerPolicy{{
+				ContainerName: "*",
+				MinAllowed:    component.vpa.MinAllowed,
+				MaxAllowed:    component.vpa.MaxAllowed,
+			}},
+		}
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+}
+
+// reconcileVerticalPodAutoscalers will ensure that a VerticalPodAutoscaler is present for every component requesting
+// one, and that the VerticalPodAutoscalers of the other components are removed.
+func (r *ReconcileArgoCD) reconcileVerticalPodAutoscalers(cr *argoproj.ArgoCD) error {
+	if !IsVPAAPIAvailable() {
+		for _, component := range getVPAComponents(cr) {
+			if component.enabled && component.vpa.IsEnabled() {
+				log.Info("VerticalPodAutoscaler API is not available, skipping", "component", component.name)
+			}
+		}
+		return nil
+	}
+
+	for _, component := range getVPAComponents(cr) {
+		if err := r.reconcileVerticalPodAutoscaler(cr, component); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileVerticalPodAutoscaler will ensure that the VerticalPodAutoscaler of the given component is present when
+// requested, and reconcile any detected changes.
+func (r *ReconcileArgoCD) reconcileVerticalPodAutoscaler(cr *argoproj.ArgoCD, component vpaComponent) error {
+	vpa := newVerticalPodAutoscalerWithSuffix(component.name, cr)
+	exists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, vpa.GetName(), vpa)
+	if err != nil {
+		return err
+	}
+
+	if !component.enabled || !component.vpa.IsEnabled() {
+		if exists {
+			argoutil.LogResourceDeletion(log, vpa, "vertical pod autoscaling is disabled")
+			return r.Delete(context.TODO(), vpa)
+		}
+		return nil
+	}
+
+	// A conflicting VerticalPodAutoscaler is removed, the VerticalPodAutoscalersValid condition reports the error.
+	if err := validateVerticalPodAutoscaler(component); err != nil {
+		if exists {
+			argoutil.LogResourceDeletion(log, vpa, err.Error())
+			return r.Delete(context.TODO(), vpa)
+		}
+		return nil
+	}
+
+	spec, err := getVerticalPodAutoscalerSpec(component)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		if !reflect.DeepEqual(vpa.Object["spec"], spec) {
+			vpa.Object["spec"] = spec
+			argoutil.LogResourceUpdate(log, vpa, "due to differences from ArgoCD CR")
+			return r.Update(context.TODO(), vpa)
+		}
+		return nil
+	}
+
+	vpa.Object["spec"] = spec
+	if err := controllerutil.SetControllerReference(cr, vpa, r.Scheme); err != nil {
+		return err
+	}
+	argoutil.LogResourceCreation(log, vpa)
+	return r.Create(context.TODO(), vpa)
+}
+
+// reconcileStatusResourceRecommendations will ensure that the resources recommended by the VerticalPodAutoscalers
+// are reported in the ArgoCD status.
+func (r *ReconcileArgoCD) reconcileStatusResourceRecommendations(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	argocdStatus.ResourceRecommendations = nil
+	if !IsVPAAPIAvailable() {
+		return nil
+	}
+
+	for _, component := range getVPAComponents(cr) {
+		if !component.enabled || !component.vpa.IsEnabled() {
+			continue
+		}
+
+		vpa := newVerticalPodAutoscalerWithSuffix(component.name, cr)
+		exists, err := argoutil.IsObjectFound(r.Client, cr.Namespace, vpa.GetName(), vpa)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+
+		recommendation, err := getVerticalPodAutoscalerRecommendation(vpa)
+		if err != nil {
+			log.Error(err, "failed to read the VerticalPodAutoscaler recommendation", "name", vpa.GetName())
+			continue
+		}
+		if len(recommendation) == 0 {
+			continue
+		}
+		argocdStatus.ResourceRecommendations = append(argocdStatus.ResourceRecommendations, argoproj.ArgoCDResourceRecommendation{
+			Component:  component.name,
+			Containers: recommendation,
+		})
+	}
+	return nil
+}
+
+// getVerticalPodAutoscalerRecommendation returns the container recommendations of the given VerticalPodAutoscaler.
+func getVerticalPodAutoscalerRecommendation(vpa *unstructured.Unstructured) ([]argoproj.ArgoCDContainerResourceRecommendation, error) {
+	containers, found, err := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+	if err != nil || !found {
+		return nil, err
+	}
+
+	var recommendations []argoproj.ArgoCDContainerResourceRecommendation
+	for _, container := range containers {
+		m, ok := container.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		recommendation := argoproj.ArgoCDContainerResourceRecommendation{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &recommendation); err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	return recommendations, nil
+}
+
+// reconcileStatusVerticalPodAutoscalers will ensure that the VerticalPodAutoscalersValid condition reports the
+// VerticalPodAutoscalers conflicting with the autoscaling of the replicas of their component.
+func (r *ReconcileArgoCD) reconcileStatusVerticalPodAutoscalers(cr *argoproj.ArgoCD, argocdStatus *argoproj.ArgoCDStatus) error {
+	var errs []error
+	requested := false
+	for _, component := range getVPAComponents(cr) {
+		if !component.enabled || !component.vpa.IsEnabled() {
+			continue
+		}
+		requested = true
+		if err := validateVerticalPodAutoscaler(component); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if !requested {
+		removeCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionVerticalPodAutoscalersValid)
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:   argoproj.ArgoCDConditionVerticalPodAutoscalersValid,
+		Status: metav1.ConditionTrue,
+		Reason: argoproj.ArgoCDConditionReasonSuccess,
+	}
+	if err := errors.Join(errs...); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonConflictingAutoscalers
+		condition.Message = err.Error()
+	}
+	argocdStatus.Conditions = append(argocdStatus.Conditions, condition)
+	return nil
+}
@@ -0,0 +1,137 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileVerticalPodAutoscalers(t *testing.T) {
+	vpaAPIFound = true
+	defer func() {
+		vpaAPIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.VPA = &argoproj.ArgoCDVPASpec{
+			Enabled:    true,
+			UpdateMode: "Off",
+			MaxAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		}
+		a.Spec.Repo.VPA = &argoproj.ArgoCDVPASpec{Enabled: true, UpdateMode: "Recreate"}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileVerticalPodAutoscalers(a))
+
+	vpa := newVerticalPodAutoscalerWithSuffix("application-controller", a)
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: testNamespace}, vpa))
+	assert.Equal(t, map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "StatefulSet",
+			"name":       "argocd-application-controller",
+		},
+		"updatePolicy": map[string]interface{}{"updateMode": "Off"},
+		"resourcePolicy": map[string]interface{}{
+			"containerPolicies": []interface{}{map[string]interface{}{
+				"containerName": "*",
+				"maxAllowed":    map[string]interface{}{"memory": "4Gi"},
+			}},
+		},
+	}, vpa.Object["spec"])
+
+	repoVPA := newVerticalPodAutoscalerWithSuffix("repo-server", a)
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, repoVPA))
+	assert.Equal(t, map[string]interface{}{"updateMode": "Recreate"}, repoVPA.Object["spec"].(map[string]interface{})["updatePolicy"])
+
+	serverVPA := newVerticalPodAutoscalerWithSuffix("server", a)
+	err := r.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, serverVPA)
+	assert.True(t, errors.IsNotFound(err))
+
+	// The recommendations of the VerticalPodAutoscaler are reported in the status.
+	vpa.Object["status"] = map[string]interface{}{
+		"recommendation": map[string]interface{}{
+			"containerRecommendations": []interface{}{map[string]interface{}{
+				"containerName": "argocd-application-controller",
+				"target":        map[string]interface{}{"cpu": "250m", "memory": "1Gi"},
+				"lowerBound":    map[string]interface{}{"cpu": "100m", "memory": "512Mi"},
+				"upperBound":    map[string]interface{}{"cpu": "1", "memory": "2Gi"},
+			}},
+		},
+	}
+	require.NoError(t, r.Update(context.TODO(), vpa))
+
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusResourceRecommendations(a, status))
+	require.Len(t, status.ResourceRecommendations, 1)
+	assert.Equal(t, "application-controller", status.ResourceRecommendations[0].Component)
+	require.Len(t, status.ResourceRecommendations[0].Containers, 1)
+	container := status.ResourceRecommendations[0].Containers[0]
+	assert.Equal(t, "argocd-application-controller", container.ContainerName)
+	assert.True(t, resource.MustParse("1Gi").Equal(container.Target[corev1.ResourceMemory]))
+	assert.True(t, resource.MustParse("100m").Equal(container.LowerBound[corev1.ResourceCPU]))
+	assert.True(t, resource.MustParse("2Gi").Equal(container.UpperBound[corev1.ResourceMemory]))
+
+	// The VerticalPodAutoscaler is removed once disabled.
+	a.Spec.Controller.VPA.Enabled = false
+	require.NoError(t, r.reconcileVerticalPodAutoscalers(a))
+	err = r.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: testNamespace}, newVerticalPodAutoscalerWithSuffix("application-controller", a))
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileVerticalPodAutoscalers_conflictingAutoscalers(t *testing.T) {
+	vpaAPIFound = true
+	defer func() {
+		vpaAPIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.VPA = &argoproj.ArgoCDVPASpec{Enabled: true, UpdateMode: "Auto"}
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch, testclient.NewSimpleClientset())
+
+	require.NoError(t, r.reconcileVerticalPodAutoscalers(a))
+	repoVPA := newVerticalPodAutoscalerWithSuffix("repo-server", a)
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, repoVPA))
+	status := &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusVerticalPodAutoscalers(a, status))
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+
+	// Scaling the repo server on its CPU utilization removes the VerticalPodAutoscaler applying its recommendations.
+	a.Spec.Repo.Autoscale = &argoproj.ArgoCDAutoscaleSpec{Enabled: true}
+	require.NoError(t, r.reconcileVerticalPodAutoscalers(a))
+	err := r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, repoVPA)
+	assert.True(t, errors.IsNotFound(err))
+	status = &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusVerticalPodAutoscalers(a, status))
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonConflictingAutoscalers, status.Conditions[0].Reason)
+	assert.Equal(t, "repo-server: the VerticalPodAutoscaler in Auto mode cannot be combined with autoscaling on CPU or memory, use the Off or Initial mode", status.Conditions[0].Message)
+
+	// Recommendations only are not applied to the pods.
+	a.Spec.Repo.VPA.UpdateMode = "Off"
+	require.NoError(t, r.reconcileVerticalPodAutoscalers(a))
+	require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, repoVPA))
+	status = &argoproj.ArgoCDStatus{}
+	require.NoError(t, r.reconcileStatusVerticalPodAutoscalers(a, status))
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+}
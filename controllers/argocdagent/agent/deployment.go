@@ -17,7 +17,6 @@ package agent
 import (
 	"context"
 	"fmt"
-	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -60,8 +59,17 @@ func ReconcileAgentDeployment(client client.Client, compName, saName string, cr
 			return nil
 		}
 
+		if err := ValidateAgentRedis(cr); err != nil {
+			log.Info("skipping the agent deployment update as the Redis configuration is invalid", "error", err.Error())
+			return nil
+		}
+
 		deployment, changed := updateDeploymentIfChanged(compName, saName, cr, deployment)
-		if changed {
+		revisionChanged, err := argoutil.SetRedisAuthRevision(client, cr, &deployment.Spec.Template)
+		if err != nil {
+			return fmt.Errorf("failed to get redis auth revision for agent deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
+		}
+		if changed || revisionChanged {
 			argoutil.LogResourceUpdate(log, deployment, "agent deployment is being updated")
 			if err := client.Update(context.TODO(), deployment); err != nil {
 				return fmt.Errorf("failed to update agent deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
@@ -75,12 +83,20 @@ func ReconcileAgentDeployment(client client.Client, compName, saName string, cr
 		return nil
 	}
 
+	if err := ValidateAgentRedis(cr); err != nil {
+		log.Info("skipping the agent deployment creation as the Redis configuration is invalid", "error", err.Error())
+		return nil
+	}
+
 	if err := controllerutil.SetControllerReference(cr, deployment, scheme); err != nil {
 		return fmt.Errorf("failed to set ArgoCD CR %s as owner for service %s: %w", cr.Name, deployment.Name, err)
 	}
 
 	argoutil.LogResourceCreation(log, deployment)
 	deployment.Spec = buildAgentSpec(compName, saName, cr)
+	if _, err := argoutil.SetRedisAuthRevision(client, cr, &deployment.Spec.Template); err != nil {
+		return fmt.Errorf("failed to get redis auth revision for agent deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
+	}
 	if err := client.Create(context.TODO(), deployment); err != nil {
 		return fmt.Errorf("failed to create agent deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
 	}
@@ -176,7 +192,7 @@ func buildAgentImage(cr *argoproj.ArgoCD) string {
 	}
 
 	// Value specified in the environment take precedence over the default
-	if env := os.Getenv(EnvArgoCDAgentImage); env != "" {
+	if env := argoutil.GetOperatorSetting(EnvArgoCDAgentImage); env != "" {
 		return env
 	}
 
@@ -372,7 +388,7 @@ func buildAgentContainerEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
 		},
 	}
 
-	env = append(env, argoutil.GetRedisAuthEnv()...)
+	env = append(env, argoutil.GetRedisAuthEnv(cr)...)
 
 	// Add custom environment variables if specified in the CR
 	if hasAgent(cr) && cr.Spec.ArgoCDAgent.Agent.Env != nil {
@@ -399,7 +415,7 @@ const (
 	EnvArgoCDAgentEnableCompression   = "ARGOCD_AGENT_ENABLE_COMPRESSION"
 	EnvArgoCDAgentKeepAliveInterval   = "ARGOCD_AGENT_KEEP_ALIVE_PING_INTERVAL"
 	EnvArgoCDAgentEnableResourceProxy = "ARGOCD_AGENT_ENABLE_RESOURCE_PROXY"
-	EnvArgoCDAgentImage               = "ARGOCD_AGENT_IMAGE"
+	EnvArgoCDAgentImage               = common.ArgoCDAgentImageEnvName
 	EnvArgoCDAgentRedisAddress        = "REDIS_ADDR"
 	EnvArgoCDAgentDestinationBasedMap = "ARGOCD_AGENT_DESTINATION_BASED_MAPPING"
 	EnvArgoCDAgentCreateNamespace     = "ARGOCD_AGENT_CREATE_NAMESPACE"
@@ -529,10 +545,32 @@ func getAgentKeepAliveInterval(cr *argoproj.ArgoCD) string {
 }
 
 // Redis Configuration
+
+// ValidateAgentRedis checks that the agent can connect to the Redis of the given ArgoCD. The agent does not discover
+// Redis through Sentinel, so an external Redis only reachable through Sentinel requires an explicit
+// spec.argoCDAgent.agent.redis.serverAddress. The agent is not configured with the TLS settings of the external Redis
+// either, so an external Redis requiring TLS is rejected.
+func ValidateAgentRedis(cr *argoproj.ArgoCD) error {
+	external := cr.Spec.Redis.External
+	if external == nil {
+		return nil
+	}
+	if external.TLS != nil {
+		return fmt.Errorf("the agent does not support TLS to Redis, remove spec.redis.external.tls or disable the agent")
+	}
+	if external.Address != "" || (hasRedis(cr) && cr.Spec.ArgoCDAgent.Agent.Redis.ServerAddress != "") {
+		return nil
+	}
+	return fmt.Errorf("the agent does not support Redis Sentinel, set spec.argoCDAgent.agent.redis.serverAddress to the address of the Redis server")
+}
+
 func getAgentRedisAddress(cr *argoproj.ArgoCD) string {
 	if hasRedis(cr) && cr.Spec.ArgoCDAgent.Agent.Redis.ServerAddress != "" {
 		return cr.Spec.ArgoCDAgent.Agent.Redis.ServerAddress
 	}
+	if cr.Spec.Redis.External != nil && cr.Spec.Redis.External.Address != "" {
+		return cr.Spec.Redis.External.Address
+	}
 	return fmt.Sprintf("%s-%s:%d", cr.Name, "redis", common.ArgoCDDefaultRedisPort)
 }
 
@@ -0,0 +1,173 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/util/glob"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiError "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// ReconcileAgentModeMigration migrates the agent of the given ArgoCD to the mode of its spec when it runs in another
+// mode. The Applications of the agent are labelled with the new mode, which owns them from then on, and the agent
+// Deployment is annotated with the mode it is migrated from, before ReconcileAgentDeployment restarts the agent in the
+// new mode. The Applications are labelled until the agent Deployment rolled out.
+func ReconcileAgentModeMigration(client client.Client, compName string, cr *argoproj.ArgoCD) error {
+	if !hasAgent(cr) || !cr.Spec.ArgoCDAgent.Agent.IsEnabled() {
+		return nil
+	}
+
+	deployment := buildDeployment(compName, cr)
+	if err := argoutil.FetchObject(client, cr.Namespace, deployment.Name, deployment); err != nil {
+		if apiError.IsNotFound(err) {
+			// A new agent starts in the mode of its spec.
+			return nil
+		}
+		return fmt.Errorf("failed to get existing agent deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
+	}
+
+	running := getDeploymentAgentMode(deployment)
+	desired := argoproj.AgentMode(getAgentMode(cr))
+	if running != desired {
+		if deployment.Annotations == nil {
+			deployment.Annotations = map[string]string{}
+		}
+		deployment.Annotations[common.ArgoCDAgentModeMigrationFromAnnotation] = string(running)
+		deployment.Annotations[common.ArgoCDAgentModeMigrationStartedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		argoutil.LogResourceUpdate(log, deployment, "agent is being migrated from", string(running), "to", string(desired), "mode")
+		if err := client.Update(context.TODO(), deployment); err != nil {
+			return fmt.Errorf("failed to update agent deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
+		}
+	} else if _, ok := deployment.Annotations[common.ArgoCDAgentModeMigrationFromAnnotation]; !ok || isDeploymentRolledOut(deployment) {
+		return nil
+	}
+
+	namespaces, err := getAgentApplicationNamespaces(client, cr)
+	if err != nil {
+		return err
+	}
+	if _, err := argocdagent.MigrateAgentApplications(context.TODO(), client, namespaces, desired); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetAgentModeMigrationStatus returns the progress of the last migration of the agent of the given ArgoCD between
+// modes, or nil if the agent was never migrated. A completed migration is reported as it was when it completed.
+func GetAgentModeMigrationStatus(client client.Client, compName string, cr *argoproj.ArgoCD) (*argoproj.AgentModeMigrationStatus, error) {
+	if !hasAgent(cr) || !cr.Spec.ArgoCDAgent.Agent.IsEnabled() {
+		return nil, nil
+	}
+
+	deployment := buildDeployment(compName, cr)
+	if err := argoutil.FetchObject(client, cr.Namespace, deployment.Name, deployment); err != nil {
+		if apiError.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	from, ok := deployment.Annotations[common.ArgoCDAgentModeMigrationFromAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	status := &argoproj.AgentModeMigrationStatus{
+		From: argoproj.AgentMode(from),
+		To:   argoproj.AgentMode(getAgentMode(cr)),
+	}
+	if started, err := time.Parse(time.RFC3339, deployment.Annotations[common.ArgoCDAgentModeMigrationStartedAnnotation]); err == nil {
+		status.StartTime = &metav1.Time{Time: started}
+	}
+
+	if previous := cr.Status.AgentModeMigration; previous != nil && previous.Phase == argoproj.AgentModeMigrationPhaseCompleted &&
+		previous.From == status.From && previous.To == status.To && previous.StartTime.Equal(status.StartTime) {
+		return previous.DeepCopy(), nil
+	}
+
+	namespaces, err := getAgentApplicationNamespaces(client, cr)
+	if err != nil {
+		return nil, err
+	}
+	if status.Applications, status.MigratedApplications, err = argocdagent.CountAgentApplications(context.TODO(), client, namespaces, status.To); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case getDeploymentAgentMode(deployment) != status.To || status.MigratedApplications < status.Applications:
+		status.Phase = argoproj.AgentModeMigrationPhaseMigratingApplications
+		status.Message = fmt.Sprintf("%d of %d applications are labelled with the %s mode", status.MigratedApplications, status.Applications, status.To)
+	case !isDeploymentRolledOut(deployment):
+		status.Phase = argoproj.AgentModeMigrationPhaseWaitingForAgent
+		status.Message = fmt.Sprintf("waiting for the agent deployment %s to roll out in %s mode", deployment.Name, status.To)
+	default:
+		status.Phase = argoproj.AgentModeMigrationPhaseCompleted
+		status.CompletionTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+	}
+	return status, nil
+}
+
+// getDeploymentAgentMode returns the mode the given agent Deployment runs the agent in.
+func getDeploymentAgentMode(deployment *appsv1.Deployment) argoproj.AgentMode {
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == EnvArgoCDAgentMode && env.Value != "" {
+				return argoproj.AgentMode(env.Value)
+			}
+		}
+	}
+	return argoproj.AgentModeManaged
+}
+
+// isDeploymentRolledOut returns whether all the replicas of the given Deployment run its latest spec and are available.
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+// getAgentApplicationNamespaces returns the namespaces holding the Applications of the agent of the given ArgoCD: the
+// namespace of the ArgoCD and the existing namespaces matching the allowed namespaces of the agent.
+func getAgentApplicationNamespaces(c client.Client, cr *argoproj.ArgoCD) ([]string, error) {
+	namespaces := []string{cr.Namespace}
+	allowed := cr.Spec.ArgoCDAgent.Agent.AllowedNamespaces
+	if len(allowed) == 0 {
+		return namespaces, nil
+	}
+
+	list := &corev1.NamespaceList{}
+	if err := c.List(context.TODO(), list); err != nil {
+		return nil, fmt.Errorf("failed to list the allowed namespaces of the agent: %w", err)
+	}
+	for _, ns := range list.Items {
+		if ns.Name != cr.Namespace && glob.MatchStringInList(allowed, ns.Name, glob.GLOB) {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	return namespaces, nil
+}
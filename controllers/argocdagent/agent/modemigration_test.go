@@ -0,0 +1,160 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func withAgentMode(mode argoproj.AgentMode) argoCDOpt {
+	return func(a *argoproj.ArgoCD) {
+		if a.Spec.ArgoCDAgent == nil {
+			a.Spec.ArgoCDAgent = &argoproj.ArgoCDAgentSpec{}
+		}
+		if a.Spec.ArgoCDAgent.Agent == nil {
+			a.Spec.ArgoCDAgent.Agent = &argoproj.AgentSpec{}
+		}
+		if a.Spec.ArgoCDAgent.Agent.Client == nil {
+			a.Spec.ArgoCDAgent.Agent.Client = &argoproj.AgentClientSpec{}
+		}
+		a.Spec.ArgoCDAgent.Agent.Client.Mode = string(mode)
+	}
+}
+
+func makeTestApplication(name, namespace string) *argocdv1alpha1.Application {
+	return &argocdv1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func TestReconcileAgentModeMigration(t *testing.T) {
+	cr := makeTestArgoCD(withAgentEnabled(true), withAgentMode(argoproj.AgentModeAutonomous), withAgentAllowedNamespaces([]string{"apps-*"}))
+	deployment := makeTestDeployment(makeTestArgoCD(withAgentEnabled(true), withAgentMode(argoproj.AgentModeManaged)))
+
+	sch := makeTestReconcilerScheme()
+	require.NoError(t, argocdv1alpha1.AddToScheme(sch))
+	cl := makeTestReconcilerClient(sch, []client.Object{
+		cr,
+		deployment,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		makeTestApplication("guestbook", testNamespace),
+		makeTestApplication("payments", "apps-a"),
+		makeTestApplication("unrelated", "other"),
+	})
+
+	require.NoError(t, ReconcileAgentModeMigration(cl, testAgentCompName, cr))
+
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment))
+	assert.Equal(t, string(argoproj.AgentModeManaged), deployment.Annotations[common.ArgoCDAgentModeMigrationFromAnnotation])
+	assert.NotEmpty(t, deployment.Annotations[common.ArgoCDAgentModeMigrationStartedAnnotation])
+
+	for _, key := range []client.ObjectKey{{Namespace: testNamespace, Name: "guestbook"}, {Namespace: "apps-a", Name: "payments"}} {
+		app := &argocdv1alpha1.Application{}
+		require.NoError(t, cl.Get(context.TODO(), key, app))
+		assert.Equal(t, string(argoproj.AgentModeAutonomous), app.Labels[common.ArgoCDAgentModeLabel], key.String())
+	}
+	app := &argocdv1alpha1.Application{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Namespace: "other", Name: "unrelated"}, app))
+	assert.NotContains(t, app.Labels, common.ArgoCDAgentModeLabel)
+}
+
+func TestReconcileAgentModeMigration_SameMode(t *testing.T) {
+	cr := makeTestArgoCD(withAgentEnabled(true), withAgentMode(argoproj.AgentModeManaged))
+	deployment := makeTestDeployment(cr)
+
+	sch := makeTestReconcilerScheme()
+	require.NoError(t, argocdv1alpha1.AddToScheme(sch))
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, deployment, makeTestApplication("guestbook", testNamespace)})
+
+	require.NoError(t, ReconcileAgentModeMigration(cl, testAgentCompName, cr))
+
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment))
+	assert.NotContains(t, deployment.Annotations, common.ArgoCDAgentModeMigrationFromAnnotation)
+	app := &argocdv1alpha1.Application{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: "guestbook"}, app))
+	assert.NotContains(t, app.Labels, common.ArgoCDAgentModeLabel)
+
+	status, err := GetAgentModeMigrationStatus(cl, testAgentCompName, cr)
+	require.NoError(t, err)
+	assert.Nil(t, status)
+}
+
+func TestGetAgentModeMigrationStatus(t *testing.T) {
+	cr := makeTestArgoCD(withAgentEnabled(true), withAgentMode(argoproj.AgentModeAutonomous))
+	deployment := makeTestDeployment(makeTestArgoCD(withAgentEnabled(true), withAgentMode(argoproj.AgentModeManaged)))
+
+	sch := makeTestReconcilerScheme()
+	require.NoError(t, argocdv1alpha1.AddToScheme(sch))
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, deployment, makeTestApplication("guestbook", testNamespace)})
+	require.NoError(t, ReconcileAgentModeMigration(cl, testAgentCompName, cr))
+
+	// The agent Deployment still runs the managed mode.
+	status, err := GetAgentModeMigrationStatus(cl, testAgentCompName, cr)
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, argoproj.AgentModeManaged, status.From)
+	assert.Equal(t, argoproj.AgentModeAutonomous, status.To)
+	assert.Equal(t, argoproj.AgentModeMigrationPhaseMigratingApplications, status.Phase)
+	assert.Equal(t, int32(1), status.Applications)
+	assert.Equal(t, int32(1), status.MigratedApplications)
+	assert.NotNil(t, status.StartTime)
+
+	// The agent Deployment is updated to the autonomous mode but not rolled out yet.
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment))
+	deployment.Spec = buildAgentSpec(testAgentCompName, deployment.Name, cr)
+	require.NoError(t, cl.Update(context.TODO(), deployment))
+
+	status, err = GetAgentModeMigrationStatus(cl, testAgentCompName, cr)
+	require.NoError(t, err)
+	assert.Equal(t, argoproj.AgentModeMigrationPhaseWaitingForAgent, status.Phase)
+	assert.Nil(t, status.CompletionTime)
+
+	// The agent Deployment rolled out.
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment))
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: deployment.Generation,
+		UpdatedReplicas:    1,
+		AvailableReplicas:  1,
+	}
+	require.NoError(t, cl.Status().Update(context.TODO(), deployment))
+
+	status, err = GetAgentModeMigrationStatus(cl, testAgentCompName, cr)
+	require.NoError(t, err)
+	assert.Equal(t, argoproj.AgentModeMigrationPhaseCompleted, status.Phase)
+	require.NotNil(t, status.CompletionTime)
+
+	// A completed migration is reported as it was when it completed.
+	cr.Status.AgentModeMigration = status
+	require.NoError(t, cl.Create(context.TODO(), makeTestApplication("payments", testNamespace)))
+	frozen, err := GetAgentModeMigrationStatus(cl, testAgentCompName, cr)
+	require.NoError(t, err)
+	assert.Equal(t, status, frozen)
+}
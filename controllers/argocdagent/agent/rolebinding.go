@@ -17,7 +17,6 @@ package agent
 import (
 	"context"
 	"fmt"
-	"os"
 	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
@@ -216,5 +215,5 @@ func buildClusterRoleBinding(compName string, cr *argoproj.ArgoCD) *v1.ClusterRo
 }
 
 func getCustomRoleName() string {
-	return os.Getenv(common.ArgoCDAgentClusterRoleEnvName)
+	return argoutil.GetOperatorSetting(common.ArgoCDAgentClusterRoleEnvName)
 }
@@ -0,0 +1,584 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdagent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// PrincipalMetricAgentsConnected is the gauge of the principal reporting the agents connected to it. Each replica
+	// of the principal only reports the agents connected to itself, either as the number of connected agents, or as
+	// one series per agent labelled with the name of the agent.
+	PrincipalMetricAgentsConnected = "agent_connected_with_principal"
+
+	// PrincipalMetricLabelAgentName is the label of PrincipalMetricAgentsConnected holding the name of the agent, when
+	// the principal reports the agents one by one. The series of an agent is 1 when it is connected and 0 otherwise.
+	PrincipalMetricLabelAgentName = "agent_name"
+	// PrincipalMetricLabelMode is the label of PrincipalMetricAgentsConnected holding the mode of the agent.
+	PrincipalMetricLabelMode = "mode"
+	// PrincipalMetricLabelVersion is the label of PrincipalMetricAgentsConnected holding the version of the agent.
+	PrincipalMetricLabelVersion = "version"
+
+	// PrincipalMetricsPollInterval is how often the metrics of the principals are scraped by default.
+	PrincipalMetricsPollInterval = time.Minute
+
+	principalMetricsScrapeTimeout = 10 * time.Second
+
+	// principalMetricsEventBuffer is the number of events buffered for each subscriber of the PrincipalMetricsPoller.
+	principalMetricsEventBuffer = 1024
+)
+
+// AgentConnections is the state of the connections of the agents to a principal.
+type AgentConnections struct {
+	// Connected is the number of agents connected to the principal.
+	Connected int32
+	// Agents is the connection of each agent, by name. It is nil when the principal only reports the number of
+	// connected agents.
+	Agents map[string]AgentConnection
+}
+
+// AgentConnection is the state of the connection of an agent to the principal.
+type AgentConnection struct {
+	// Connected is whether the agent is connected to the principal.
+	Connected bool
+	// Mode is the mode the agent connected with.
+	Mode string
+	// Version is the version of the agent.
+	Version string
+	// LastSeen is when the agent was last found connected to the principal, if ever.
+	LastSeen *time.Time
+}
+
+// AgentConnectionReader returns the state of the connections of the agents to the principal of an ArgoCD, or nil if the
+// principal does not report it. The returned AgentConnections must not be modified.
+type AgentConnectionReader interface {
+	GetAgentConnections(ctx context.Context, cr *argoproj.ArgoCD) (*AgentConnections, error)
+}
+
+// PrincipalMetricsReader reads the state of the connections of the agents from the metrics endpoint of the principal.
+type PrincipalMetricsReader struct {
+	// Client is used to find the replicas of the principal, which are scraped one by one since each of them only
+	// reports the agents connected to it. It should read from the API server, as a cached client would watch all the
+	// Pods of the cluster. The metrics Service of the principal is scraped when nil.
+	Client client.Reader
+	// HTTPClient is the client used to scrape the metrics endpoint. A client with a timeout is used when nil.
+	HTTPClient *http.Client
+}
+
+// blank assignment to verify that PrincipalMetricsReader implements AgentConnectionReader
+var _ AgentConnectionReader = &PrincipalMetricsReader{}
+
+// GetAgentConnections scrapes the replicas of the principal of the given ArgoCD, or its metrics Service, and merges the
+// agents connected to them. The connected agents are last seen now.
+func (m *PrincipalMetricsReader) GetAgentConnections(ctx context.Context, cr *argoproj.ArgoCD) (*AgentConnections, error) {
+	now := time.Now().Truncate(time.Second)
+	if m.Client == nil {
+		connections, err := m.scrape(ctx, fmt.Sprintf("http://%s.%s.svc.%s:%d/metrics",
+			generateAgentResourceName(cr.Name, string(argoproj.AgentComponentTypePrincipal)+"-metrics"),
+			cr.Namespace, argoutil.GetClusterDomain(cr), PrincipalMetricsServicePort))
+		if err != nil {
+			return nil, err
+		}
+		return setLastSeen(connections, now), nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := m.Client.List(ctx, pods, client.InNamespace(cr.Namespace), client.MatchingLabels{
+		common.ArgoCDKeyName: generateAgentResourceName(cr.Name, string(argoproj.AgentComponentTypePrincipal)),
+	}); err != nil {
+		return nil, err
+	}
+
+	var connections *AgentConnections
+	scraped := 0
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		podConnections, err := m.scrape(ctx, fmt.Sprintf("http://%s/metrics",
+			net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(PrincipalMetricsServiceTargetPort))))
+		if err != nil {
+			// The agents connected to this replica would be reported as disconnected otherwise.
+			return nil, fmt.Errorf("principal pod %s: %w", pod.Name, err)
+		}
+		scraped++
+		connections = mergeAgentConnections(connections, podConnections)
+	}
+	if scraped == 0 {
+		return nil, fmt.Errorf("no running principal pod in namespace %s", cr.Namespace)
+	}
+	return setLastSeen(connections, now), nil
+}
+
+func (m *PrincipalMetricsReader) scrape(ctx context.Context, url string) (*AgentConnections, error) {
+	httpClient := m.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: principalMetricsScrapeTimeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape the principal metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape the principal metrics: %s", resp.Status)
+	}
+	return ParseAgentConnections(resp.Body)
+}
+
+// mergeAgentConnections returns the agents connected to either of the given replicas of a principal. An agent reported
+// by both replicas is connected when it is connected to either of them.
+func mergeAgentConnections(a, b *AgentConnections) *AgentConnections {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.Agents == nil && b.Agents == nil {
+		return &AgentConnections{Connected: a.Connected + b.Connected}
+	}
+
+	merged := &AgentConnections{Agents: map[string]AgentConnection{}}
+	for _, connections := range []*AgentConnections{a, b} {
+		for name, connection := range connections.Agents {
+			if existing, ok := merged.Agents[name]; ok && existing.Connected && !connection.Connected {
+				continue
+			}
+			merged.Agents[name] = connection
+		}
+	}
+	merged.Connected = countConnectedAgents(merged.Agents)
+	return merged
+}
+
+// setLastSeen sets the last seen time of the connected agents of the given connections to the given time.
+func setLastSeen(connections *AgentConnections, now time.Time) *AgentConnections {
+	if connections == nil {
+		return nil
+	}
+	for name, connection := range connections.Agents {
+		if connection.Connected {
+			connection.LastSeen = &now
+			connections.Agents[name] = connection
+		}
+	}
+	return connections
+}
+
+func countConnectedAgents(agents map[string]AgentConnection) int32 {
+	var connected int32
+	for _, connection := range agents {
+		if connection.Connected {
+			connected++
+		}
+	}
+	return connected
+}
+
+// ParseAgentConnections returns the state of the connections of the agents reported in the given metrics of the
+// principal, in the Prometheus text format. It returns nil if the principal does not report it.
+func ParseAgentConnections(in io.Reader) (*AgentConnections, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the principal metrics: %w", err)
+	}
+
+	family, ok := families[PrincipalMetricAgentsConnected]
+	if !ok || len(family.GetMetric()) == 0 {
+		// The principal does not report the connected agents.
+		return nil, nil
+	}
+
+	connections := &AgentConnections{}
+	for _, metric := range family.GetMetric() {
+		// The metric may be declared as a gauge or have no type declared.
+		value := metric.GetUntyped().GetValue()
+		if metric.GetGauge() != nil {
+			value = metric.GetGauge().GetValue()
+		}
+
+		labels := getMetricLabels(metric)
+		name := labels[PrincipalMetricLabelAgentName]
+		if name == "" {
+			connections.Connected += int32(value)
+			continue
+		}
+		if connections.Agents == nil {
+			connections.Agents = map[string]AgentConnection{}
+		}
+		connection := connections.Agents[name]
+		connection.Connected = connection.Connected || value > 0
+		connection.Mode = labels[PrincipalMetricLabelMode]
+		connection.Version = labels[PrincipalMetricLabelVersion]
+		connections.Agents[name] = connection
+	}
+	if connections.Agents != nil {
+		// The series without an agent name are ignored when the agents are reported one by one.
+		connections.Connected = countConnectedAgents(connections.Agents)
+	}
+	return connections, nil
+}
+
+func getMetricLabels(metric *dto.Metric) map[string]string {
+	labels := map[string]string{}
+	for _, pair := range metric.GetLabel() {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+	return labels
+}
+
+// PrincipalMetricsPoller scrapes the metrics of the principals of all the ArgoCDs in the background, so that the
+// reconciles read the connections of the agents from a cache instead of waiting on the principals. It sends an event
+// for an ArgoCD to its subscribers whenever the connections of the agents of its principal change, and remembers when
+// each agent was last seen once it disconnects.
+type PrincipalMetricsPoller struct {
+	// Client is used to list the ArgoCDs with the principal enabled.
+	Client client.Client
+	// Reader scrapes the metrics of a principal.
+	Reader AgentConnectionReader
+	// Interval is how often the principals are scraped, PrincipalMetricsPollInterval when zero.
+	Interval time.Duration
+
+	mu          sync.RWMutex
+	results     map[types.NamespacedName]agentConnectionsResult
+	lastSeen    map[types.NamespacedName]map[string]time.Time
+	subscribers []chan event.GenericEvent
+}
+
+// agentConnectionsResult is the result of the last scrape of a principal.
+type agentConnectionsResult struct {
+	connections *AgentConnections
+	err         error
+}
+
+// blank assignments to verify that PrincipalMetricsPoller implements AgentConnectionReader, and is a Runnable only
+// run by the leader, like the controllers reading from it
+var (
+	_ AgentConnectionReader          = &PrincipalMetricsPoller{}
+	_ manager.LeaderElectionRunnable = &PrincipalMetricsPoller{}
+)
+
+// NewPrincipalMetricsPoller returns a PrincipalMetricsPoller listing the ArgoCDs with the given client and scraping
+// their principals with the given reader.
+func NewPrincipalMetricsPoller(c client.Client, reader AgentConnectionReader) *PrincipalMetricsPoller {
+	return &PrincipalMetricsPoller{Client: c, Reader: reader}
+}
+
+// Subscribe returns a channel receiving an event for an ArgoCD whenever the connections of the agents of its principal
+// change. It must be called before the poller is started. The channel is buffered, and the events are dropped rather
+// than blocking the poller when the subscriber does not keep up.
+func (p *PrincipalMetricsPoller) Subscribe() <-chan event.GenericEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ch := make(chan event.GenericEvent, principalMetricsEventBuffer)
+	p.subscribers = append(p.subscribers, ch)
+	return ch
+}
+
+// GetAgentConnections returns the connections of the agents from the last scrape of the principal of the given ArgoCD.
+func (p *PrincipalMetricsPoller) GetAgentConnections(ctx context.Context, cr *argoproj.ArgoCD) (*AgentConnections, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	result, ok := p.results[client.ObjectKeyFromObject(cr)]
+	if !ok {
+		return nil, fmt.Errorf("the metrics of the principal have not been scraped yet")
+	}
+	return result.connections, result.err
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (p *PrincipalMetricsPoller) NeedLeaderElection() bool {
+	return true
+}
+
+// Start scrapes the principals until the given context is done.
+func (p *PrincipalMetricsPoller) Start(ctx context.Context) error {
+	interval := p.Interval
+	if interval == 0 {
+		interval = PrincipalMetricsPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Poll(ctx); err != nil {
+			log.Error(err, "failed to scrape the metrics of the principals")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll scrapes the principals of the ArgoCDs with the principal enabled once, and notifies the subscribers of the
+// ArgoCDs whose agent connections changed.
+func (p *PrincipalMetricsPoller) Poll(ctx context.Context) error {
+	list := &argoproj.ArgoCDList{}
+	if err := p.Client.List(ctx, list); err != nil {
+		return err
+	}
+
+	results := map[types.NamespacedName]agentConnectionsResult{}
+	lastSeen := map[types.NamespacedName]map[string]time.Time{}
+	var changed []*argoproj.ArgoCD
+	for i := range list.Items {
+		cr := &list.Items[i]
+		if !hasPrincipal(cr) || !cr.Spec.ArgoCDAgent.Principal.IsEnabled() {
+			continue
+		}
+		key := client.ObjectKeyFromObject(cr)
+		scrapeCtx, cancel := context.WithTimeout(ctx, principalMetricsScrapeTimeout)
+		connections, err := p.Reader.GetAgentConnections(scrapeCtx, cr)
+		cancel()
+
+		p.mu.RLock()
+		previous, ok := p.results[key]
+		seen := maps.Clone(p.lastSeen[key])
+		p.mu.RUnlock()
+
+		if seen == nil {
+			seen = map[string]time.Time{}
+		}
+		connections = withLastSeen(connections, seen)
+		result := agentConnectionsResult{connections: connections, err: err}
+		results[key] = result
+		lastSeen[key] = seen
+		if !ok || !equalAgentConnections(previous, result) {
+			changed = append(changed, cr)
+		}
+	}
+
+	p.mu.Lock()
+	p.results = results
+	p.lastSeen = lastSeen
+	subscribers := p.subscribers
+	p.mu.Unlock()
+
+	for _, cr := range changed {
+		for _, ch := range subscribers {
+			select {
+			case ch <- event.GenericEvent{Object: cr}:
+			default:
+				log.Info(fmt.Sprintf("dropping the agent connections event of ArgoCD %s in namespace %s, its subscriber is not keeping up",
+					cr.Name, cr.Namespace))
+			}
+		}
+	}
+	return nil
+}
+
+// withLastSeen records the last seen time of the connected agents of the given connections in seen, and returns a copy
+// of the connections in which the agents no longer connected keep their last seen time. The agents that were seen
+// before and are no longer reported by the principal are added as disconnected.
+func withLastSeen(connections *AgentConnections, seen map[string]time.Time) *AgentConnections {
+	if connections == nil || connections.Agents == nil {
+		return connections
+	}
+
+	agents := make(map[string]AgentConnection, len(connections.Agents))
+	for name, connection := range connections.Agents {
+		if connection.LastSeen != nil {
+			seen[name] = *connection.LastSeen
+		}
+		agents[name] = connection
+	}
+	for name, lastSeen := range seen {
+		connection := agents[name]
+		if connection.LastSeen == nil {
+			connection.LastSeen = &lastSeen
+			agents[name] = connection
+		}
+	}
+	return &AgentConnections{Connected: connections.Connected, Agents: agents}
+}
+
+// equalAgentConnections returns whether the given scrapes of a principal report the same connections of the agents.
+// The last seen times are not compared, as they change with every scrape.
+func equalAgentConnections(a, b agentConnectionsResult) bool {
+	if (a.err == nil) != (b.err == nil) || (a.connections == nil) != (b.connections == nil) {
+		return false
+	}
+	if a.connections == nil {
+		return true
+	}
+	if a.connections.Connected != b.connections.Connected ||
+		(a.connections.Agents == nil) != (b.connections.Agents == nil) ||
+		len(a.connections.Agents) != len(b.connections.Agents) {
+		return false
+	}
+	for name, connection := range a.connections.Agents {
+		other, ok := b.connections.Agents[name]
+		if !ok || connection.Connected != other.Connected || connection.Mode != other.Mode ||
+			connection.Version != other.Version {
+			return false
+		}
+	}
+	return true
+}
+
+// GetAgentsStatus returns the connections of the agents to the principal of the given ArgoCD, read with the given
+// reader, along with the AgentsDegraded condition. The agents are the ones reported by the principal and the ones
+// having a cluster Secret in the namespace of the ArgoCD, so that an agent that never connected is accounted for. When
+// the principal only reports the number of connected agents, the total is the number of agents having a cluster Secret
+// or the number of connected agents if greater, and the status has no entry per agent. The status is nil when the
+// principal does not report the connected agents.
+func GetAgentsStatus(ctx context.Context, c client.Client, cr *argoproj.ArgoCD, reader AgentConnectionReader) (*argoproj.ArgoCDAgentsStatus, metav1.Condition, error) {
+	condition := metav1.Condition{
+		Type:   argoproj.ArgoCDConditionAgentsDegraded,
+		Status: metav1.ConditionUnknown,
+		Reason: argoproj.ArgoCDConditionReasonAgentMetricsUnavailable,
+	}
+
+	connections, err := reader.GetAgentConnections(ctx, cr)
+	if err != nil {
+		condition.Message = err.Error()
+		return nil, condition, nil
+	}
+	if connections == nil {
+		condition.Message = fmt.Sprintf("the principal does not report the %s metric", PrincipalMetricAgentsConnected)
+		return nil, condition, nil
+	}
+
+	agents, err := listAgentNames(ctx, c, cr)
+	if err != nil {
+		return nil, condition, err
+	}
+
+	status := &argoproj.ArgoCDAgentsStatus{
+		PrincipalVersion: getImageVersion(buildPrincipalImage(cr)),
+	}
+	if connections.Agents == nil {
+		status.Connected = connections.Connected
+		status.Total = max(int32(len(agents)), connections.Connected)
+		if status.Connected < status.Total {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = argoproj.ArgoCDConditionReasonAgentsDisconnected
+			condition.Message = fmt.Sprintf("%d of %d agents are not connected to the principal",
+				status.Total-status.Connected, status.Total)
+			return status, condition, nil
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonAgentsHealthy
+		condition.Message = fmt.Sprintf("%d of %d agents are connected to the principal", status.Connected, status.Total)
+		return status, condition, nil
+	}
+
+	names := sets.New(agents...)
+	for name := range connections.Agents {
+		names.Insert(name)
+	}
+	var disconnected, skewed []string
+	for _, name := range sets.List(names) {
+		connection := connections.Agents[name]
+		item := argoproj.ArgoCDAgentStatus{
+			Name:        name,
+			Connected:   connection.Connected,
+			Mode:        connection.Mode,
+			Version:     connection.Version,
+			VersionSkew: hasVersionSkew(status.PrincipalVersion, connection.Version),
+		}
+		if connection.LastSeen != nil {
+			item.LastSeen = &metav1.Time{Time: *connection.LastSeen}
+		}
+		if item.Connected {
+			status.Connected++
+		} else {
+			disconnected = append(disconnected, name)
+		}
+		if item.VersionSkew {
+			skewed = append(skewed, name)
+		}
+		status.Items = append(status.Items, item)
+	}
+	status.Total = int32(len(status.Items))
+
+	switch {
+	case len(disconnected) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = argoproj.ArgoCDConditionReasonAgentsDisconnected
+		condition.Message = fmt.Sprintf("%d of %d agents are not connected to the principal: %s",
+			len(disconnected), status.Total, strings.Join(disconnected, ", "))
+	case len(skewed) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = argoproj.ArgoCDConditionReasonAgentVersionSkew
+		condition.Message = fmt.Sprintf("%d of %d agents run a minor version other than the one of the principal (%s): %s",
+			len(skewed), status.Total, status.PrincipalVersion, strings.Join(skewed, ", "))
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonAgentsHealthy
+		condition.Message = fmt.Sprintf("%d of %d agents are connected to the principal", status.Connected, status.Total)
+	}
+	return status, condition, nil
+}
+
+// hasVersionSkew returns whether the given versions of the principal and an agent differ in their major or minor
+// version. Versions that are not semantic versions are never considered skewed.
+func hasVersionSkew(principalVersion, agentVersion string) bool {
+	principal, err := version.ParseGeneric(principalVersion)
+	if err != nil {
+		return false
+	}
+	agent, err := version.ParseGeneric(agentVersion)
+	if err != nil {
+		return false
+	}
+	return principal.Major() != agent.Major() || principal.Minor() != agent.Minor()
+}
+
+// getImageVersion returns the tag of the given image, or an empty string if the image is referenced by digest or has
+// no tag.
+func getImageVersion(image string) string {
+	if strings.Contains(image, "@") {
+		return ""
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return ""
+}
@@ -0,0 +1,375 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdagent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+type fakeAgentConnectionReader struct {
+	connections *AgentConnections
+	err         error
+}
+
+func (f *fakeAgentConnectionReader) GetAgentConnections(ctx context.Context, cr *argoproj.ArgoCD) (*AgentConnections, error) {
+	return f.connections, f.err
+}
+
+var testLastSeen = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func makeTestAgentClusterSecret(agentName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-" + agentName,
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				common.ArgoCDSecretTypeLabel: "cluster",
+				common.ArgoCDAgentNameLabel:  agentName,
+			},
+		},
+	}
+}
+
+func TestParseAgentConnections(t *testing.T) {
+	t.Run("Number of connected agents reported", func(t *testing.T) {
+		metrics := `# HELP agent_connected_with_principal The total number of agents connected with principal
+# TYPE agent_connected_with_principal gauge
+agent_connected_with_principal 2
+# TYPE go_goroutines gauge
+go_goroutines 42
+`
+		connections, err := ParseAgentConnections(strings.NewReader(metrics))
+		require.NoError(t, err)
+		assert.Equal(t, &AgentConnections{Connected: 2}, connections)
+	})
+
+	t.Run("Connected agents reported one by one", func(t *testing.T) {
+		metrics := `# TYPE agent_connected_with_principal gauge
+agent_connected_with_principal{agent_name="workload-1",mode="managed",version="v0.8.1"} 1
+agent_connected_with_principal{agent_name="workload-2",mode="autonomous",version="v0.7.0"} 0
+`
+		connections, err := ParseAgentConnections(strings.NewReader(metrics))
+		require.NoError(t, err)
+		assert.Equal(t, &AgentConnections{Connected: 1, Agents: map[string]AgentConnection{
+			"workload-1": {Connected: true, Mode: "managed", Version: "v0.8.1"},
+			"workload-2": {Mode: "autonomous", Version: "v0.7.0"},
+		}}, connections)
+	})
+
+	t.Run("Connected agents not reported", func(t *testing.T) {
+		metrics := `# TYPE go_goroutines gauge
+go_goroutines 42
+`
+		connections, err := ParseAgentConnections(strings.NewReader(metrics))
+		require.NoError(t, err)
+		assert.Nil(t, connections)
+	})
+
+	t.Run("Invalid metrics", func(t *testing.T) {
+		_, err := ParseAgentConnections(strings.NewReader("not a metric {"))
+		assert.Error(t, err)
+	})
+}
+
+func TestGetAgentsStatus(t *testing.T) {
+	tests := []struct {
+		name              string
+		reader            *fakeAgentConnectionReader
+		secrets           []client.Object
+		expectedStatus    *argoproj.ArgoCDAgentsStatus
+		expectedCondition metav1.ConditionStatus
+		expectedReason    string
+	}{
+		{
+			name:              "All agents connected",
+			reader:            &fakeAgentConnectionReader{connections: &AgentConnections{Connected: 1}},
+			secrets:           []client.Object{makeTestAgentClusterSecret("workload-1")},
+			expectedStatus:    &argoproj.ArgoCDAgentsStatus{PrincipalVersion: "v0.8.1", Connected: 1, Total: 1},
+			expectedCondition: metav1.ConditionFalse,
+			expectedReason:    argoproj.ArgoCDConditionReasonAgentsHealthy,
+		},
+		{
+			name:              "Agent with a cluster secret not connected",
+			reader:            &fakeAgentConnectionReader{connections: &AgentConnections{Connected: 1}},
+			secrets:           []client.Object{makeTestAgentClusterSecret("workload-1"), makeTestAgentClusterSecret("workload-2")},
+			expectedStatus:    &argoproj.ArgoCDAgentsStatus{PrincipalVersion: "v0.8.1", Connected: 1, Total: 2},
+			expectedCondition: metav1.ConditionTrue,
+			expectedReason:    argoproj.ArgoCDConditionReasonAgentsDisconnected,
+		},
+		{
+			name:              "Agents connected without a cluster secret",
+			reader:            &fakeAgentConnectionReader{connections: &AgentConnections{Connected: 2}},
+			secrets:           []client.Object{makeTestAgentClusterSecret("workload-1")},
+			expectedStatus:    &argoproj.ArgoCDAgentsStatus{PrincipalVersion: "v0.8.1", Connected: 2, Total: 2},
+			expectedCondition: metav1.ConditionFalse,
+			expectedReason:    argoproj.ArgoCDConditionReasonAgentsHealthy,
+		},
+		{
+			name: "Agents reported one by one",
+			reader: &fakeAgentConnectionReader{connections: &AgentConnections{Connected: 2, Agents: map[string]AgentConnection{
+				"workload-1": {Connected: true, Mode: "managed", Version: "v0.8.0", LastSeen: &testLastSeen},
+				"workload-3": {Connected: true, Mode: "autonomous", Version: "v0.8.1", LastSeen: &testLastSeen},
+			}}},
+			secrets: []client.Object{makeTestAgentClusterSecret("workload-1"), makeTestAgentClusterSecret("workload-2")},
+			expectedStatus: &argoproj.ArgoCDAgentsStatus{PrincipalVersion: "v0.8.1", Connected: 2, Total: 3, Items: []argoproj.ArgoCDAgentStatus{
+				{Name: "workload-1", Connected: true, Mode: "managed", Version: "v0.8.0", LastSeen: &metav1.Time{Time: testLastSeen}},
+				{Name: "workload-2"},
+				{Name: "workload-3", Connected: true, Mode: "autonomous", Version: "v0.8.1", LastSeen: &metav1.Time{Time: testLastSeen}},
+			}},
+			expectedCondition: metav1.ConditionTrue,
+			expectedReason:    argoproj.ArgoCDConditionReasonAgentsDisconnected,
+		},
+		{
+			name: "Agent with a version skew",
+			reader: &fakeAgentConnectionReader{connections: &AgentConnections{Connected: 2, Agents: map[string]AgentConnection{
+				"workload-1": {Connected: true, Mode: "managed", Version: "v0.7.2"},
+				"workload-2": {Connected: true, Mode: "managed", Version: "v0.8.0"},
+			}}},
+			secrets: []client.Object{makeTestAgentClusterSecret("workload-1"), makeTestAgentClusterSecret("workload-2")},
+			expectedStatus: &argoproj.ArgoCDAgentsStatus{PrincipalVersion: "v0.8.1", Connected: 2, Total: 2, Items: []argoproj.ArgoCDAgentStatus{
+				{Name: "workload-1", Connected: true, Mode: "managed", Version: "v0.7.2", VersionSkew: true},
+				{Name: "workload-2", Connected: true, Mode: "managed", Version: "v0.8.0"},
+			}},
+			expectedCondition: metav1.ConditionTrue,
+			expectedReason:    argoproj.ArgoCDConditionReasonAgentVersionSkew,
+		},
+		{
+			name:              "Connected agents not reported",
+			reader:            &fakeAgentConnectionReader{},
+			secrets:           []client.Object{makeTestAgentClusterSecret("workload-1")},
+			expectedCondition: metav1.ConditionUnknown,
+			expectedReason:    argoproj.ArgoCDConditionReasonAgentMetricsUnavailable,
+		},
+		{
+			name:              "Principal unreachable",
+			reader:            &fakeAgentConnectionReader{err: errors.New("connection refused")},
+			expectedCondition: metav1.ConditionUnknown,
+			expectedReason:    argoproj.ArgoCDConditionReasonAgentMetricsUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := makeTestArgoCD(withPrincipalEnabled(true))
+			cl := makeTestReconcilerClient(makeTestReconcilerScheme(), tt.secrets)
+
+			status, condition, err := GetAgentsStatus(context.TODO(), cl, cr, tt.reader)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, argoproj.ArgoCDConditionAgentsDegraded, condition.Type)
+			assert.Equal(t, tt.expectedCondition, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestHasVersionSkew(t *testing.T) {
+	assert.False(t, hasVersionSkew("v0.8.1", "v0.8.0"))
+	assert.True(t, hasVersionSkew("v0.8.1", "v0.7.2"))
+	assert.True(t, hasVersionSkew("v0.8.1", "1.8.1"))
+	assert.False(t, hasVersionSkew("latest", "v0.7.2"))
+	assert.False(t, hasVersionSkew("v0.8.1", ""))
+}
+
+func TestGetImageVersion(t *testing.T) {
+	assert.Equal(t, "v0.8.1", getImageVersion("quay.io/argoprojlabs/argocd-agent:v0.8.1"))
+	assert.Equal(t, "v0.8.1", getImageVersion("localhost:5000/argocd-agent:v0.8.1"))
+	assert.Equal(t, "", getImageVersion("localhost:5000/argocd-agent"))
+	assert.Equal(t, "", getImageVersion("quay.io/argoprojlabs/argocd-agent@sha256:abc"))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func makeTestPrincipalPod(name, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    buildLabelsForAgentPrincipal(testArgoCDName, testCompName),
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: ip},
+	}
+}
+
+func TestPrincipalMetricsReader_GetAgentConnections(t *testing.T) {
+	metrics := map[string]string{
+		"10.0.0.1:8000": "agent_connected_with_principal 1\n",
+		"10.0.0.2:8000": "agent_connected_with_principal 2\n",
+		"10.0.0.4:8000": `agent_connected_with_principal{agent_name="workload-1",mode="managed",version="v0.8.1"} 1
+agent_connected_with_principal{agent_name="workload-2",mode="managed",version="v0.8.1"} 0
+`,
+		"10.0.0.5:8000": `agent_connected_with_principal{agent_name="workload-1",mode="managed",version="v0.8.1"} 0
+agent_connected_with_principal{agent_name="workload-3",mode="autonomous",version="v0.8.1"} 1
+`,
+	}
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body, ok := metrics[req.URL.Host]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+
+	cr := makeTestArgoCD(withPrincipalEnabled(true))
+
+	t.Run("Each replica is scraped", func(t *testing.T) {
+		cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{
+			makeTestPrincipalPod("principal-1", "10.0.0.1"),
+			makeTestPrincipalPod("principal-2", "10.0.0.2"),
+		})
+		reader := &PrincipalMetricsReader{Client: cl, HTTPClient: httpClient}
+
+		connections, err := reader.GetAgentConnections(context.TODO(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, &AgentConnections{Connected: 3}, connections)
+	})
+
+	t.Run("The agents reported by each replica are merged", func(t *testing.T) {
+		cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{
+			makeTestPrincipalPod("principal-4", "10.0.0.4"),
+			makeTestPrincipalPod("principal-5", "10.0.0.5"),
+		})
+		reader := &PrincipalMetricsReader{Client: cl, HTTPClient: httpClient}
+
+		connections, err := reader.GetAgentConnections(context.TODO(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, connections)
+		assert.Equal(t, int32(2), connections.Connected)
+		require.Len(t, connections.Agents, 3)
+		assert.True(t, connections.Agents["workload-1"].Connected)
+		assert.NotNil(t, connections.Agents["workload-1"].LastSeen)
+		assert.False(t, connections.Agents["workload-2"].Connected)
+		assert.Nil(t, connections.Agents["workload-2"].LastSeen)
+		assert.True(t, connections.Agents["workload-3"].Connected)
+		assert.Equal(t, "autonomous", connections.Agents["workload-3"].Mode)
+	})
+
+	t.Run("A replica cannot be scraped", func(t *testing.T) {
+		cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{
+			makeTestPrincipalPod("principal-1", "10.0.0.1"),
+			makeTestPrincipalPod("principal-3", "10.0.0.3"),
+		})
+		reader := &PrincipalMetricsReader{Client: cl, HTTPClient: httpClient}
+
+		_, err := reader.GetAgentConnections(context.TODO(), cr)
+		assert.Error(t, err)
+	})
+
+	t.Run("No running replica", func(t *testing.T) {
+		cl := makeTestReconcilerClient(makeTestReconcilerScheme(), nil)
+		reader := &PrincipalMetricsReader{Client: cl, HTTPClient: httpClient}
+
+		_, err := reader.GetAgentConnections(context.TODO(), cr)
+		assert.Error(t, err)
+	})
+}
+
+func TestPrincipalMetricsPoller_Poll(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true))
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{cr})
+	reader := &fakeAgentConnectionReader{connections: &AgentConnections{Connected: 1}}
+	poller := NewPrincipalMetricsPoller(cl, reader)
+	events := poller.Subscribe()
+
+	_, err := poller.GetAgentConnections(context.TODO(), cr)
+	assert.Error(t, err, "nothing is read before the first scrape")
+
+	require.NoError(t, poller.Poll(context.TODO()))
+	connections, err := poller.GetAgentConnections(context.TODO(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), connections.Connected)
+	require.Len(t, events, 1)
+	e := <-events
+	assert.Equal(t, cr.Name, e.Object.GetName())
+
+	// No event is sent when the connected agents did not change.
+	require.NoError(t, poller.Poll(context.TODO()))
+	assert.Empty(t, events)
+
+	reader.connections = &AgentConnections{Connected: 0}
+	require.NoError(t, poller.Poll(context.TODO()))
+	connections, err = poller.GetAgentConnections(context.TODO(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), connections.Connected)
+	require.Len(t, events, 1)
+	e = <-events
+	assert.Equal(t, cr.Name, e.Object.GetName())
+}
+
+func TestPrincipalMetricsPoller_Poll_lastSeen(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true))
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{cr})
+	reader := &fakeAgentConnectionReader{connections: &AgentConnections{Connected: 2, Agents: map[string]AgentConnection{
+		"workload-1": {Connected: true, Mode: "managed", LastSeen: &testLastSeen},
+		"workload-2": {Connected: true, Mode: "managed", LastSeen: &testLastSeen},
+	}}}
+	poller := NewPrincipalMetricsPoller(cl, reader)
+	events := poller.Subscribe()
+	require.NoError(t, poller.Poll(context.TODO()))
+	<-events
+
+	// An agent that disconnects, or is no longer reported, keeps the time it was last seen.
+	later := testLastSeen.Add(time.Minute)
+	reader.connections = &AgentConnections{Connected: 0, Agents: map[string]AgentConnection{
+		"workload-1": {Mode: "managed"},
+		"workload-3": {Connected: true, Mode: "managed", LastSeen: &later},
+	}}
+	require.NoError(t, poller.Poll(context.TODO()))
+	connections, err := poller.GetAgentConnections(context.TODO(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]AgentConnection{
+		"workload-1": {Mode: "managed", LastSeen: &testLastSeen},
+		"workload-2": {LastSeen: &testLastSeen},
+		"workload-3": {Connected: true, Mode: "managed", LastSeen: &later},
+	}, connections.Agents)
+	assert.Len(t, events, 1)
+
+	// The reader is not modified by the poller.
+	assert.Nil(t, reader.connections.Agents["workload-1"].LastSeen)
+}
+
+func TestPrincipalMetricsPoller_Poll_slowSubscriber(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true))
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{cr})
+	reader := &fakeAgentConnectionReader{}
+	poller := NewPrincipalMetricsPoller(cl, reader)
+	events := poller.Subscribe()
+
+	// The poller does not block on a subscriber whose buffer is full.
+	for i := 0; i <= principalMetricsEventBuffer; i++ {
+		reader.connections = &AgentConnections{Connected: int32(i % 2)}
+		require.NoError(t, poller.Poll(context.TODO()))
+	}
+	assert.Len(t, events, principalMetricsEventBuffer)
+}
@@ -17,7 +17,7 @@ package argocdagent
 import (
 	"context"
 	"fmt"
-	"os"
+	"maps"
 	"reflect"
 	"strconv"
 	"strings"
@@ -27,6 +27,7 @@ import (
 	apiError "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -38,6 +39,10 @@ import (
 	tlsProfile "github.com/argoproj-labs/argocd-operator/pkg/tlsprofile"
 )
 
+// principalPreStopSleepSeconds is how long a stopping principal replica keeps serving, so that it is removed from the
+// endpoints of its Services before it stops.
+const principalPreStopSleepSeconds = 5
+
 // ReconcilePrincipalDeployment reconciles the ArgoCD agent principal deployment.
 // It creates, updates, or deletes the deployment based on the ArgoCD CR configuration.
 func ReconcilePrincipalDeployment(client client.Client, compName, saName string, cr *argoproj.ArgoCD, scheme *runtime.Scheme, centralTLSProfile tlsProfile.TLSConfigProfile) error {
@@ -62,8 +67,21 @@ func ReconcilePrincipalDeployment(client client.Client, compName, saName string,
 			return nil
 		}
 
+		if err := ValidatePrincipalRedis(cr); err != nil {
+			log.Info("skipping the principal deployment update as the Redis configuration is invalid", "error", err.Error())
+			return nil
+		}
+
 		deployment, changed := updateDeploymentIfChanged(compName, saName, cr, deployment, centralTLSProfile)
-		if changed {
+		revisionChanged, err := argoutil.SetRedisAuthRevision(client, cr, &deployment.Spec.Template)
+		if err != nil {
+			return fmt.Errorf("failed to get redis auth revision for principal deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
+		}
+		pkiChanged, err := setPrincipalPKIChecksum(client, cr, &deployment.Spec.Template)
+		if err != nil {
+			return fmt.Errorf("failed to get PKI checksum for principal deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
+		}
+		if changed || revisionChanged || pkiChanged {
 			argoutil.LogResourceUpdate(log, deployment, "principal deployment is being updated")
 			if err := client.Update(context.TODO(), deployment); err != nil {
 				return fmt.Errorf("failed to update principal deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
@@ -77,12 +95,23 @@ func ReconcilePrincipalDeployment(client client.Client, compName, saName string,
 		return nil
 	}
 
+	if err := ValidatePrincipalRedis(cr); err != nil {
+		log.Info("skipping the principal deployment creation as the Redis configuration is invalid", "error", err.Error())
+		return nil
+	}
+
 	if err := controllerutil.SetControllerReference(cr, deployment, scheme); err != nil {
 		return fmt.Errorf("failed to set ArgoCD CR %s as owner for service %s: %w", cr.Name, deployment.Name, err)
 	}
 
 	argoutil.LogResourceCreation(log, deployment)
 	deployment.Spec = buildPrincipalSpec(compName, saName, cr, centralTLSProfile)
+	if _, err := argoutil.SetRedisAuthRevision(client, cr, &deployment.Spec.Template); err != nil {
+		return fmt.Errorf("failed to get redis auth revision for principal deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
+	}
+	if _, err := setPrincipalPKIChecksum(client, cr, &deployment.Spec.Template); err != nil {
+		return fmt.Errorf("failed to get PKI checksum for principal deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
+	}
 	if err := client.Create(context.TODO(), deployment); err != nil {
 		return fmt.Errorf("failed to create principal deployment %s in namespace %s: %v", deployment.Name, cr.Namespace, err)
 	}
@@ -101,9 +130,11 @@ func buildDeployment(compName string, cr *argoproj.ArgoCD) *appsv1.Deployment {
 
 func buildPrincipalSpec(compName, saName string, cr *argoproj.ArgoCD, centralTLSProfile tlsProfile.TLSConfigProfile) appsv1.DeploymentSpec {
 	redisAuthVolume, redisAuthMount := argoutil.MountRedisAuthToArgo(cr)
-	envParams := buildPrincipalContainerEnv(cr, centralTLSProfile)
+	// A new principal is not allowed any other namespace while its namespace policies are invalid.
+	envParams := keepPrincipalNamespaceEnv(cr, buildPrincipalContainerEnv(cr, centralTLSProfile), nil)
 	return appsv1.DeploymentSpec{
 		Selector: buildSelector(compName, cr),
+		Strategy: buildPrincipalStrategy(),
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
 				Labels: buildLabelsForAgentPrincipal(cr.Name, compName),
@@ -120,6 +151,8 @@ func buildPrincipalSpec(compName, saName string, cr *argoproj.ArgoCD, centralTLS
 						Ports:           buildPorts(compName),
 						VolumeMounts:    append(buildVolumeMounts(), redisAuthMount),
 						Resources:       getPrincipalResources(cr),
+						ReadinessProbe:  buildPrincipalReadinessProbe(),
+						Lifecycle:       buildPrincipalLifecycle(),
 					},
 				},
 				ServiceAccountName: saName,
@@ -129,6 +162,46 @@ func buildPrincipalSpec(compName, saName string, cr *argoproj.ArgoCD, centralTLS
 	}
 }
 
+// buildPrincipalStrategy returns the rollout strategy of the principal, which starts a new replica and waits for it to
+// be ready before stopping an old one, so that agents can reconnect right away.
+func buildPrincipalStrategy() appsv1.DeploymentStrategy {
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+			MaxSurge:       ptr.To(intstr.FromInt32(1)),
+		},
+	}
+}
+
+// buildPrincipalReadinessProbe returns the readiness probe of the principal, on its healthz endpoint.
+func buildPrincipalReadinessProbe() *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   "/healthz",
+				Port:   intstr.FromString(PrincipalHealthzServicePortName),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		InitialDelaySeconds: 5,
+		TimeoutSeconds:      1,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+}
+
+// buildPrincipalLifecycle returns the lifecycle of the principal, which keeps a stopping replica serving until it is
+// removed from the endpoints of its Services, so that agents are only disconnected once a ready replica can take over.
+func buildPrincipalLifecycle() *corev1.Lifecycle {
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Sleep: &corev1.SleepAction{Seconds: principalPreStopSleepSeconds},
+		},
+	}
+}
+
 func buildSelector(compName string, cr *argoproj.ArgoCD) *metav1.LabelSelector {
 	return &metav1.LabelSelector{
 		MatchLabels: buildLabelsForAgentPrincipal(cr.Name, compName),
@@ -194,7 +267,7 @@ func buildPrincipalImage(cr *argoproj.ArgoCD) string {
 	}
 
 	// Value specified in the environment take precedence over the default
-	if env := os.Getenv(EnvArgoCDPrincipalImage); env != "" {
+	if env := argoutil.GetOperatorSetting(EnvArgoCDPrincipalImage); env != "" {
 		return env
 	}
 
@@ -279,7 +352,7 @@ func updateDeploymentIfChanged(compName, saName string, cr *argoproj.ArgoCD, dep
 		changed = true
 		deployment.Spec.Template.Spec.Containers[0].Name = generateAgentResourceName(cr.Name, compName)
 	}
-	envParams := buildPrincipalContainerEnv(cr, centralTLSProfile)
+	envParams := keepPrincipalNamespaceEnv(cr, buildPrincipalContainerEnv(cr, centralTLSProfile), deployment.Spec.Template.Spec.Containers[0].Env)
 	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Containers[0].Env, envParams) {
 		log.Info("deployment container env is being updated")
 		changed = true
@@ -332,9 +405,56 @@ func updateDeploymentIfChanged(compName, saName string, cr *argoproj.ArgoCD, dep
 		deployment.Spec.Template.Spec.Containers[0].Resources = principalResources
 	}
 
+	if !reflect.DeepEqual(deployment.Spec.Strategy, buildPrincipalStrategy()) {
+		log.Info("deployment strategy is being updated")
+		changed = true
+		deployment.Spec.Strategy = buildPrincipalStrategy()
+	}
+
+	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Containers[0].ReadinessProbe, buildPrincipalReadinessProbe()) {
+		log.Info("deployment container readiness probe is being updated")
+		changed = true
+		deployment.Spec.Template.Spec.Containers[0].ReadinessProbe = buildPrincipalReadinessProbe()
+	}
+
+	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Containers[0].Lifecycle, buildPrincipalLifecycle()) {
+		log.Info("deployment container lifecycle is being updated")
+		changed = true
+		deployment.Spec.Template.Spec.Containers[0].Lifecycle = buildPrincipalLifecycle()
+	}
+
 	return deployment, changed
 }
 
+// principalNamespacePolicyEnv are the env vars of the principal derived from its namespace policies, with the values
+// used when the principal has no previous ones.
+var principalNamespacePolicyEnv = map[string]string{
+	EnvArgoCDPrincipalAllowedNamespaces:      "",
+	EnvArgoCDPrincipalNamespaceCreateEnable:  "false",
+	EnvArgoCDPrincipalNamespaceCreatePattern: "",
+}
+
+// keepPrincipalNamespaceEnv returns the given env of the principal of the given ArgoCD, with the namespace settings of
+// the given previous env when the namespace policies are invalid, so that the rest of the principal is still updated
+// without changing the namespaces it serves. The principal is allowed no other namespace when it has no previous env.
+func keepPrincipalNamespaceEnv(cr *argoproj.ArgoCD, env, previous []corev1.EnvVar) []corev1.EnvVar {
+	if err := ValidatePrincipalNamespacePolicies(cr); err == nil {
+		return env
+	}
+	values := maps.Clone(principalNamespacePolicyEnv)
+	for _, e := range previous {
+		if _, ok := values[e.Name]; ok {
+			values[e.Name] = e.Value
+		}
+	}
+	for i := range env {
+		if value, ok := values[env[i].Name]; ok {
+			env[i].Value = value
+		}
+	}
+	return env
+}
+
 func buildPrincipalContainerEnv(cr *argoproj.ArgoCD, centralTLSProfile tlsProfile.TLSConfigProfile) []corev1.EnvVar {
 	arguments := getPrincipalTlsConfig(centralTLSProfile)
 	env := []corev1.EnvVar{
@@ -413,7 +533,7 @@ func buildPrincipalContainerEnv(cr *argoproj.ArgoCD, centralTLSProfile tlsProfil
 		},
 	}
 
-	env = append(env, argoutil.GetRedisAuthEnv()...)
+	env = append(env, argoutil.GetRedisAuthEnv(cr)...)
 
 	// Add custom environment variables if specified in the CR
 	if hasPrincipal(cr) && cr.Spec.ArgoCDAgent.Principal.Env != nil {
@@ -446,7 +566,7 @@ const (
 	EnvArgoCDPrincipalResourceProxySecretName   = "ARGOCD_PRINCIPAL_RESOURCE_PROXY_SECRET_NAME"
 	EnvArgoCDPrincipalResourceProxyCaSecretName = "ARGOCD_PRINCIPAL_RESOURCE_PROXY_CA_SECRET_NAME"
 	EnvArgoCDPrincipalJwtSecretName             = "ARGOCD_PRINCIPAL_JWT_SECRET_NAME"
-	EnvArgoCDPrincipalImage                     = "ARGOCD_PRINCIPAL_IMAGE"
+	EnvArgoCDPrincipalImage                     = common.ArgoCDPrincipalImageEnvName
 	EnvArgoCDPrincipalDestinationBasedMapping   = "ARGOCD_PRINCIPAL_DESTINATION_BASED_MAPPING"
 	EnvArgoCDPrincipalLabelSelector             = "ARGOCD_PRINCIPAL_LABEL_SELECTOR"
 	EnvArgoCDPrincipalTlsMinVersion             = "ARGOCD_PRINCIPAL_TLS_MIN_VERSION"
@@ -515,6 +635,9 @@ func getPrincipalLabelSelector(cr *argoproj.ArgoCD) string {
 }
 
 func getPrincipalAllowedNamespaces(cr *argoproj.ArgoCD) string {
+	if hasNamespacePolicies(cr) {
+		return strings.Join(getNamespacePolicyPatterns(cr), ",")
+	}
 	if hasNamespace(cr) &&
 		cr.Spec.ArgoCDAgent.Principal.Namespace.AllowedNamespaces != nil &&
 		len(cr.Spec.ArgoCDAgent.Principal.Namespace.AllowedNamespaces) > 0 {
@@ -524,6 +647,9 @@ func getPrincipalAllowedNamespaces(cr *argoproj.ArgoCD) string {
 }
 
 func getPrincipalNamespaceCreateEnable(cr *argoproj.ArgoCD) string {
+	if hasNamespacePolicies(cr) {
+		return strconv.FormatBool(getNamespacePolicyCreatePattern(cr) != "")
+	}
 	if hasNamespace(cr) && cr.Spec.ArgoCDAgent.Principal.Namespace.EnableNamespaceCreate != nil {
 		return strconv.FormatBool(*cr.Spec.ArgoCDAgent.Principal.Namespace.EnableNamespaceCreate)
 	}
@@ -531,6 +657,9 @@ func getPrincipalNamespaceCreateEnable(cr *argoproj.ArgoCD) string {
 }
 
 func getPrincipalNamespaceCreatePattern(cr *argoproj.ArgoCD) string {
+	if hasNamespacePolicies(cr) {
+		return getNamespacePolicyCreatePattern(cr)
+	}
 	if hasNamespace(cr) && cr.Spec.ArgoCDAgent.Principal.Namespace.NamespaceCreatePattern != "" {
 		return cr.Spec.ArgoCDAgent.Principal.Namespace.NamespaceCreatePattern
 	}
@@ -584,10 +713,32 @@ func getPrincipalKeepAliveMinInterval(cr *argoproj.ArgoCD) string {
 }
 
 // Redis Configuration
+
+// ValidatePrincipalRedis checks that the principal can connect to the Redis of the given ArgoCD. The principal does not
+// discover Redis through Sentinel, so an external Redis only reachable through Sentinel requires an explicit
+// spec.argoCDAgent.principal.redis.serverAddress. The principal is not configured with the TLS settings of the external
+// Redis either, so an external Redis requiring TLS is rejected.
+func ValidatePrincipalRedis(cr *argoproj.ArgoCD) error {
+	external := cr.Spec.Redis.External
+	if external == nil {
+		return nil
+	}
+	if external.TLS != nil {
+		return fmt.Errorf("the principal does not support TLS to Redis, remove spec.redis.external.tls or disable the principal")
+	}
+	if external.Address != "" || (hasRedis(cr) && cr.Spec.ArgoCDAgent.Principal.Redis.ServerAddress != "") {
+		return nil
+	}
+	return fmt.Errorf("the principal does not support Redis Sentinel, set spec.argoCDAgent.principal.redis.serverAddress to the address of the Redis server")
+}
+
 func getPrincipalRedisServerAddress(cr *argoproj.ArgoCD) string {
 	if hasRedis(cr) && cr.Spec.ArgoCDAgent.Principal.Redis.ServerAddress != "" {
 		return cr.Spec.ArgoCDAgent.Principal.Redis.ServerAddress
 	}
+	if cr.Spec.Redis.External != nil && cr.Spec.Redis.External.Address != "" {
+		return cr.Spec.Redis.External.Address
+	}
 	return fmt.Sprintf("%s-%s:%d", cr.Name, "redis", common.ArgoCDDefaultRedisPort)
 }
 
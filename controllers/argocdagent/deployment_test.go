@@ -26,6 +26,7 @@ import (
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -863,3 +864,28 @@ func TestBuildPrincipalContainerEnv_LabelSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilePrincipalDeployment_Rollout(t *testing.T) {
+	// Test case: Verify the settings keeping agents connected during rollouts of the principal
+	// Expected behavior: Should start a ready replica before stopping the old one, which keeps serving while stopping
+
+	cr := makeTestArgoCD(withPrincipalEnabled(true))
+	saName := generateAgentResourceName(cr.Name, testCompName)
+
+	resObjs := []client.Object{cr}
+	sch := makeTestReconcilerScheme()
+	cl := makeTestReconcilerClient(sch, resObjs)
+
+	err := ReconcilePrincipalDeployment(cl, testCompName, saName, cr, sch, tlsprofile.TLSConfigProfile{})
+	assert.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: generateAgentResourceName(cr.Name, testCompName), Namespace: cr.Namespace}
+	assert.NoError(t, cl.Get(context.TODO(), key, deployment))
+	assert.Equal(t, intstr.FromInt32(0), *deployment.Spec.Strategy.RollingUpdate.MaxUnavailable)
+	assert.Equal(t, intstr.FromInt32(1), *deployment.Spec.Strategy.RollingUpdate.MaxSurge)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "/healthz", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, intstr.FromString(PrincipalHealthzServicePortName), container.ReadinessProbe.HTTPGet.Port)
+	assert.Equal(t, int64(principalPreStopSleepSeconds), container.Lifecycle.PreStop.Sleep.Seconds)
+}
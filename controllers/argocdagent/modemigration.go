@@ -0,0 +1,74 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdagent
+
+import (
+	"context"
+	"fmt"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// MigrateAgentApplications labels the Applications of the given namespaces with the given mode, which owns them once
+// the agent migrated to it, so that they are kept instead of being recreated. It returns the number of Applications.
+func MigrateAgentApplications(ctx context.Context, c client.Client, namespaces []string, mode argoproj.AgentMode) (int32, error) {
+	var total int32
+	for _, namespace := range namespaces {
+		apps := &argocdv1alpha1.ApplicationList{}
+		if err := c.List(ctx, apps, client.InNamespace(namespace)); err != nil {
+			return total, fmt.Errorf("failed to list the applications of namespace %s: %w", namespace, err)
+		}
+		for i := range apps.Items {
+			app := &apps.Items[i]
+			total++
+			if app.Labels[common.ArgoCDAgentModeLabel] == string(mode) {
+				continue
+			}
+			if app.Labels == nil {
+				app.Labels = map[string]string{}
+			}
+			app.Labels[common.ArgoCDAgentModeLabel] = string(mode)
+			argoutil.LogResourceUpdate(log, app, "labelling application with agent mode", string(mode))
+			if err := c.Update(ctx, app); err != nil {
+				return total, fmt.Errorf("failed to label application %s in namespace %s: %w", app.Name, namespace, err)
+			}
+		}
+	}
+	return total, nil
+}
+
+// CountAgentApplications returns the number of Applications of the given namespaces, and how many of them are labelled
+// with the given mode.
+func CountAgentApplications(ctx context.Context, c client.Client, namespaces []string, mode argoproj.AgentMode) (int32, int32, error) {
+	var total, migrated int32
+	for _, namespace := range namespaces {
+		apps := &argocdv1alpha1.ApplicationList{}
+		if err := c.List(ctx, apps, client.InNamespace(namespace)); err != nil {
+			return 0, 0, fmt.Errorf("failed to list the applications of namespace %s: %w", namespace, err)
+		}
+		for _, app := range apps.Items {
+			total++
+			if app.Labels[common.ArgoCDAgentModeLabel] == string(mode) {
+				migrated++
+			}
+		}
+	}
+	return total, migrated, nil
+}
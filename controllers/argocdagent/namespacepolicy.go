@@ -0,0 +1,413 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v3/util/glob"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// namespacePatternRegexp matches the names of namespaces and agents, which may contain the `*` and `?` wildcards.
+var namespacePatternRegexp = regexp.MustCompile(`^[a-z0-9*?]([-a-z0-9*?]*[a-z0-9*?])?$`)
+
+// hasNamespacePolicies returns whether the principal of the given ArgoCD declares namespace policies.
+func hasNamespacePolicies(cr *argoproj.ArgoCD) bool {
+	return hasNamespace(cr) && len(cr.Spec.ArgoCDAgent.Principal.Namespace.Policies) > 0
+}
+
+// ValidatePrincipalNamespacePolicies returns an error describing every problem found in the namespace policies of the
+// principal of the given ArgoCD, or nil if they are valid or not set.
+func ValidatePrincipalNamespacePolicies(cr *argoproj.ArgoCD) error {
+	if !hasNamespacePolicies(cr) {
+		return nil
+	}
+	namespace := cr.Spec.ArgoCDAgent.Principal.Namespace
+
+	var problems []string
+	agentPolicies := map[string]string{}
+	for _, policy := range namespace.Policies {
+		for _, agent := range policy.Agents {
+			if !isValidNamespacePattern(agent) {
+				problems = append(problems, fmt.Sprintf("policy %s: agent %q is not a valid agent name pattern", policy.Name, agent))
+				continue
+			}
+			if !isNamespacePatternGlob(agent) {
+				agentPolicies[agent] = policy.Name
+			}
+		}
+		for _, ns := range policy.Namespaces {
+			if !isValidNamespacePattern(ns) {
+				problems = append(problems, fmt.Sprintf("policy %s: namespace %q is not a valid namespace pattern", policy.Name, ns))
+			}
+		}
+	}
+
+	// The namespace of an agent is reserved to the policies of this agent, another agent would otherwise be able to
+	// manage its Applications.
+	for _, policy := range namespace.Policies {
+		for _, ns := range policy.Namespaces {
+			owner, ok := agentPolicies[ns]
+			if ok && owner != policy.Name && !matchesNamespacePolicy(policy, ns) {
+				problems = append(problems, fmt.Sprintf("policy %s: namespace %s is the namespace of agent %s of policy %s", policy.Name, ns, ns, owner))
+			}
+		}
+	}
+
+	for _, label := range namespace.NamespaceCreateLabels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			problems = append(problems, fmt.Sprintf("namespace create label %q is not of the form key=value", label))
+			continue
+		}
+		for _, msg := range validation.IsQualifiedName(key) {
+			problems = append(problems, fmt.Sprintf("namespace create label %q: %s", label, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			problems = append(problems, fmt.Sprintf("namespace create label %q: %s", label, msg))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func isValidNamespacePattern(pattern string) bool {
+	return len(pattern) <= validation.DNS1123LabelMaxLength && namespacePatternRegexp.MatchString(pattern)
+}
+
+func isNamespacePatternGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?")
+}
+
+// matchesNamespacePolicy returns whether the given agent is one of the agents of the given policy.
+func matchesNamespacePolicy(policy argoproj.PrincipalNamespacePolicy, agent string) bool {
+	return glob.MatchStringInList(policy.Agents, agent, glob.GLOB)
+}
+
+// getNamespacePolicyPatterns returns the patterns of the namespaces the principal is allowed to use according to the
+// namespace policies of the given ArgoCD: the namespaces of the agents and the additional namespaces of the policies.
+func getNamespacePolicyPatterns(cr *argoproj.ArgoCD) []string {
+	var patterns []string
+	seen := map[string]bool{}
+	for _, policy := range cr.Spec.ArgoCDAgent.Principal.Namespace.Policies {
+		for _, pattern := range append(append([]string{}, policy.Agents...), policy.Namespaces...) {
+			if !seen[pattern] {
+				seen[pattern] = true
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+	return patterns
+}
+
+// getNamespacePolicyCreatePattern returns the regular expression matching the namespaces the principal is allowed to
+// create, which are the namespaces of the agents of the policies allowing it. It is empty when no policy allows it.
+func getNamespacePolicyCreatePattern(cr *argoproj.ArgoCD) string {
+	var alternatives []string
+	for _, policy := range cr.Spec.ArgoCDAgent.Principal.Namespace.Policies {
+		if !policy.CreateNamespace {
+			continue
+		}
+		for _, agent := range policy.Agents {
+			alternatives = append(alternatives, globToRegexp(agent))
+		}
+	}
+	if len(alternatives) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("^(%s)$", strings.Join(alternatives, "|"))
+}
+
+// globToRegexp converts a pattern supporting the `*` and `?` wildcards to a regular expression.
+func globToRegexp(pattern string) string {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	return strings.ReplaceAll(quoted, `\?`, ".")
+}
+
+// IsPrincipalNamespaceAllowed returns whether the principal of the given ArgoCD is allowed to use the given namespace
+// according to its namespace policies. It always returns false when no policy is set.
+func IsPrincipalNamespaceAllowed(cr *argoproj.ArgoCD, namespace string) bool {
+	if !hasNamespacePolicies(cr) || !cr.Spec.ArgoCDAgent.Principal.IsEnabled() {
+		return false
+	}
+	return glob.MatchStringInList(getNamespacePolicyPatterns(cr), namespace, glob.GLOB)
+}
+
+// GetAgentNamespacesStatus returns, for each agent having a cluster Secret in the namespace of the given ArgoCD, the
+// existing namespaces it is allowed to use and the namespace the principal would create for it, according to the
+// namespace policies of the principal. Nothing is created, the report only reflects what the policies allow.
+func GetAgentNamespacesStatus(ctx context.Context, c client.Client, cr *argoproj.ArgoCD) ([]argoproj.ArgoCDAgentNamespacesStatus, error) {
+	agents, err := listAgentNames(ctx, c, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaces); err != nil {
+		return nil, err
+	}
+	existing := map[string]bool{}
+	for _, ns := range namespaces.Items {
+		existing[ns.Name] = true
+	}
+
+	statuses := []argoproj.ArgoCDAgentNamespacesStatus{}
+	for _, agent := range agents {
+		status := argoproj.ArgoCDAgentNamespacesStatus{Name: agent}
+		var patterns []string
+		create := false
+		for _, policy := range cr.Spec.ArgoCDAgent.Principal.Namespace.Policies {
+			if !matchesNamespacePolicy(policy, agent) {
+				continue
+			}
+			status.Policies = append(status.Policies, policy.Name)
+			patterns = append(patterns, policy.Namespaces...)
+			create = create || policy.CreateNamespace
+		}
+		if len(status.Policies) > 0 {
+			patterns = append(patterns, agent)
+			for _, ns := range namespaces.Items {
+				if glob.MatchStringInList(patterns, ns.Name, glob.GLOB) {
+					status.Namespaces = append(status.Namespaces, ns.Name)
+				}
+			}
+			sort.Strings(status.Namespaces)
+			if create && !existing[agent] {
+				status.CreateNamespace = agent
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// listAgentNames returns the sorted names of the agents having a cluster Secret in the namespace of the given ArgoCD.
+func listAgentNames(ctx context.Context, c client.Client, cr *argoproj.ArgoCD) ([]string, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(cr.Namespace), client.HasLabels{common.ArgoCDAgentNameLabel},
+		client.MatchingLabels{common.ArgoCDSecretTypeLabel: "cluster"}); err != nil {
+		return nil, err
+	}
+	var names []string
+	seen := map[string]bool{}
+	for _, secret := range secrets.Items {
+		name := secret.Labels[common.ArgoCDAgentNameLabel]
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ReconcilePrincipalNamespaceRoles manages the Roles and RoleBindings granting the principal write access to the Argo
+// CD resources of the namespaces allowed by its namespace policies, which it can read cluster-wide. The namespace of
+// the ArgoCD is always granted, the other existing namespaces allowed by the policies only when the ArgoCD is in a
+// cluster config namespace. A namespace created by the principal is granted once its creation is observed. The Roles
+// and RoleBindings of the namespaces that are no longer allowed are deleted.
+func ReconcilePrincipalNamespaceRoles(c client.Client, compName string, sa *corev1.ServiceAccount, cr *argoproj.ArgoCD) error {
+	desired := map[string]bool{}
+	if hasPrincipal(cr) && cr.Spec.ArgoCDAgent.Principal.IsEnabled() && hasNamespacePolicies(cr) {
+		if err := ValidatePrincipalNamespacePolicies(cr); err != nil {
+			log.Info("skipping the principal namespace roles as the namespace policies are invalid", "error", err.Error())
+			return nil
+		}
+
+		desired[cr.Namespace] = true
+		if argoutil.IsNamespaceClusterConfigNamespace(cr.Namespace) {
+			namespaces := &corev1.NamespaceList{}
+			if err := c.List(context.TODO(), namespaces); err != nil {
+				return fmt.Errorf("failed to list namespaces for the principal namespace policies: %w", err)
+			}
+			for _, ns := range namespaces.Items {
+				if ns.DeletionTimestamp == nil && IsPrincipalNamespaceAllowed(cr, ns.Name) {
+					desired[ns.Name] = true
+				}
+			}
+		}
+	}
+
+	if err := deletePrincipalNamespaceRoles(c, cr, desired); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(desired))
+	for ns := range desired {
+		names = append(names, ns)
+	}
+	sort.Strings(names)
+	for _, ns := range names {
+		if err := reconcilePrincipalNamespaceRole(c, compName, ns, cr); err != nil {
+			return err
+		}
+		if err := reconcilePrincipalNamespaceRoleBinding(c, compName, ns, sa, cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeletePrincipalNamespaceRoles deletes the Roles and RoleBindings granting the principal of the given ArgoCD access
+// to the namespaces of its namespace policies, which are not owned by the ArgoCD outside of its namespace.
+func DeletePrincipalNamespaceRoles(c client.Client, cr *argoproj.ArgoCD) error {
+	return deletePrincipalNamespaceRoles(c, cr, nil)
+}
+
+func deletePrincipalNamespaceRoles(c client.Client, cr *argoproj.ArgoCD, keep map[string]bool) error {
+	selector := client.MatchingLabels{
+		common.ArgoCDKeyManagedBy:                 cr.Name,
+		common.ArgoCDAgentPrincipalNamespaceLabel: cr.Namespace,
+	}
+
+	roles := &v1.RoleList{}
+	if err := c.List(context.TODO(), roles, selector); err != nil {
+		return fmt.Errorf("failed to list principal namespace roles: %w", err)
+	}
+	for i := range roles.Items {
+		role := &roles.Items[i]
+		if keep[role.Namespace] {
+			continue
+		}
+		argoutil.LogResourceDeletion(log, role, "namespace is no longer allowed by the principal namespace policies")
+		if err := c.Delete(context.TODO(), role); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete principal namespace role %s in namespace %s: %w", role.Name, role.Namespace, err)
+		}
+	}
+
+	roleBindings := &v1.RoleBindingList{}
+	if err := c.List(context.TODO(), roleBindings, selector); err != nil {
+		return fmt.Errorf("failed to list principal namespace roleBindings: %w", err)
+	}
+	for i := range roleBindings.Items {
+		roleBinding := &roleBindings.Items[i]
+		if keep[roleBinding.Namespace] {
+			continue
+		}
+		argoutil.LogResourceDeletion(log, roleBinding, "namespace is no longer allowed by the principal namespace policies")
+		if err := c.Delete(context.TODO(), roleBinding); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete principal namespace roleBinding %s in namespace %s: %w", roleBinding.Name, roleBinding.Namespace, err)
+		}
+	}
+	return nil
+}
+
+// buildPolicyRuleForPrincipalNamespace grants the write verbs on the Argo CD resources of a namespace allowed by the
+// namespace policies of the principal.
+func buildPolicyRuleForPrincipalNamespace() []v1.PolicyRule {
+	return buildPolicyRuleForArgoCDResources([]string{
+		"create",
+		"update",
+		"delete",
+		"patch",
+	})
+}
+
+func reconcilePrincipalNamespaceRole(c client.Client, compName, namespace string, cr *argoproj.ArgoCD) error {
+	role := &v1.Role{ObjectMeta: buildPrincipalNamespaceObjectMeta(compName, namespace, cr)}
+	expectedPolicyRule := buildPolicyRuleForPrincipalNamespace()
+
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: role.Name, Namespace: namespace}, role); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get existing principal namespace role %s in namespace %s: %w", role.Name, namespace, err)
+		}
+		role.Rules = expectedPolicyRule
+		argoutil.LogResourceCreation(log, role)
+		if err := c.Create(context.TODO(), role); err != nil {
+			return fmt.Errorf("failed to create principal namespace role %s in namespace %s: %w", role.Name, namespace, err)
+		}
+		return nil
+	}
+
+	if !reflect.DeepEqual(expectedPolicyRule, role.Rules) {
+		role.Rules = expectedPolicyRule
+		argoutil.LogResourceUpdate(log, role, "principal namespace role rules are being updated")
+		if err := c.Update(context.TODO(), role); err != nil {
+			return fmt.Errorf("failed to update principal namespace role %s in namespace %s: %w", role.Name, namespace, err)
+		}
+	}
+	return nil
+}
+
+func reconcilePrincipalNamespaceRoleBinding(c client.Client, compName, namespace string, sa *corev1.ServiceAccount, cr *argoproj.ArgoCD) error {
+	roleBinding := &v1.RoleBinding{ObjectMeta: buildPrincipalNamespaceObjectMeta(compName, namespace, cr)}
+	expectedSubjects := buildSubjects(sa, cr)
+	expectedRoleRef := buildRoleRef(roleBinding.Name, "Role")
+
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: roleBinding.Name, Namespace: namespace}, roleBinding); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get existing principal namespace roleBinding %s in namespace %s: %w", roleBinding.Name, namespace, err)
+		}
+		roleBinding.Subjects = expectedSubjects
+		roleBinding.RoleRef = expectedRoleRef
+		argoutil.LogResourceCreation(log, roleBinding)
+		if err := c.Create(context.TODO(), roleBinding); err != nil {
+			return fmt.Errorf("failed to create principal namespace roleBinding %s in namespace %s: %w", roleBinding.Name, namespace, err)
+		}
+		return nil
+	}
+
+	if !reflect.DeepEqual(roleBinding.RoleRef, expectedRoleRef) {
+		// The role reference of a RoleBinding cannot be updated.
+		argoutil.LogResourceDeletion(log, roleBinding, "principal namespace roleBinding role reference changed")
+		if err := c.Delete(context.TODO(), roleBinding); err != nil {
+			return fmt.Errorf("failed to delete principal namespace roleBinding %s in namespace %s: %w", roleBinding.Name, namespace, err)
+		}
+		return reconcilePrincipalNamespaceRoleBinding(c, compName, namespace, sa, cr)
+	}
+
+	if !reflect.DeepEqual(roleBinding.Subjects, expectedSubjects) {
+		roleBinding.Subjects = expectedSubjects
+		argoutil.LogResourceUpdate(log, roleBinding, "principal namespace roleBinding is being updated")
+		if err := c.Update(context.TODO(), roleBinding); err != nil {
+			return fmt.Errorf("failed to update principal namespace roleBinding %s in namespace %s: %w", roleBinding.Name, namespace, err)
+		}
+	}
+	return nil
+}
+
+// buildPrincipalNamespaceObjectMeta returns the metadata of the Role and RoleBinding of the principal in the given
+// namespace. They are named after the namespace of the ArgoCD as well, since several principals may be granted access
+// to the same namespace.
+func buildPrincipalNamespaceObjectMeta(compName, namespace string, cr *argoproj.ArgoCD) metav1.ObjectMeta {
+	labels := buildLabelsForAgentPrincipal(cr.Name, compName)
+	labels[common.ArgoCDAgentPrincipalNamespaceLabel] = cr.Namespace
+	return metav1.ObjectMeta{
+		Name:      generateAgentResourceName(cr.Name+"-"+cr.Namespace, compName),
+		Namespace: namespace,
+		Labels:    labels,
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/tlsprofile"
+)
+
+func withNamespacePolicies(policies ...argoproj.PrincipalNamespacePolicy) argoCDOpt {
+	return func(a *argoproj.ArgoCD) {
+		if a.Spec.ArgoCDAgent.Principal.Namespace == nil {
+			a.Spec.ArgoCDAgent.Principal.Namespace = &argoproj.PrincipalNamespaceSpec{}
+		}
+		a.Spec.ArgoCDAgent.Principal.Namespace.Policies = policies
+	}
+}
+
+func makeTestNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func makeTestNamespacePolicies() []argoproj.PrincipalNamespacePolicy {
+	return []argoproj.PrincipalNamespacePolicy{
+		{
+			Name:            "teams",
+			Agents:          []string{"team-*"},
+			Namespaces:      []string{"shared-apps"},
+			CreateNamespace: true,
+		},
+		{
+			Name:   "edge",
+			Agents: []string{"edge-1", "edge-2"},
+		},
+	}
+}
+
+func TestValidatePrincipalNamespacePolicies(t *testing.T) {
+	t.Run("valid policies", func(t *testing.T) {
+		cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(makeTestNamespacePolicies()...), func(a *argoproj.ArgoCD) {
+			a.Spec.ArgoCDAgent.Principal.Namespace.NamespaceCreateLabels = []string{"example.com/team=payments"}
+		})
+		assert.NoError(t, ValidatePrincipalNamespacePolicies(cr))
+	})
+
+	t.Run("no policies", func(t *testing.T) {
+		assert.NoError(t, ValidatePrincipalNamespacePolicies(makeTestArgoCD(withPrincipalEnabled(true))))
+	})
+
+	t.Run("invalid patterns and labels", func(t *testing.T) {
+		cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(argoproj.PrincipalNamespacePolicy{
+			Name:       "invalid",
+			Agents:     []string{"Team_A"},
+			Namespaces: []string{"apps-"},
+		}), func(a *argoproj.ArgoCD) {
+			a.Spec.ArgoCDAgent.Principal.Namespace.NamespaceCreateLabels = []string{"team", "team=a b"}
+		})
+		err := ValidatePrincipalNamespacePolicies(cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `agent "Team_A" is not a valid agent name pattern`)
+		assert.Contains(t, err.Error(), `namespace "apps-" is not a valid namespace pattern`)
+		assert.Contains(t, err.Error(), `namespace create label "team" is not of the form key=value`)
+		assert.Contains(t, err.Error(), `namespace create label "team=a b"`)
+	})
+
+	t.Run("namespace of another agent", func(t *testing.T) {
+		cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(makeTestNamespacePolicies()[1], argoproj.PrincipalNamespacePolicy{
+			Name:       "shared",
+			Agents:     []string{"edge-3"},
+			Namespaces: []string{"edge-1"},
+		}))
+		err := ValidatePrincipalNamespacePolicies(cr)
+		require.Error(t, err)
+		assert.Equal(t, "policy shared: namespace edge-1 is the namespace of agent edge-1 of policy edge", err.Error())
+	})
+}
+
+func TestNamespacePolicyPrincipalEnv(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(makeTestNamespacePolicies()...))
+
+	assert.Equal(t, "team-*,shared-apps,edge-1,edge-2", getPrincipalAllowedNamespaces(cr))
+	assert.Equal(t, "true", getPrincipalNamespaceCreateEnable(cr))
+	assert.Equal(t, "^(team-.*)$", getPrincipalNamespaceCreatePattern(cr))
+
+	cr.Spec.ArgoCDAgent.Principal.Namespace.Policies[0].CreateNamespace = false
+	assert.Equal(t, "false", getPrincipalNamespaceCreateEnable(cr))
+	assert.Equal(t, "", getPrincipalNamespaceCreatePattern(cr))
+}
+
+func TestIsPrincipalNamespaceAllowed(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(makeTestNamespacePolicies()...))
+
+	assert.True(t, IsPrincipalNamespaceAllowed(cr, "team-a"))
+	assert.True(t, IsPrincipalNamespaceAllowed(cr, "shared-apps"))
+	assert.True(t, IsPrincipalNamespaceAllowed(cr, "edge-2"))
+	assert.False(t, IsPrincipalNamespaceAllowed(cr, "edge-3"))
+	assert.False(t, IsPrincipalNamespaceAllowed(makeTestArgoCD(withPrincipalEnabled(true)), "team-a"))
+}
+
+func TestGetAgentNamespacesStatus(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(makeTestNamespacePolicies()...))
+	resObjs := []client.Object{
+		cr,
+		makeTestAgentClusterSecret("team-a"),
+		makeTestAgentClusterSecret("team-b"),
+		makeTestAgentClusterSecret("edge-1"),
+		makeTestAgentClusterSecret("other"),
+		makeTestNamespace("team-a"),
+		makeTestNamespace("shared-apps"),
+		makeTestNamespace("edge-1"),
+	}
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), resObjs)
+
+	statuses, err := GetAgentNamespacesStatus(context.TODO(), cl, cr)
+	require.NoError(t, err)
+	assert.Equal(t, []argoproj.ArgoCDAgentNamespacesStatus{
+		{Name: "edge-1", Policies: []string{"edge"}, Namespaces: []string{"edge-1"}},
+		{Name: "other"},
+		{Name: "team-a", Policies: []string{"teams"}, Namespaces: []string{"shared-apps", "team-a"}},
+		{Name: "team-b", Policies: []string{"teams"}, Namespaces: []string{"shared-apps"}, CreateNamespace: "team-b"},
+	}, statuses)
+}
+
+func TestReconcilePrincipalNamespaceRoles(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(makeTestNamespacePolicies()...))
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", cr.Namespace)
+
+	resObjs := []client.Object{
+		cr,
+		makeTestNamespace(cr.Namespace),
+		makeTestNamespace("team-a"),
+		makeTestNamespace("shared-apps"),
+		makeTestNamespace("unrelated"),
+	}
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), resObjs)
+	sa := makeTestServiceAccount()
+	name := generateAgentResourceName(cr.Name+"-"+cr.Namespace, testCompName)
+
+	require.NoError(t, ReconcilePrincipalNamespaceRoles(cl, testCompName, sa, cr))
+
+	roles := &v1.RoleList{}
+	require.NoError(t, cl.List(context.TODO(), roles, client.MatchingLabels{common.ArgoCDAgentPrincipalNamespaceLabel: cr.Namespace}))
+	var namespaces []string
+	for _, role := range roles.Items {
+		namespaces = append(namespaces, role.Namespace)
+		assert.Equal(t, name, role.Name)
+		assert.Equal(t, buildPolicyRuleForPrincipalNamespace(), role.Rules)
+	}
+	assert.ElementsMatch(t, []string{cr.Namespace, "team-a", "shared-apps"}, namespaces)
+
+	roleBindings := &v1.RoleBindingList{}
+	require.NoError(t, cl.List(context.TODO(), roleBindings, client.MatchingLabels{common.ArgoCDAgentPrincipalNamespaceLabel: cr.Namespace}))
+	assert.Len(t, roleBindings.Items, 3)
+	for _, roleBinding := range roleBindings.Items {
+		assert.Equal(t, buildRoleRef(name, "Role"), roleBinding.RoleRef)
+		assert.Equal(t, buildSubjects(sa, cr), roleBinding.Subjects)
+	}
+
+	// Namespaces no longer allowed by the policies lose their Role and RoleBinding.
+	cr.Spec.ArgoCDAgent.Principal.Namespace.Policies = makeTestNamespacePolicies()[1:]
+	require.NoError(t, ReconcilePrincipalNamespaceRoles(cl, testCompName, sa, cr))
+	require.NoError(t, cl.List(context.TODO(), roles, client.MatchingLabels{common.ArgoCDAgentPrincipalNamespaceLabel: cr.Namespace}))
+	require.Len(t, roles.Items, 1)
+	assert.Equal(t, cr.Namespace, roles.Items[0].Namespace)
+
+	require.NoError(t, DeletePrincipalNamespaceRoles(cl, cr))
+	require.NoError(t, cl.List(context.TODO(), roles, client.MatchingLabels{common.ArgoCDAgentPrincipalNamespaceLabel: cr.Namespace}))
+	assert.Empty(t, roles.Items)
+	require.NoError(t, cl.List(context.TODO(), roleBindings, client.MatchingLabels{common.ArgoCDAgentPrincipalNamespaceLabel: cr.Namespace}))
+	assert.Empty(t, roleBindings.Items)
+}
+
+func TestReconcilePrincipalDeployment_InvalidNamespacePolicies(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(makeTestNamespacePolicies()...))
+	saName := generateAgentResourceName(cr.Name, testCompName)
+	sch := makeTestReconcilerScheme()
+	cl := makeTestReconcilerClient(sch, []client.Object{cr})
+	key := client.ObjectKey{Name: generateAgentResourceName(cr.Name, testCompName), Namespace: cr.Namespace}
+
+	getEnv := func(deployment *appsv1.Deployment) map[string]string {
+		env := map[string]string{}
+		for _, e := range deployment.Spec.Template.Spec.Containers[0].Env {
+			env[e.Name] = e.Value
+		}
+		return env
+	}
+
+	require.NoError(t, ReconcilePrincipalDeployment(cl, testCompName, saName, cr, sch, tlsprofile.TLSConfigProfile{}))
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, cl.Get(context.TODO(), key, deployment))
+	allowed := getEnv(deployment)[EnvArgoCDPrincipalAllowedNamespaces]
+	assert.NotEmpty(t, allowed)
+
+	// The rest of the principal is updated while the namespaces it serves are kept.
+	cr.Spec.ArgoCDAgent.Principal.Namespace.Policies[0].Agents = []string{"Team_A"}
+	cr.Spec.ArgoCDAgent.Principal.LogLevel = "debug"
+	require.Error(t, ValidatePrincipalNamespacePolicies(cr))
+	require.NoError(t, ReconcilePrincipalDeployment(cl, testCompName, saName, cr, sch, tlsprofile.TLSConfigProfile{}))
+	require.NoError(t, cl.Get(context.TODO(), key, deployment))
+	env := getEnv(deployment)
+	assert.Equal(t, "debug", env[EnvArgoCDPrincipalLogLevel])
+	assert.Equal(t, allowed, env[EnvArgoCDPrincipalAllowedNamespaces])
+	assert.Equal(t, "true", env[EnvArgoCDPrincipalNamespaceCreateEnable])
+
+	// A new principal is allowed no other namespace.
+	require.NoError(t, cl.Delete(context.TODO(), deployment))
+	require.NoError(t, ReconcilePrincipalDeployment(cl, testCompName, saName, cr, sch, tlsprofile.TLSConfigProfile{}))
+	deployment = &appsv1.Deployment{}
+	require.NoError(t, cl.Get(context.TODO(), key, deployment))
+	env = getEnv(deployment)
+	assert.Empty(t, env[EnvArgoCDPrincipalAllowedNamespaces])
+	assert.Equal(t, "false", env[EnvArgoCDPrincipalNamespaceCreateEnable])
+	assert.Empty(t, env[EnvArgoCDPrincipalNamespaceCreatePattern])
+}
+
+func TestBuildPolicyRuleForClusterRole_NamespacePolicies(t *testing.T) {
+	// The Argo CD resources stay readable cluster-wide for the informers of the principal.
+	cr := makeTestArgoCD(withPrincipalEnabled(true), withNamespacePolicies(makeTestNamespacePolicies()...))
+	readRules := buildPolicyRuleForArgoCDResources([]string{"get", "list", "watch"})
+	assert.Equal(t, append(readRules, buildPolicyRuleForNamespaces([]string{"create", "get", "list", "watch"})),
+		buildPolicyRuleForClusterRole(cr))
+
+	cr.Spec.ArgoCDAgent.Principal.Namespace.Policies = makeTestNamespacePolicies()[1:]
+	assert.Equal(t, append(readRules, buildPolicyRuleForNamespaces([]string{"get", "list", "watch"})),
+		buildPolicyRuleForClusterRole(cr))
+}
@@ -0,0 +1,847 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdagent
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"slices"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// PrincipalPKICA identifies the CA in the status of the principal PKI
+	PrincipalPKICA = "ca"
+	// PrincipalPKIPrincipalTLS identifies the principal gRPC certificate in the status of the principal PKI
+	PrincipalPKIPrincipalTLS = "principal-tls"
+	// PrincipalPKIResourceProxyTLS identifies the resource proxy certificate in the status of the principal PKI
+	PrincipalPKIResourceProxyTLS = "resource-proxy-tls"
+	// PrincipalPKIJWT identifies the JWT signing key in the status of the principal PKI
+	PrincipalPKIJWT = "jwt"
+
+	// PrincipalJWTKey is the key of the JWT signing key in its Secret
+	PrincipalJWTKey = "jwt.key"
+
+	defaultPrincipalCertificateValidity = common.ArgoCDDuration365Days
+	defaultPrincipalCAValidity          = 10 * common.ArgoCDDuration365Days
+	defaultPrincipalRenewBefore         = 30 * 24 * time.Hour
+
+	// principalCAGraceRecheckInterval is how often the retirement of the previous CA is checked during a CA rotation
+	principalCAGraceRecheckInterval = 5 * time.Minute
+)
+
+// principalCertificateSANs are the subject alternative names of a certificate issued for the principal.
+type principalCertificateSANs struct {
+	dnsNames    []string
+	ipAddresses []net.IP
+}
+
+// ReconcilePrincipalPKI ensures the CA, the principal and resource proxy certificates and the JWT signing key used by
+// the principal. Secrets that are not controlled by the ArgoCD are provided by the user and are never modified.
+// It returns the duration after which the next certificate or key managed by the operator is due for renewal.
+func ReconcilePrincipalPKI(client client.Client, compName string, cr *argoproj.ArgoCD, scheme *runtime.Scheme) (time.Duration, error) {
+	if !hasPrincipal(cr) || !cr.Spec.ArgoCDAgent.Principal.IsEnabled() {
+		return 0, nil
+	}
+
+	now := time.Now()
+	var requeueAfter time.Duration
+	next := func(d time.Duration) {
+		if d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+			requeueAfter = d
+		}
+	}
+
+	caSecret, d, err := reconcilePrincipalCA(client, cr, scheme, now)
+	if err != nil {
+		return 0, err
+	}
+	next(d)
+
+	caCert, caKey := parsePrincipalCA(caSecret)
+	if err := reconcilePrincipalResourceProxyCA(client, cr, scheme, caSecret); err != nil {
+		return 0, err
+	}
+
+	if !isPrincipalTLSInsecureGenerate(cr) {
+		sans, err := getPrincipalSANs(client, compName, cr)
+		if err != nil {
+			return 0, err
+		}
+		d, err := reconcilePrincipalCertificate(client, cr, scheme, getPrincipalTLSServerSecretName(cr), generateAgentResourceName(cr.Name, compName), sans, caCert, caKey, caSecret.Data[corev1.ServiceAccountRootCAKey], now)
+		if err != nil {
+			return 0, err
+		}
+		next(d)
+	}
+
+	sans, err := getServiceSANs(client, cr, generateAgentResourceName(cr.Name, compName+"-resource-proxy"))
+	if err != nil {
+		return 0, err
+	}
+	d, err = reconcilePrincipalCertificate(client, cr, scheme, getPrincipalResourceProxySecretName(cr), generateAgentResourceName(cr.Name, compName+"-resource-proxy"), sans, caCert, caKey, caSecret.Data[corev1.ServiceAccountRootCAKey], now)
+	if err != nil {
+		return 0, err
+	}
+	next(d)
+
+	if !isPrincipalJWTInsecureGenerate(cr) {
+		d, err := reconcilePrincipalJWTKey(client, cr, scheme, now)
+		if err != nil {
+			return 0, err
+		}
+		next(d)
+	}
+
+	return requeueAfter, nil
+}
+
+// reconcilePrincipalCA ensures the CA Secret of the principal, renewing it when it is controlled by the ArgoCD and due.
+// The ca.crt of a renewed CA is a trust bundle holding the new CA followed by the previous one, which is retired by
+// reconcilePrincipalCATrustBundle once the client certificates of the agents were re-issued from the new CA.
+func reconcilePrincipalCA(client client.Client, cr *argoproj.ArgoCD, scheme *runtime.Scheme, now time.Time) (*corev1.Secret, time.Duration, error) {
+	name := getPrincipalTlsServerRootCASecretName(cr)
+	secret, exists, err := fetchPrincipalSecret(client, cr, name)
+	if err != nil {
+		return nil, 0, err
+	}
+	if exists && !metav1.IsControlledBy(secret, cr) {
+		return secret, 0, nil
+	}
+
+	var previous *x509.Certificate
+	if exists {
+		if cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey]); err == nil {
+			if renewAt := getRenewAt(cert, getPrincipalRenewBefore(cr)); now.Before(renewAt) {
+				d, err := reconcilePrincipalCATrustBundle(client, cr, secret, cert, now)
+				if err != nil {
+					return nil, 0, err
+				}
+				if d == 0 || renewAt.Sub(now) < d {
+					d = renewAt.Sub(now)
+				}
+				return secret, d, nil
+			}
+			if now.Before(cert.NotAfter) {
+				previous = cert
+			}
+		}
+	}
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, 0, err
+	}
+	cert, err := newPrincipalCACertificate(cr, key, now.Add(getDurationOrDefault(caValidity(cr), defaultPrincipalCAValidity)))
+	if err != nil {
+		return nil, 0, err
+	}
+	// The agents and the principal keep trusting the previous CA until it is retired.
+	bundle := argoutil.EncodeCertificatePEM(cert)
+	if previous != nil {
+		bundle = append(bundle, argoutil.EncodeCertificatePEM(previous)...)
+		log.Info(fmt.Sprintf("renewed the CA %s of the principal, the previous CA is trusted until the client certificates of the agents are re-issued", name))
+	}
+	data := map[string][]byte{
+		corev1.TLSCertKey:              argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey:        argoutil.EncodePrivateKeyPEM(key),
+		corev1.ServiceAccountRootCAKey: bundle,
+	}
+	if err := writePrincipalSecret(client, cr, scheme, secret, exists, corev1.SecretTypeTLS, data, nil); err != nil {
+		return nil, 0, err
+	}
+	if previous != nil {
+		return secret, principalCAGraceRecheckInterval, nil
+	}
+	return secret, getRenewAt(cert, getPrincipalRenewBefore(cr)).Sub(now), nil
+}
+
+// reconcilePrincipalCATrustBundle retires the previous CAs from the trust bundle of the given CA Secret once they
+// expired, or once the client certificates of all the agents registered through an ArgoCDAgentRegistration were
+// re-issued from the given current CA. It returns the duration after which the retirement is checked again, or 0 if
+// the trust bundle holds no previous CA.
+func reconcilePrincipalCATrustBundle(client client.Client, cr *argoproj.ArgoCD, secret *corev1.Secret, caCert *x509.Certificate, now time.Time) (time.Duration, error) {
+	var previous []*x509.Certificate
+	expired := false
+	for _, cert := range parsePEMEncodedCerts(secret.Data[corev1.ServiceAccountRootCAKey]) {
+		if cert.Equal(caCert) {
+			continue
+		}
+		if !now.Before(cert.NotAfter) {
+			expired = true
+			continue
+		}
+		previous = append(previous, cert)
+	}
+	if len(previous) == 0 && !expired {
+		return 0, nil
+	}
+
+	if len(previous) > 0 {
+		reissued, err := areAgentClientCertificatesIssuedBy(client, cr, caCert)
+		if err != nil {
+			return 0, err
+		}
+		if !reissued {
+			if !expired {
+				return principalCAGraceRecheckInterval, nil
+			}
+		} else {
+			previous = nil
+		}
+	}
+
+	bundle := argoutil.EncodeCertificatePEM(caCert)
+	for _, cert := range previous {
+		bundle = append(bundle, argoutil.EncodeCertificatePEM(cert)...)
+	}
+	secret.Data[corev1.ServiceAccountRootCAKey] = bundle
+	argoutil.LogResourceUpdate(log, secret, "retiring the previous CA of the principal")
+	if err := client.Update(context.TODO(), secret); err != nil {
+		return 0, fmt.Errorf("failed to update secret %s in namespace %s: %v", secret.Name, secret.Namespace, err)
+	}
+	if len(previous) > 0 {
+		return principalCAGraceRecheckInterval, nil
+	}
+	return 0, nil
+}
+
+// areAgentClientCertificatesIssuedBy returns whether the client certificates in the bootstrap Secrets of all the
+// ArgoCDAgentRegistrations of the namespace of the given ArgoCD are signed by the given CA.
+func areAgentClientCertificatesIssuedBy(c client.Client, cr *argoproj.ArgoCD, caCert *x509.Certificate) (bool, error) {
+	registrations := &argoproj.ArgoCDAgentRegistrationList{}
+	if err := c.List(context.TODO(), registrations, client.InNamespace(cr.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list the ArgoCDAgentRegistrations in namespace %s: %v", cr.Namespace, err)
+	}
+	for _, registration := range registrations.Items {
+		if registration.Status.BootstrapSecretName == "" {
+			continue
+		}
+		secret, exists, err := fetchPrincipalSecret(c, cr, registration.Status.BootstrapSecretName)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			continue
+		}
+		cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+		if err != nil || cert.CheckSignatureFrom(caCert) != nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// parsePEMEncodedCerts returns the certificates of the given PEM encoded bundle, skipping the invalid ones.
+func parsePEMEncodedCerts(pemdata []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, pemdata = pem.Decode(pemdata)
+		if block == nil {
+			return certs
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// reconcilePrincipalResourceProxyCA copies the given CA Secret to the CA Secret of the resource proxy when the
+// resource proxy is configured with a different one that is controlled by the ArgoCD or does not exist.
+func reconcilePrincipalResourceProxyCA(client client.Client, cr *argoproj.ArgoCD, scheme *runtime.Scheme, caSecret *corev1.Secret) error {
+	name := getPrincipalResourceProxyCaSecretName(cr)
+	if name == caSecret.Name {
+		return nil
+	}
+	secret, exists, err := fetchPrincipalSecret(client, cr, name)
+	if err != nil {
+		return err
+	}
+	if exists && (!metav1.IsControlledBy(secret, cr) || reflect.DeepEqual(secret.Data, caSecret.Data)) {
+		return nil
+	}
+	return writePrincipalSecret(client, cr, scheme, secret, exists, corev1.SecretTypeTLS, caSecret.Data, nil)
+}
+
+// reconcilePrincipalCertificate ensures the certificate Secret with the given name is signed by the given CA for the
+// given subject alternative names, and re-issues it when it is controlled by the ArgoCD and due for renewal. The given
+// trust bundle of the CA is written to its ca.crt.
+func reconcilePrincipalCertificate(client client.Client, cr *argoproj.ArgoCD, scheme *runtime.Scheme, name, commonName string, sans principalCertificateSANs, caCert *x509.Certificate, caKey *rsa.PrivateKey, caBundle []byte, now time.Time) (time.Duration, error) {
+	secret, exists, err := fetchPrincipalSecret(client, cr, name)
+	if err != nil {
+		return 0, err
+	}
+	if exists && !metav1.IsControlledBy(secret, cr) {
+		return 0, nil
+	}
+	if caCert == nil || caKey == nil {
+		log.Info(fmt.Sprintf("skipping certificate %s of the principal as the CA %s has no usable certificate and key", name, getPrincipalTlsServerRootCASecretName(cr)))
+		return 0, nil
+	}
+
+	if exists {
+		if cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey]); err == nil &&
+			cert.CheckSignatureFrom(caCert) == nil && hasSANs(cert, sans) {
+			if renewAt := getRenewAt(cert, getPrincipalRenewBefore(cr)); now.Before(renewAt) {
+				return renewAt.Sub(now), nil
+			}
+		}
+	}
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return 0, err
+	}
+	notAfter := now.Add(getDurationOrDefault(certificateValidity(cr), defaultPrincipalCertificateValidity))
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+	cert, err := newPrincipalCertificate(commonName, sans, x509.ExtKeyUsageServerAuth, key, caCert, caKey, now, notAfter)
+	if err != nil {
+		return 0, err
+	}
+	if len(caBundle) == 0 {
+		caBundle = argoutil.EncodeCertificatePEM(caCert)
+	}
+	data := map[string][]byte{
+		corev1.TLSCertKey:              argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey:        argoutil.EncodePrivateKeyPEM(key),
+		corev1.ServiceAccountRootCAKey: caBundle,
+	}
+	if err := writePrincipalSecret(client, cr, scheme, secret, exists, corev1.SecretTypeTLS, data, nil); err != nil {
+		return 0, err
+	}
+	return getRenewAt(cert, getPrincipalRenewBefore(cr)).Sub(now), nil
+}
+
+// reconcilePrincipalJWTKey ensures the JWT signing key Secret of the principal, and generates a new key when it is
+// controlled by the ArgoCD and older than the configured validity.
+func reconcilePrincipalJWTKey(client client.Client, cr *argoproj.ArgoCD, scheme *runtime.Scheme, now time.Time) (time.Duration, error) {
+	secret, exists, err := fetchPrincipalSecret(client, cr, getPrincipalJWTSecretName(cr))
+	if err != nil {
+		return 0, err
+	}
+	if exists && !metav1.IsControlledBy(secret, cr) {
+		return 0, nil
+	}
+
+	if exists && len(secret.Data[PrincipalJWTKey]) > 0 {
+		notAfter := getJWTKeyNotAfter(cr, secret)
+		if notAfter == nil {
+			return 0, nil
+		}
+		if now.Before(*notAfter) {
+			return notAfter.Sub(now), nil
+		}
+	}
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return 0, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return 0, err
+	}
+	data := map[string][]byte{
+		PrincipalJWTKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+	}
+	annotations := map[string]string{
+		common.AnnotationAgentJWTKeyRotatedAt: now.UTC().Format(time.RFC3339Nano),
+	}
+	if err := writePrincipalSecret(client, cr, scheme, secret, exists, corev1.SecretTypeOpaque, data, annotations); err != nil {
+		return 0, err
+	}
+	if validity := jwtValidity(cr); validity != nil {
+		return validity.Duration, nil
+	}
+	return 0, nil
+}
+
+// GetPrincipalCA returns the certificate and the private key of the CA of the principal of the given ArgoCD, along
+// with the PEM encoded trust bundle the agents must trust, which holds the previous CA during a CA rotation.
+func GetPrincipalCA(client client.Client, cr *argoproj.ArgoCD) (*x509.Certificate, *rsa.PrivateKey, []byte, error) {
+	name := getPrincipalTlsServerRootCASecretName(cr)
+	secret, exists, err := fetchPrincipalSecret(client, cr, name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !exists {
+		return nil, nil, nil, fmt.Errorf("the CA secret %s of the principal does not exist", name)
+	}
+	cert, key := parsePrincipalCA(secret)
+	if cert == nil || key == nil {
+		return nil, nil, nil, fmt.Errorf("the CA secret %s of the principal has no usable certificate and key", name)
+	}
+	bundle := secret.Data[corev1.ServiceAccountRootCAKey]
+	if len(bundle) == 0 {
+		bundle = argoutil.EncodeCertificatePEM(cert)
+	}
+	return cert, key, bundle, nil
+}
+
+// IssueAgentClientCertificate issues a client certificate for the agent with the given name, signed by the given CA
+// of the principal of the given ArgoCD. It returns the certificate along with the PEM encoded certificate and key.
+func IssueAgentClientCertificate(cr *argoproj.ArgoCD, agentName string, caCert *x509.Certificate, caKey *rsa.PrivateKey) (*x509.Certificate, []byte, []byte, error) {
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	now := time.Now()
+	notAfter := now.Add(getDurationOrDefault(certificateValidity(cr), defaultPrincipalCertificateValidity))
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+	cert, err := newPrincipalCertificate(agentName, principalCertificateSANs{}, x509.ExtKeyUsageClientAuth, key, caCert, caKey, now, notAfter)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cert, argoutil.EncodeCertificatePEM(cert), argoutil.EncodePrivateKeyPEM(key), nil
+}
+
+// GetCertificateRenewAt returns when the given certificate issued from the CA of the principal of the given ArgoCD is
+// due for renewal.
+func GetCertificateRenewAt(cr *argoproj.ArgoCD, cert *x509.Certificate) time.Time {
+	return getRenewAt(cert, getPrincipalRenewBefore(cr))
+}
+
+// GetPrincipalAddress returns the address agents connect to the principal of the given ArgoCD on: the host of the
+// principal Route, the address of the principal LoadBalancer Service or the name of the principal Service.
+func GetPrincipalAddress(client client.Client, cr *argoproj.ArgoCD) (string, error) {
+	name := generateAgentResourceName(cr.Name, string(argoproj.AgentComponentTypePrincipal))
+
+	if argoutil.IsRouteAPIAvailable() {
+		route := &routev1.Route{}
+		if err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, route); err != nil {
+			if !errors.IsNotFound(err) {
+				return "", fmt.Errorf("failed to get principal route %s in namespace %s: %v", name, cr.Namespace, err)
+			}
+		} else {
+			if route.Spec.Host != "" {
+				return route.Spec.Host, nil
+			}
+			for _, ingress := range route.Status.Ingress {
+				if ingress.Host != "" {
+					return ingress.Host, nil
+				}
+			}
+		}
+	}
+
+	service := &corev1.Service{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, service); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get principal service %s in namespace %s: %v", name, cr.Namespace, err)
+		}
+	} else {
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.Hostname != "" {
+				return ingress.Hostname, nil
+			}
+			if ingress.IP != "" {
+				return ingress.IP, nil
+			}
+		}
+	}
+	return fmt.Sprintf("%s.%s.svc", name, cr.Namespace), nil
+}
+
+// GetPrincipalResourceProxyServer returns the server URL Argo CD reaches the cluster of the agent with the given name
+// on, through the resource proxy of the principal of the given ArgoCD.
+func GetPrincipalResourceProxyServer(cr *argoproj.ArgoCD, agentName string) string {
+	name := generateAgentResourceName(cr.Name, string(argoproj.AgentComponentTypePrincipal)+"-resource-proxy")
+	return fmt.Sprintf("https://%s.%s.svc:%d?agentName=%s", name, cr.Namespace, PrincipalResourceProxyServicePort, agentName)
+}
+
+// GetPrincipalPKIStatus reports the certificates and the JWT signing key used by the principal of the given ArgoCD.
+func GetPrincipalPKIStatus(client client.Client, cr *argoproj.ArgoCD) ([]argoproj.ArgoCDAgentPKIStatus, error) {
+	if !hasPrincipal(cr) || !cr.Spec.ArgoCDAgent.Principal.IsEnabled() {
+		return nil, nil
+	}
+
+	entries := []struct {
+		name, secretName string
+		insecureGenerate bool
+	}{
+		{PrincipalPKICA, getPrincipalTlsServerRootCASecretName(cr), false},
+		{PrincipalPKIPrincipalTLS, getPrincipalTLSServerSecretName(cr), isPrincipalTLSInsecureGenerate(cr)},
+		{PrincipalPKIResourceProxyTLS, getPrincipalResourceProxySecretName(cr), false},
+		{PrincipalPKIJWT, getPrincipalJWTSecretName(cr), isPrincipalJWTInsecureGenerate(cr)},
+	}
+
+	var statuses []argoproj.ArgoCDAgentPKIStatus
+	for _, entry := range entries {
+		status := argoproj.ArgoCDAgentPKIStatus{Name: entry.name, SecretName: entry.secretName}
+		secret, exists, err := fetchPrincipalSecret(client, cr, entry.secretName)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !exists && entry.insecureGenerate:
+			status.Message = "generated by the principal on startup"
+		case !exists:
+			status.Message = "Secret not found"
+		case entry.name == PrincipalPKIJWT:
+			status.Managed = metav1.IsControlledBy(secret, cr)
+			if status.Managed {
+				if notAfter := getJWTKeyNotAfter(cr, secret); notAfter != nil {
+					status.NotAfter = &metav1.Time{Time: *notAfter}
+					status.RenewAt = status.NotAfter
+				}
+			}
+		default:
+			status.Managed = metav1.IsControlledBy(secret, cr)
+			cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+			if err != nil {
+				status.Message = fmt.Sprintf("invalid certificate: %v", err)
+				break
+			}
+			status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+			if status.Managed {
+				status.RenewAt = &metav1.Time{Time: getRenewAt(cert, getPrincipalRenewBefore(cr))}
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// setPrincipalPKIChecksum sets the checksum of the certificates and the JWT signing key used by the principal on the
+// given pod template, so that the principal is rolled out when they change. It returns whether the checksum changed.
+func setPrincipalPKIChecksum(client client.Client, cr *argoproj.ArgoCD, template *corev1.PodTemplateSpec) (bool, error) {
+	hash := sha256.New()
+	found := false
+	for _, name := range []string{
+		getPrincipalTlsServerRootCASecretName(cr),
+		getPrincipalTLSServerSecretName(cr),
+		getPrincipalResourceProxyCaSecretName(cr),
+		getPrincipalResourceProxySecretName(cr),
+		getPrincipalJWTSecretName(cr),
+	} {
+		secret, exists, err := fetchPrincipalSecret(client, cr, name)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			continue
+		}
+		found = true
+		for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, corev1.ServiceAccountRootCAKey, PrincipalJWTKey} {
+			hash.Write(secret.Data[key])
+		}
+	}
+	if !found {
+		return false, nil
+	}
+
+	checksum := fmt.Sprintf("%x", hash.Sum(nil))
+	if template.Annotations[common.ArgoCDAgentPKIChecksum] == checksum {
+		return false, nil
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[common.ArgoCDAgentPKIChecksum] = checksum
+	return true, nil
+}
+
+// getPrincipalSANs returns the subject alternative names of the principal gRPC certificate, taken from the principal
+// Service and Route.
+func getPrincipalSANs(client client.Client, compName string, cr *argoproj.ArgoCD) (principalCertificateSANs, error) {
+	name := generateAgentResourceName(cr.Name, compName)
+	sans, err := getServiceSANs(client, cr, name)
+	if err != nil {
+		return sans, err
+	}
+
+	if argoutil.IsRouteAPIAvailable() {
+		route := &routev1.Route{}
+		if err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, route); err != nil {
+			if !errors.IsNotFound(err) {
+				return sans, fmt.Errorf("failed to get principal route %s in namespace %s: %v", name, cr.Namespace, err)
+			}
+		} else {
+			sans.addDNSName(route.Spec.Host)
+			for _, ingress := range route.Status.Ingress {
+				sans.addDNSName(ingress.Host)
+			}
+		}
+	}
+	return sans, nil
+}
+
+// getServiceSANs returns the subject alternative names of a certificate served behind the given Service.
+func getServiceSANs(client client.Client, cr *argoproj.ArgoCD, name string) (principalCertificateSANs, error) {
+	sans := principalCertificateSANs{}
+	sans.addDNSName(name)
+	sans.addDNSName(fmt.Sprintf("%s.%s", name, cr.Namespace))
+	sans.addDNSName(fmt.Sprintf("%s.%s.svc", name, cr.Namespace))
+	sans.addDNSName(fmt.Sprintf("%s.%s.svc.%s", name, cr.Namespace, argoutil.GetClusterDomain(cr)))
+	sans.addIPAddress("127.0.0.1")
+
+	service := &corev1.Service{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, service); err != nil {
+		if errors.IsNotFound(err) {
+			return sans, nil
+		}
+		return sans, fmt.Errorf("failed to get service %s in namespace %s: %v", name, cr.Namespace, err)
+	}
+	sans.addIPAddress(service.Spec.ClusterIP)
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		sans.addIPAddress(ingress.IP)
+		sans.addDNSName(ingress.Hostname)
+	}
+	return sans, nil
+}
+
+func (s *principalCertificateSANs) addDNSName(name string) {
+	if name != "" && !slices.Contains(s.dnsNames, name) {
+		s.dnsNames = append(s.dnsNames, name)
+	}
+}
+
+func (s *principalCertificateSANs) addIPAddress(address string) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return
+	}
+	for _, existing := range s.ipAddresses {
+		if existing.Equal(ip) {
+			return
+		}
+	}
+	s.ipAddresses = append(s.ipAddresses, ip)
+}
+
+// hasSANs returns whether the given certificate is valid for exactly the given subject alternative names.
+func hasSANs(cert *x509.Certificate, sans principalCertificateSANs) bool {
+	if len(cert.DNSNames) != len(sans.dnsNames) || len(cert.IPAddresses) != len(sans.ipAddresses) {
+		return false
+	}
+	for _, name := range sans.dnsNames {
+		if !slices.Contains(cert.DNSNames, name) {
+			return false
+		}
+	}
+	for _, ip := range sans.ipAddresses {
+		if !slices.ContainsFunc(cert.IPAddresses, ip.Equal) {
+			return false
+		}
+	}
+	return true
+}
+
+// newPrincipalCACertificate returns a self-signed CA certificate for the principal of the given ArgoCD.
+func newPrincipalCACertificate(cr *argoproj.ArgoCD, key *rsa.PrivateKey, notAfter time.Time) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+	tmpl := x509.Certificate{
+		SerialNumber:          serial,
+		NotBefore:             time.Now().UTC(),
+		NotAfter:              notAfter.UTC(),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("argocd-agent-ca@%s", cr.Namespace)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// newPrincipalCertificate returns a certificate for the given subject alternative names signed by the given CA.
+func newPrincipalCertificate(commonName string, sans principalCertificateSANs, extKeyUsage x509.ExtKeyUsage, key *rsa.PrivateKey, caCert *x509.Certificate, caKey *rsa.PrivateKey, notBefore, notAfter time.Time) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+	tmpl := x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     sans.dnsNames,
+		IPAddresses:  sans.ipAddresses,
+		NotBefore:    notBefore.UTC(),
+		NotAfter:     notAfter.UTC(),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// parsePrincipalCA returns the certificate and the private key of the given CA Secret, or nil if they are not usable.
+func parsePrincipalCA(secret *corev1.Secret) (*x509.Certificate, *rsa.PrivateKey) {
+	cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	if err != nil || !cert.IsCA {
+		return nil, nil
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSPrivateKeyKey])
+	if block == nil {
+		return nil, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return cert, key
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if rsaKey, ok := key.(*rsa.PrivateKey); ok {
+			return cert, rsaKey
+		}
+	}
+	return nil, nil
+}
+
+// getRenewAt returns when the given certificate is due for renewal. The renewal happens at the latest half way through
+// the lifetime of the certificate.
+func getRenewAt(cert *x509.Certificate, renewBefore time.Duration) time.Time {
+	if lifetime := cert.NotAfter.Sub(cert.NotBefore); renewBefore > lifetime/2 {
+		renewBefore = lifetime / 2
+	}
+	return cert.NotAfter.Add(-renewBefore)
+}
+
+// getJWTKeyNotAfter returns when the JWT signing key in the given Secret is due for rotation, or nil if it is not
+// rotated.
+func getJWTKeyNotAfter(cr *argoproj.ArgoCD, secret *corev1.Secret) *time.Time {
+	validity := jwtValidity(cr)
+	if validity == nil {
+		return nil
+	}
+	rotatedAt := secret.CreationTimestamp.Time
+	if t, err := time.Parse(time.RFC3339Nano, secret.Annotations[common.AnnotationAgentJWTKeyRotatedAt]); err == nil {
+		rotatedAt = t
+	}
+	notAfter := rotatedAt.Add(validity.Duration)
+	return &notAfter
+}
+
+func fetchPrincipalSecret(client client.Client, cr *argoproj.ArgoCD, name string) (*corev1.Secret, bool, error) {
+	secret := &corev1.Secret{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, secret); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, false, fmt.Errorf("failed to get secret %s in namespace %s: %v", name, cr.Namespace, err)
+		}
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cr.Namespace,
+			},
+		}, false, nil
+	}
+	return secret, true, nil
+}
+
+// writePrincipalSecret creates the given Secret, controlled by the ArgoCD, or updates it with the given data.
+func writePrincipalSecret(client client.Client, cr *argoproj.ArgoCD, scheme *runtime.Scheme, secret *corev1.Secret, exists bool, secretType corev1.SecretType, data map[string][]byte, annotations map[string]string) error {
+	secret.Data = data
+	for k, v := range annotations {
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[k] = v
+	}
+
+	if exists {
+		argoutil.LogResourceUpdate(log, secret, "renewing principal PKI secret")
+		if err := client.Update(context.TODO(), secret); err != nil {
+			return fmt.Errorf("failed to update secret %s in namespace %s: %v", secret.Name, secret.Namespace, err)
+		}
+		return nil
+	}
+
+	secret.Type = secretType
+	secret.Labels = buildLabelsForAgentPrincipal(cr.Name, string(argoproj.AgentComponentTypePrincipal))
+	if err := controllerutil.SetControllerReference(cr, secret, scheme); err != nil {
+		return fmt.Errorf("failed to set ArgoCD CR %s as owner for secret %s: %w", cr.Name, secret.Name, err)
+	}
+	argoutil.LogResourceCreation(log, secret)
+	if err := client.Create(context.TODO(), secret); err != nil {
+		return fmt.Errorf("failed to create secret %s in namespace %s: %v", secret.Name, secret.Namespace, err)
+	}
+	return nil
+}
+
+func getDurationOrDefault(d *metav1.Duration, def time.Duration) time.Duration {
+	if d != nil && d.Duration > 0 {
+		return d.Duration
+	}
+	return def
+}
+
+func getPrincipalRenewBefore(cr *argoproj.ArgoCD) time.Duration {
+	if hasTLS(cr) {
+		return getDurationOrDefault(cr.Spec.ArgoCDAgent.Principal.TLS.RenewBefore, defaultPrincipalRenewBefore)
+	}
+	return defaultPrincipalRenewBefore
+}
+
+func certificateValidity(cr *argoproj.ArgoCD) *metav1.Duration {
+	if hasTLS(cr) {
+		return cr.Spec.ArgoCDAgent.Principal.TLS.Validity
+	}
+	return nil
+}
+
+func caValidity(cr *argoproj.ArgoCD) *metav1.Duration {
+	if hasTLS(cr) {
+		return cr.Spec.ArgoCDAgent.Principal.TLS.CAValidity
+	}
+	return nil
+}
+
+func jwtValidity(cr *argoproj.ArgoCD) *metav1.Duration {
+	if hasJWT(cr) && cr.Spec.ArgoCDAgent.Principal.JWT.Validity != nil && cr.Spec.ArgoCDAgent.Principal.JWT.Validity.Duration > 0 {
+		return cr.Spec.ArgoCDAgent.Principal.JWT.Validity
+	}
+	return nil
+}
+
+func isPrincipalTLSInsecureGenerate(cr *argoproj.ArgoCD) bool {
+	return hasTLS(cr) && cr.Spec.ArgoCDAgent.Principal.TLS.InsecureGenerate != nil && *cr.Spec.ArgoCDAgent.Principal.TLS.InsecureGenerate
+}
+
+func isPrincipalJWTInsecureGenerate(cr *argoproj.ArgoCD) bool {
+	return hasJWT(cr) && cr.Spec.ArgoCDAgent.Principal.JWT.InsecureGenerate != nil && *cr.Spec.ArgoCDAgent.Principal.JWT.InsecureGenerate
+}
@@ -0,0 +1,420 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdagent
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func withPrincipalTLS(tls *argoproj.PrincipalTLSSpec) argoCDOpt {
+	return func(a *argoproj.ArgoCD) {
+		a.Spec.ArgoCDAgent.Principal.TLS = tls
+	}
+}
+
+func withPrincipalJWT(jwt *argoproj.PrincipalJWTSpec) argoCDOpt {
+	return func(a *argoproj.ArgoCD) {
+		a.Spec.ArgoCDAgent.Principal.JWT = jwt
+	}
+}
+
+func makeTestPrincipalService(name, clusterIP string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec:       corev1.ServiceSpec{ClusterIP: clusterIP},
+	}
+}
+
+func getTestSecret(t *testing.T, cl client.Client, name string) *corev1.Secret {
+	t.Helper()
+	secret := &corev1.Secret{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, secret))
+	return secret
+}
+
+func TestReconcilePrincipalPKI_CreatesSecrets(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true))
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{
+		cr,
+		makeTestPrincipalService("argocd-agent-principal", "10.0.0.5"),
+		makeTestPrincipalService("argocd-agent-principal-resource-proxy", "10.0.0.6"),
+	})
+
+	requeueAfter, err := ReconcilePrincipalPKI(cl, testCompName, cr, makeTestReconcilerScheme())
+	assert.NoError(t, err)
+	assert.Greater(t, requeueAfter, 300*24*time.Hour)
+	assert.LessOrEqual(t, requeueAfter, common.ArgoCDDuration365Days-defaultPrincipalRenewBefore)
+
+	caSecret := getTestSecret(t, cl, "argocd-agent-ca")
+	assert.True(t, metav1.IsControlledBy(caSecret, cr))
+	assert.Equal(t, corev1.SecretTypeTLS, caSecret.Type)
+	caCert, caKey := parsePrincipalCA(caSecret)
+	assert.NotNil(t, caCert)
+	assert.NotNil(t, caKey)
+
+	principalSecret := getTestSecret(t, cl, "argocd-agent-principal-tls")
+	assert.True(t, metav1.IsControlledBy(principalSecret, cr))
+	cert, err := argoutil.ParsePEMEncodedCert(principalSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.Contains(t, cert.DNSNames, "argocd-agent-principal.argocd.svc.cluster.local")
+	assert.True(t, containsIP(cert.IPAddresses, "10.0.0.5"))
+	assert.Equal(t, caSecret.Data[corev1.TLSCertKey], principalSecret.Data[corev1.ServiceAccountRootCAKey])
+
+	proxySecret := getTestSecret(t, cl, "argocd-agent-resource-proxy-tls")
+	cert, err = argoutil.ParsePEMEncodedCert(proxySecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.Contains(t, cert.DNSNames, "argocd-agent-principal-resource-proxy")
+	assert.True(t, containsIP(cert.IPAddresses, "10.0.0.6"))
+
+	jwtSecret := getTestSecret(t, cl, "argocd-agent-jwt")
+	assert.True(t, metav1.IsControlledBy(jwtSecret, cr))
+	assert.Contains(t, string(jwtSecret.Data[PrincipalJWTKey]), "BEGIN PRIVATE KEY")
+
+	// A second run keeps the issued certificates and key.
+	_, err = ReconcilePrincipalPKI(cl, testCompName, cr, makeTestReconcilerScheme())
+	assert.NoError(t, err)
+	assert.Equal(t, principalSecret.Data, getTestSecret(t, cl, "argocd-agent-principal-tls").Data)
+	assert.Equal(t, jwtSecret.Data, getTestSecret(t, cl, "argocd-agent-jwt").Data)
+}
+
+func TestReconcilePrincipalPKI_PrincipalDisabled(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(false))
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{cr})
+
+	requeueAfter, err := ReconcilePrincipalPKI(cl, testCompName, cr, makeTestReconcilerScheme())
+	assert.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+
+	secrets := &corev1.SecretList{}
+	assert.NoError(t, cl.List(context.TODO(), secrets, client.InNamespace(testNamespace)))
+	assert.Empty(t, secrets.Items)
+}
+
+func TestReconcilePrincipalPKI_HonorsInsecureGenerate(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true),
+		withPrincipalTLS(&argoproj.PrincipalTLSSpec{InsecureGenerate: ptr.To(true)}),
+		withPrincipalJWT(&argoproj.PrincipalJWTSpec{InsecureGenerate: ptr.To(true)}))
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{cr})
+
+	_, err := ReconcilePrincipalPKI(cl, testCompName, cr, makeTestReconcilerScheme())
+	assert.NoError(t, err)
+
+	for name, exists := range map[string]bool{
+		"argocd-agent-ca":                 true,
+		"argocd-agent-resource-proxy-tls": true,
+		"argocd-agent-principal-tls":      false,
+		"argocd-agent-jwt":                false,
+	} {
+		found, err := argoutil.IsObjectFound(cl, testNamespace, name, &corev1.Secret{})
+		assert.NoError(t, err)
+		assert.Equal(t, exists, found, name)
+	}
+}
+
+func TestReconcilePrincipalPKI_UserProvidedSecrets(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true),
+		withPrincipalTLS(&argoproj.PrincipalTLSSpec{SecretName: "my-principal-tls", RootCASecretName: "my-ca"}))
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-principal-tls", Namespace: testNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("user cert"), corev1.TLSPrivateKeyKey: []byte("user key")},
+	}
+	userCA := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-ca", Namespace: testNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("user ca")},
+	}
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{cr, userSecret, userCA})
+
+	_, err := ReconcilePrincipalPKI(cl, testCompName, cr, makeTestReconcilerScheme())
+	assert.NoError(t, err)
+
+	assert.Equal(t, userSecret.Data, getTestSecret(t, cl, "my-principal-tls").Data)
+	assert.Equal(t, userCA.Data, getTestSecret(t, cl, "my-ca").Data)
+
+	// Without the key of the user provided CA, the operator cannot issue the resource proxy certificate.
+	found, err := argoutil.IsObjectFound(cl, testNamespace, "argocd-agent-resource-proxy-tls", &corev1.Secret{})
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	// The resource proxy uses the default CA Secret name, to which the user provided CA is copied.
+	assert.Equal(t, userCA.Data, getTestSecret(t, cl, "argocd-agent-ca").Data)
+}
+
+func TestReconcilePrincipalCertificate_Renewal(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true),
+		withPrincipalTLS(&argoproj.PrincipalTLSSpec{
+			Validity:    &metav1.Duration{Duration: 48 * time.Hour},
+			RenewBefore: &metav1.Duration{Duration: 12 * time.Hour},
+		}))
+	service := makeTestPrincipalService("argocd-agent-principal", "10.0.0.5")
+	cl := makeTestReconcilerClient(makeTestReconcilerScheme(), []client.Object{cr, service})
+	sch := makeTestReconcilerScheme()
+
+	requeueAfter, err := ReconcilePrincipalPKI(cl, testCompName, cr, sch)
+	assert.NoError(t, err)
+	assert.InDelta(t, (36 * time.Hour).Seconds(), requeueAfter.Seconds(), 60)
+
+	caCert, caKey := parsePrincipalCA(getTestSecret(t, cl, "argocd-agent-ca"))
+	issued := getTestSecret(t, cl, "argocd-agent-principal-tls").Data[corev1.TLSCertKey]
+	sans, err := getPrincipalSANs(cl, testCompName, cr)
+	assert.NoError(t, err)
+
+	t.Run("not due", func(t *testing.T) {
+		d, err := reconcilePrincipalCertificate(cl, cr, sch, "argocd-agent-principal-tls", "argocd-agent-principal", sans, caCert, caKey, nil, time.Now().Add(24*time.Hour))
+		assert.NoError(t, err)
+		assert.InDelta(t, (12 * time.Hour).Seconds(), d.Seconds(), 60)
+		assert.Equal(t, issued, getTestSecret(t, cl, "argocd-agent-principal-tls").Data[corev1.TLSCertKey])
+	})
+
+	t.Run("due", func(t *testing.T) {
+		_, err := reconcilePrincipalCertificate(cl, cr, sch, "argocd-agent-principal-tls", "argocd-agent-principal", sans, caCert, caKey, nil, time.Now().Add(40*time.Hour))
+		assert.NoError(t, err)
+		assert.NotEqual(t, issued, getTestSecret(t, cl, "argocd-agent-principal-tls").Data[corev1.TLSCertKey])
+	})
+
+	t.Run("service address changed", func(t *testing.T) {
+		issued := getTestSecret(t, cl, "argocd-agent-principal-tls").Data[corev1.TLSCertKey]
+		service.Spec.ClusterIP = "10.0.0.7"
+		assert.NoError(t, cl.Update(context.TODO(), service))
+
+		_, err := ReconcilePrincipalPKI(cl, testCompName, cr, sch)
+		assert.NoError(t, err)
+		reissued := getTestSecret(t, cl, "argocd-agent-principal-tls").Data[corev1.TLSCertKey]
+		assert.NotEqual(t, issued, reissued)
+		cert, err := argoutil.ParsePEMEncodedCert(reissued)
+		assert.NoError(t, err)
+		assert.True(t, containsIP(cert.IPAddresses, "10.0.0.7"))
+		assert.False(t, containsIP(cert.IPAddresses, "10.0.0.5"))
+	})
+}
+
+func TestReconcilePrincipalCA_Rotation(t *testing.T) {
+	cr := makeTestArgoCD(withPrincipalEnabled(true),
+		withPrincipalTLS(&argoproj.PrincipalTLSSpec{
+			CAValidity:  &metav1.Duration{Duration: 48 * time.Hour},
+			RenewBefore: &metav1.Duration{Duration: 12 * time.Hour},
+		}))
+	registration := &argoproj.ArgoCDAgentRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-1", Namespace: testNamespace},
+		Status:     argoproj.ArgoCDAgentRegistrationStatus{BootstrapSecretName: "workload-1-bootstrap"},
+	}
+	sch := makeTestReconcilerScheme()
+	cl := makeTestReconcilerClient(sch, []client.Object{cr, registration})
+
+	_, err := ReconcilePrincipalPKI(cl, testCompName, cr, sch)
+	assert.NoError(t, err)
+	oldCA, oldKey := parsePrincipalCA(getTestSecret(t, cl, "argocd-agent-ca"))
+	assert.NotNil(t, oldCA)
+
+	// The agent holds a client certificate issued from the CA about to be rotated.
+	issueBootstrap := func(caCert *x509.Certificate, caKey *rsa.PrivateKey) {
+		t.Helper()
+		_, certPEM, keyPEM, err := IssueAgentClientCertificate(cr, "workload-1", caCert, caKey)
+		assert.NoError(t, err)
+		bootstrap := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-1-bootstrap", Namespace: testNamespace},
+			Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		}
+		if err := cl.Create(context.TODO(), bootstrap); err != nil {
+			assert.NoError(t, cl.Update(context.TODO(), bootstrap))
+		}
+	}
+	issueBootstrap(oldCA, oldKey)
+
+	t.Run("renewal keeps the previous CA in the trust bundle", func(t *testing.T) {
+		_, d, err := reconcilePrincipalCA(cl, cr, sch, time.Now().Add(40*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, principalCAGraceRecheckInterval, d)
+
+		secret := getTestSecret(t, cl, "argocd-agent-ca")
+		newCA, _ := parsePrincipalCA(secret)
+		assert.False(t, newCA.Equal(oldCA))
+		bundle := parsePEMEncodedCerts(secret.Data[corev1.ServiceAccountRootCAKey])
+		assert.Len(t, bundle, 2)
+		assert.True(t, bundle[0].Equal(newCA))
+		assert.True(t, bundle[1].Equal(oldCA))
+
+		// The previous CA is kept while the client certificate of the agent is not re-issued.
+		_, d, err = reconcilePrincipalCA(cl, cr, sch, time.Now().Add(41*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, principalCAGraceRecheckInterval, d)
+		assert.Len(t, parsePEMEncodedCerts(getTestSecret(t, cl, "argocd-agent-ca").Data[corev1.ServiceAccountRootCAKey]), 2)
+	})
+
+	t.Run("the previous CA is retired once the client certificates are re-issued", func(t *testing.T) {
+		newCA, newKey := parsePrincipalCA(getTestSecret(t, cl, "argocd-agent-ca"))
+		issueBootstrap(newCA, newKey)
+
+		_, _, err := reconcilePrincipalCA(cl, cr, sch, time.Now().Add(41*time.Hour))
+		assert.NoError(t, err)
+		bundle := parsePEMEncodedCerts(getTestSecret(t, cl, "argocd-agent-ca").Data[corev1.ServiceAccountRootCAKey])
+		assert.Len(t, bundle, 1)
+		assert.True(t, bundle[0].Equal(newCA))
+	})
+
+	t.Run("an expired previous CA is retired", func(t *testing.T) {
+		secret := getTestSecret(t, cl, "argocd-agent-ca")
+		newCA, _ := parsePrincipalCA(secret)
+		issueBootstrap(oldCA, oldKey)
+		secret.Data[corev1.ServiceAccountRootCAKey] = append(argoutil.EncodeCertificatePEM(newCA), argoutil.EncodeCertificatePEM(oldCA)...)
+		assert.NoError(t, cl.Update(context.TODO(), secret))
+
+		_, _, err := reconcilePrincipalCA(cl, cr, sch, oldCA.NotAfter.Add(time.Minute))
+		assert.NoError(t, err)
+		bundle := parsePEMEncodedCerts(getTestSecret(t, cl, "argocd-agent-ca").Data[corev1.ServiceAccountRootCAKey])
+		assert.Len(t, bundle, 1)
+		assert.True(t, bundle[0].Equal(newCA))
+	})
+}
+
+func TestReconcilePrincipalJWTKey_Rotation(t *testing.T) {
+	sch := makeTestReconcilerScheme()
+
+	t.Run("not rotated without validity", func(t *testing.T) {
+		cr := makeTestArgoCD(withPrincipalEnabled(true))
+		cl := makeTestReconcilerClient(sch, []client.Object{cr})
+
+		d, err := reconcilePrincipalJWTKey(cl, cr, sch, time.Now())
+		assert.NoError(t, err)
+		assert.Zero(t, d)
+		key := getTestSecret(t, cl, "argocd-agent-jwt").Data[PrincipalJWTKey]
+
+		d, err = reconcilePrincipalJWTKey(cl, cr, sch, time.Now().Add(10*common.ArgoCDDuration365Days))
+		assert.NoError(t, err)
+		assert.Zero(t, d)
+		assert.Equal(t, key, getTestSecret(t, cl, "argocd-agent-jwt").Data[PrincipalJWTKey])
+	})
+
+	t.Run("rotated after validity", func(t *testing.T) {
+		cr := makeTestArgoCD(withPrincipalEnabled(true),
+			withPrincipalJWT(&argoproj.PrincipalJWTSpec{Validity: &metav1.Duration{Duration: time.Hour}}))
+		cl := makeTestReconcilerClient(sch, []client.Object{cr})
+
+		d, err := reconcilePrincipalJWTKey(cl, cr, sch, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, d)
+		secret := getTestSecret(t, cl, "argocd-agent-jwt")
+		assert.NotEmpty(t, secret.Annotations[common.AnnotationAgentJWTKeyRotatedAt])
+
+		d, err = reconcilePrincipalJWTKey(cl, cr, sch, time.Now().Add(30*time.Minute))
+		assert.NoError(t, err)
+		assert.InDelta(t, (30 * time.Minute).Seconds(), d.Seconds(), 60)
+		assert.Equal(t, secret.Data, getTestSecret(t, cl, "argocd-agent-jwt").Data)
+
+		_, err = reconcilePrincipalJWTKey(cl, cr, sch, time.Now().Add(2*time.Hour))
+		assert.NoError(t, err)
+		assert.NotEqual(t, secret.Data, getTestSecret(t, cl, "argocd-agent-jwt").Data)
+	})
+}
+
+func TestGetPrincipalPKIStatus(t *testing.T) {
+	sch := makeTestReconcilerScheme()
+	cr := makeTestArgoCD(withPrincipalEnabled(true),
+		withPrincipalTLS(&argoproj.PrincipalTLSSpec{InsecureGenerate: ptr.To(true)}),
+		withPrincipalJWT(&argoproj.PrincipalJWTSpec{Validity: &metav1.Duration{Duration: time.Hour}}))
+	cl := makeTestReconcilerClient(sch, []client.Object{cr})
+
+	_, err := ReconcilePrincipalPKI(cl, testCompName, cr, sch)
+	assert.NoError(t, err)
+
+	statuses, err := GetPrincipalPKIStatus(cl, cr)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 4)
+	byName := map[string]argoproj.ArgoCDAgentPKIStatus{}
+	for _, status := range statuses {
+		byName[status.Name] = status
+	}
+
+	caCert, _ := parsePrincipalCA(getTestSecret(t, cl, "argocd-agent-ca"))
+	assert.True(t, byName[PrincipalPKICA].Managed)
+	assert.True(t, byName[PrincipalPKICA].NotAfter.Time.Equal(caCert.NotAfter))
+	assert.True(t, byName[PrincipalPKICA].RenewAt.Time.Before(caCert.NotAfter))
+
+	assert.Equal(t, "argocd-agent-principal-tls", byName[PrincipalPKIPrincipalTLS].SecretName)
+	assert.False(t, byName[PrincipalPKIPrincipalTLS].Managed)
+	assert.Nil(t, byName[PrincipalPKIPrincipalTLS].NotAfter)
+	assert.Equal(t, "generated by the principal on startup", byName[PrincipalPKIPrincipalTLS].Message)
+
+	assert.True(t, byName[PrincipalPKIResourceProxyTLS].Managed)
+	assert.NotNil(t, byName[PrincipalPKIResourceProxyTLS].NotAfter)
+
+	assert.True(t, byName[PrincipalPKIJWT].Managed)
+	assert.NotNil(t, byName[PrincipalPKIJWT].NotAfter)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), byName[PrincipalPKIJWT].NotAfter.Time, time.Minute)
+
+	statuses, err = GetPrincipalPKIStatus(cl, makeTestArgoCD(withPrincipalEnabled(false)))
+	assert.NoError(t, err)
+	assert.Nil(t, statuses)
+}
+
+func TestSetPrincipalPKIChecksum(t *testing.T) {
+	sch := makeTestReconcilerScheme()
+	cr := makeTestArgoCD(withPrincipalEnabled(true))
+	cl := makeTestReconcilerClient(sch, []client.Object{cr})
+	template := &corev1.PodTemplateSpec{}
+
+	changed, err := setPrincipalPKIChecksum(cl, cr, template)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Empty(t, template.Annotations[common.ArgoCDAgentPKIChecksum])
+
+	_, err = ReconcilePrincipalPKI(cl, testCompName, cr, sch)
+	assert.NoError(t, err)
+	changed, err = setPrincipalPKIChecksum(cl, cr, template)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	checksum := template.Annotations[common.ArgoCDAgentPKIChecksum]
+	assert.NotEmpty(t, checksum)
+
+	changed, err = setPrincipalPKIChecksum(cl, cr, template)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	secret := getTestSecret(t, cl, "argocd-agent-jwt")
+	secret.Data[PrincipalJWTKey] = []byte("rotated")
+	assert.NoError(t, cl.Update(context.TODO(), secret))
+	changed, err = setPrincipalPKIChecksum(cl, cr, template)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.NotEqual(t, checksum, template.Annotations[common.ArgoCDAgentPKIChecksum])
+}
+
+func containsIP(ips []net.IP, address string) bool {
+	for _, ip := range ips {
+		if ip.Equal(net.ParseIP(address)) {
+			return true
+		}
+	}
+	return false
+}
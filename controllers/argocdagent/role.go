@@ -98,7 +98,7 @@ func ReconcilePrincipalRole(client client.Client, compName string, cr *argoproj.
 // This function creates, updates, or deletes the ClusterRole based on the principal's enabled status.
 func ReconcilePrincipalClusterRoles(client client.Client, compName string, cr *argoproj.ArgoCD, scheme *runtime.Scheme) (*v1.ClusterRole, error) {
 	clusterRole := buildClusterRole(compName, cr)
-	expectedPolicyRule := buildPolicyRuleForClusterRole()
+	expectedPolicyRule := buildPolicyRuleForClusterRole(cr)
 
 	allowed := argoutil.IsNamespaceClusterConfigNamespace(cr.Namespace)
 
@@ -217,7 +217,46 @@ func buildPolicyRuleForRole() []v1.PolicyRule {
 // Grants access to:
 // - ArgoCD resources (applications, appprojects, applicationsets): full CRUD operations
 // - namespaces: read and create permissions for managing application deployments across namespaces
-func buildPolicyRuleForClusterRole() []v1.PolicyRule {
+//
+// When namespace policies are set, the ArgoCD resources can still be read cluster-wide, which the informers of the
+// principal require, but the write verbs are granted per namespace by ReconcilePrincipalNamespaceRoles instead, and
+// namespaces can only be created if a policy allows it.
+func buildPolicyRuleForClusterRole(cr *argoproj.ArgoCD) []v1.PolicyRule {
+	if hasNamespacePolicies(cr) {
+		verbs := []string{
+			"get",
+			"list",
+			"watch",
+		}
+		// Invalid policies do not allow creating namespaces.
+		if ValidatePrincipalNamespacePolicies(cr) == nil && getNamespacePolicyCreatePattern(cr) != "" {
+			verbs = append([]string{"create"}, verbs...)
+		}
+		return append(buildPolicyRuleForArgoCDResources([]string{
+			"get",
+			"list",
+			"watch",
+		}), buildPolicyRuleForNamespaces(verbs))
+	}
+
+	return append(buildPolicyRuleForArgoCDResources([]string{
+		"create",
+		"get",
+		"list",
+		"watch",
+		"update",
+		"delete",
+		"patch",
+	}), buildPolicyRuleForNamespaces([]string{
+		"create",
+		"get",
+		"list",
+		"watch",
+	}))
+}
+
+// buildPolicyRuleForArgoCDResources grants the given verbs on applications, appprojects and applicationsets.
+func buildPolicyRuleForArgoCDResources(verbs []string) []v1.PolicyRule {
 	return []v1.PolicyRule{
 		{
 			APIGroups: []string{
@@ -228,28 +267,19 @@ func buildPolicyRuleForClusterRole() []v1.PolicyRule {
 				"appprojects",
 				"applicationsets",
 			},
-			Verbs: []string{
-				"create",
-				"get",
-				"list",
-				"watch",
-				"update",
-				"delete",
-				"patch",
-			},
-		}, {
-			APIGroups: []string{
-				"",
-			},
-			Resources: []string{
-				"namespaces",
-			},
-			Verbs: []string{
-				"create",
-				"get",
-				"list",
-				"watch",
-			},
+			Verbs: verbs,
+		},
+	}
+}
+
+func buildPolicyRuleForNamespaces(verbs []string) v1.PolicyRule {
+	return v1.PolicyRule{
+		APIGroups: []string{
+			"",
+		},
+		Resources: []string{
+			"namespaces",
 		},
+		Verbs: verbs,
 	}
 }
@@ -283,7 +283,7 @@ func TestReconcilePrincipalClusterRoles_ClusterRoleDoesNotExist_PrincipalEnabled
 	assert.Equal(t, buildLabelsForAgentPrincipal(cr.Name, testCompName), retrievedClusterRole.Labels)
 
 	// Verify ClusterRole has expected rules
-	expectedRules := buildPolicyRuleForClusterRole()
+	expectedRules := buildPolicyRuleForClusterRole(cr)
 	assert.Equal(t, expectedRules, retrievedClusterRole.Rules)
 
 	// Verify no owner reference is set for ClusterRole (as expected from the code)
@@ -302,7 +302,7 @@ func TestReconcilePrincipalClusterRoles_ClusterRoleExists_PrincipalDisabled(t *t
 			Name:   generateAgentResourceName(cr.Name+"-"+cr.Namespace, testCompName),
 			Labels: buildLabelsForAgentPrincipal(cr.Name, testCompName),
 		},
-		Rules: buildPolicyRuleForClusterRole(),
+		Rules: buildPolicyRuleForClusterRole(cr),
 	}
 
 	resObjs := []client.Object{cr, existingClusterRole}
@@ -328,7 +328,7 @@ func TestReconcilePrincipalClusterRoles_ClusterRoleExists_PrincipalEnabled_SameR
 	cr := makeTestArgoCD(withPrincipalEnabled(true))
 	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", cr.Namespace)
 
-	expectedRules := buildPolicyRuleForClusterRole()
+	expectedRules := buildPolicyRuleForClusterRole(cr)
 	existingClusterRole := &v1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   generateAgentResourceName(cr.Name+"-"+cr.Namespace, testCompName),
@@ -391,7 +391,7 @@ func TestReconcilePrincipalClusterRoles_ClusterRoleExists_PrincipalEnabled_Diffe
 	}, retrievedClusterRole)
 	assert.NoError(t, err)
 
-	expectedRules := buildPolicyRuleForClusterRole()
+	expectedRules := buildPolicyRuleForClusterRole(cr)
 	assert.Equal(t, expectedRules, retrievedClusterRole.Rules)
 }
 
@@ -407,7 +407,7 @@ func TestReconcilePrincipalClusterRoles_ClusterRoleExists_PrincipalNotSet(t *tes
 			Name:   generateAgentResourceName(cr.Name+"-"+cr.Namespace, testCompName),
 			Labels: buildLabelsForAgentPrincipal(cr.Name, testCompName),
 		},
-		Rules: buildPolicyRuleForClusterRole(),
+		Rules: buildPolicyRuleForClusterRole(cr),
 	}
 
 	resObjs := []client.Object{cr, existingClusterRole}
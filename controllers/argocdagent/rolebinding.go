@@ -17,7 +17,6 @@ package argocdagent
 import (
 	"context"
 	"fmt"
-	"os"
 	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
@@ -210,5 +209,5 @@ func buildClusterRoleBinding(compName string, cr *argoproj.ArgoCD) *v1.ClusterRo
 }
 
 func getCustomRoleName() string {
-	return os.Getenv(common.ArgoCDPrincipalClusterRoleEnvName)
+	return argoutil.GetOperatorSetting(common.ArgoCDPrincipalClusterRoleEnvName)
 }
@@ -44,20 +44,34 @@ else
 	echo "  -> Reusing existing agent PKI."
 fi
 
+# The operator creates the principal TLS, resource proxy TLS and JWT signing key secrets
+# unless insecureGenerate is set, so only create the ones that do not exist yet.
 echo "[*] Creating principal TLS configuration"
-argocd-agentctl pki issue principal --upsert \
-	--principal-namespace ${ARGOCD_AGENT_PRINCIPAL_NAMESPACE} \
-	${ARGOCD_AGENT_GRPC_SAN}
-echo "  -> Principal TLS config created."
+if ! kubectl -n ${ARGOCD_AGENT_PRINCIPAL_NAMESPACE} get secret argocd-agent-principal-tls >/dev/null 2>&1; then
+	argocd-agentctl pki issue principal --upsert \
+		--principal-namespace ${ARGOCD_AGENT_PRINCIPAL_NAMESPACE} \
+		${ARGOCD_AGENT_GRPC_SAN}
+	echo "  -> Principal TLS config created."
+else
+	echo "  -> Reusing existing principal TLS config."
+fi
 
 echo "[*] Creating resouce proxy TLS configuration"
-argocd-agentctl pki issue resource-proxy --upsert \
-	--principal-namespace ${ARGOCD_AGENT_PRINCIPAL_NAMESPACE} \
-	${ARGOCD_AGENT_RESOURCE_PROXY_SAN}
-echo "  -> Resource proxy TLS config created."
+if ! kubectl -n ${ARGOCD_AGENT_PRINCIPAL_NAMESPACE} get secret argocd-agent-resource-proxy-tls >/dev/null 2>&1; then
+	argocd-agentctl pki issue resource-proxy --upsert \
+		--principal-namespace ${ARGOCD_AGENT_PRINCIPAL_NAMESPACE} \
+		${ARGOCD_AGENT_RESOURCE_PROXY_SAN}
+	echo "  -> Resource proxy TLS config created."
+else
+	echo "  -> Reusing existing resource proxy TLS config."
+fi
 
 echo "[*] Creating JWT signing key and secret"
-argocd-agentctl jwt create-key --upsert
+if ! kubectl -n ${ARGOCD_AGENT_PRINCIPAL_NAMESPACE} get secret argocd-agent-jwt >/dev/null 2>&1; then
+	argocd-agentctl jwt create-key --upsert
+else
+	echo "  -> Reusing existing JWT signing key."
+fi
 
 AGENTS="agent-managed agent-autonomous"
 for agent in ${AGENTS}; do
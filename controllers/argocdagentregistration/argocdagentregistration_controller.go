@@ -0,0 +1,496 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdagentregistration
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logr "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// BootstrapKeyPrincipalAddress is the key of the address of the principal in the bootstrap bundle
+	BootstrapKeyPrincipalAddress = "principal.address"
+	// BootstrapKeyPrincipalPort is the key of the port of the principal in the bootstrap bundle
+	BootstrapKeyPrincipalPort = "principal.port"
+	// BootstrapKeyAgentName is the key of the name of the agent in the bootstrap bundle
+	BootstrapKeyAgentName = "agent.name"
+	// BootstrapKeyAgentMode is the key of the mode of the agent in the bootstrap bundle
+	BootstrapKeyAgentMode = "agent.mode"
+	// BootstrapKeyAgentNamespace is the key of the namespace of the agent in the bootstrap bundle
+	BootstrapKeyAgentNamespace = "agent.namespace"
+
+	defaultAgentNamespace = "argocd"
+
+	// connectionPollInterval is how often the connection state of the agent is refreshed, on top of the events of
+	// the poller of the principal metrics.
+	connectionPollInterval = time.Minute
+)
+
+// blank assignment to verify that ArgoCDAgentRegistrationReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ArgoCDAgentRegistrationReconciler{}
+
+var log = logr.Log.WithName("controller_argocdagentregistration")
+
+// ArgoCDAgentRegistrationReconciler issues the client certificate, the cluster Secret and the bootstrap bundle of the
+// agents registered with the principal of an ArgoCD
+type ArgoCDAgentRegistrationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ConnectionReader reads the connections of the agents to the principal.
+	ConnectionReader argocdagent.AgentConnectionReader
+	// ConnectionEvents receives an event for an ArgoCD whenever the connections of the agents to its principal change,
+	// so that the connection state of its agents is refreshed.
+	ConnectionEvents <-chan event.GenericEvent
+}
+
+// clusterConfig is the config of an Argo CD cluster Secret.
+type clusterConfig struct {
+	TLSClientConfig clusterTLSClientConfig `json:"tlsClientConfig"`
+}
+
+// clusterTLSClientConfig is the TLS client config of an Argo CD cluster Secret.
+type clusterTLSClientConfig struct {
+	Insecure bool   `json:"insecure"`
+	CertData []byte `json:"certData,omitempty"`
+	KeyData  []byte `json:"keyData,omitempty"`
+	CAData   []byte `json:"caData,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=argoproj.io,resources=argocdagentregistrations;argocdagentregistrations/finalizers;argocdagentregistrations/status,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=argoproj.io,resources=applications,verbs=get;list;watch;update;patch
+
+// Reconcile issues a client certificate from the CA of the principal for the registered agent, and creates the Argo CD
+// cluster Secret of the agent and a bootstrap bundle Secret holding what the agent needs to connect to the principal.
+func (r *ArgoCDAgentRegistrationReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := logr.FromContext(ctx, "Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling ArgoCDAgentRegistration")
+
+	registration := &argoproj.ArgoCDAgentRegistration{}
+	if err := r.Get(ctx, request.NamespacedName, registration); err != nil {
+		if errors.IsNotFound(err) {
+			// The Secrets of the agent are garbage collected through their owner reference.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if registration.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	status := registration.Status.DeepCopy()
+	status.AgentName = getAgentName(registration)
+
+	argocd, err := r.getPrincipalArgoCD(ctx, registration.Namespace)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if argocd == nil {
+		setReadyCondition(registration, status, metav1.ConditionFalse, "PrincipalNotFound",
+			fmt.Sprintf("no ArgoCD with the principal enabled in namespace %s", registration.Namespace))
+		return reconcile.Result{}, r.updateStatus(ctx, registration, status)
+	}
+
+	cert, err := r.reconcileSecrets(ctx, registration, argocd, status)
+	if err != nil {
+		setReadyCondition(registration, status, metav1.ConditionFalse, "ErrorOccurred", err.Error())
+		if updateErr := r.updateStatus(ctx, registration, status); updateErr != nil {
+			reqLogger.Error(updateErr, "unable to update ArgoCDAgentRegistration status")
+		}
+		return reconcile.Result{}, err
+	}
+	if cert == nil {
+		return reconcile.Result{RequeueAfter: connectionPollInterval}, r.updateStatus(ctx, registration, status)
+	}
+	status.CertificateNotAfter = &metav1.Time{Time: cert.NotAfter}
+	setReadyCondition(registration, status, metav1.ConditionTrue, "Registered", "the agent is registered with the principal")
+
+	connection := r.reconcileConnectionState(ctx, argocd, status)
+	if err := r.reconcileModeMigration(ctx, registration, connection, status); err != nil {
+		if updateErr := r.updateStatus(ctx, registration, status); updateErr != nil {
+			reqLogger.Error(updateErr, "unable to update ArgoCDAgentRegistration status")
+		}
+		return reconcile.Result{}, err
+	}
+
+	requeueAfter := connectionPollInterval
+	if d := time.Until(argocdagent.GetCertificateRenewAt(argocd, cert)); d < requeueAfter {
+		requeueAfter = max(d, time.Second)
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, r.updateStatus(ctx, registration, status)
+}
+
+// getPrincipalArgoCD returns the oldest ArgoCD of the given namespace with the principal enabled, or nil if there is
+// none.
+func (r *ArgoCDAgentRegistrationReconciler) getPrincipalArgoCD(ctx context.Context, namespace string) (*argoproj.ArgoCD, error) {
+	list := &argoproj.ArgoCDList{}
+	if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		a, b := list.Items[i], list.Items[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return a.Name < b.Name
+	})
+	for i := range list.Items {
+		argocd := &list.Items[i]
+		if argocd.Spec.ArgoCDAgent != nil && argocd.Spec.ArgoCDAgent.Principal != nil && argocd.Spec.ArgoCDAgent.Principal.IsEnabled() {
+			return argocd, nil
+		}
+	}
+	return nil, nil
+}
+
+// reconcileSecrets ensures the bootstrap bundle Secret and the cluster Secret of the agent, issuing a new client
+// certificate when there is none or it is due for renewal. It returns the client certificate of the agent, or nil if
+// the Secrets could not be written because of a conflict, which is reported in the given status.
+func (r *ArgoCDAgentRegistrationReconciler) reconcileSecrets(ctx context.Context, registration *argoproj.ArgoCDAgentRegistration, argocd *argoproj.ArgoCD, status *argoproj.ArgoCDAgentRegistrationStatus) (*x509.Certificate, error) {
+	agentName := getAgentName(registration)
+
+	bootstrap, bootstrapExists, err := r.getSecret(ctx, registration.Namespace, fmt.Sprintf("%s-bootstrap", registration.Name))
+	if err != nil {
+		return nil, err
+	}
+	cluster, clusterExists, err := r.getSecret(ctx, registration.Namespace, fmt.Sprintf("cluster-%s", agentName))
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range []struct {
+		secret *corev1.Secret
+		exists bool
+	}{{bootstrap, bootstrapExists}, {cluster, clusterExists}} {
+		if secret.exists && !metav1.IsControlledBy(secret.secret, registration) {
+			setReadyCondition(registration, status, metav1.ConditionFalse, "SecretConflict",
+				fmt.Sprintf("the secret %s already exists and is not managed by this ArgoCDAgentRegistration", secret.secret.Name))
+			return nil, nil
+		}
+	}
+	status.BootstrapSecretName = bootstrap.Name
+	status.ClusterSecretName = cluster.Name
+
+	// The trust bundle holds the previous CA during a CA rotation, so that the agent keeps trusting the principal
+	// until it is restarted with its re-issued client certificate.
+	caCert, caKey, caPEM, err := argocdagent.GetPrincipalCA(r.Client, argocd)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, certPEM, keyPEM := getIssuedCertificate(bootstrap, agentName, caCert)
+	if cert == nil || !time.Now().Before(argocdagent.GetCertificateRenewAt(argocd, cert)) {
+		cert, certPEM, keyPEM, err = argocdagent.IssueAgentClientCertificate(argocd, agentName, caCert, caKey)
+		if err != nil {
+			return nil, err
+		}
+		log.Info(fmt.Sprintf("issued the client certificate of agent %s, valid until %s", agentName, cert.NotAfter.Format(time.RFC3339)))
+	}
+
+	address := registration.Spec.PrincipalAddress
+	if address == "" {
+		if address, err = argocdagent.GetPrincipalAddress(r.Client, argocd); err != nil {
+			return nil, err
+		}
+	}
+	port := registration.Spec.PrincipalPort
+	if port == 0 {
+		port = argocdagent.PrincipalServiceHTTPSPort
+	}
+	namespace := registration.Spec.Namespace
+	if namespace == "" {
+		namespace = defaultAgentNamespace
+	}
+	mode := getAgentMode(registration)
+
+	bootstrapData := map[string][]byte{
+		corev1.TLSCertKey:              certPEM,
+		corev1.TLSPrivateKeyKey:        keyPEM,
+		corev1.ServiceAccountRootCAKey: caPEM,
+		BootstrapKeyPrincipalAddress:   []byte(address),
+		BootstrapKeyPrincipalPort:      []byte(strconv.Itoa(int(port))),
+		BootstrapKeyAgentName:          []byte(agentName),
+		BootstrapKeyAgentMode:          []byte(mode),
+		BootstrapKeyAgentNamespace:     []byte(namespace),
+	}
+	if err := r.writeSecret(ctx, registration, bootstrap, bootstrapExists, corev1.SecretTypeTLS, nil, bootstrapData); err != nil {
+		return nil, err
+	}
+
+	config, err := json.Marshal(clusterConfig{TLSClientConfig: clusterTLSClientConfig{
+		CertData: certPEM,
+		KeyData:  keyPEM,
+		CAData:   caPEM,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	labels := maps.Clone(registration.Spec.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[common.ArgoCDSecretTypeLabel] = "cluster"
+	labels[common.ArgoCDAgentNameLabel] = agentName
+	clusterData := map[string][]byte{
+		"name":   []byte(agentName),
+		"server": []byte(argocdagent.GetPrincipalResourceProxyServer(argocd, agentName)),
+		"config": config,
+	}
+	if err := r.writeSecret(ctx, registration, cluster, clusterExists, corev1.SecretTypeOpaque, labels, clusterData); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+// reconcileConnectionState updates the connection state and the last seen time of the agent from its connection to
+// the principal. The state is unknown when the principal only reports the number of connected agents, not which ones.
+// The last seen time is the one recorded by the reader, which keeps it once the agent disconnects. It returns the
+// connection of the agent, or nil if it is unknown.
+func (r *ArgoCDAgentRegistrationReconciler) reconcileConnectionState(ctx context.Context, argocd *argoproj.ArgoCD, status *argoproj.ArgoCDAgentRegistrationStatus) *argocdagent.AgentConnection {
+	status.ConnectionState = argoproj.AgentConnectionStateUnknown
+	if r.ConnectionReader == nil {
+		return nil
+	}
+
+	connections, err := r.ConnectionReader.GetAgentConnections(ctx, argocd)
+	if err != nil {
+		log.Info(fmt.Sprintf("unable to read the connection state of agent %s: %v", status.AgentName, err))
+		return nil
+	}
+	if connections == nil || connections.Agents == nil {
+		return nil
+	}
+
+	// An agent that is not reported by the principal has not connected since it started.
+	connection := connections.Agents[status.AgentName]
+	status.ConnectionState = argoproj.AgentConnectionStateDisconnected
+	if connection.Connected {
+		status.ConnectionState = argoproj.AgentConnectionStateConnected
+	}
+	if connection.LastSeen != nil && (status.LastSeen == nil || connection.LastSeen.After(status.LastSeen.Time)) {
+		status.LastSeen = &metav1.Time{Time: *connection.LastSeen}
+	}
+	return &connection
+}
+
+// reconcileModeMigration migrates the agent to the mode of the given ArgoCDAgentRegistration when it is registered in
+// another mode. The Applications of the agent in the principal, which are in the namespace named after the agent, are
+// labelled with the new mode, which owns them from then on, so that they are kept instead of being recreated. The
+// migration completes once all of them are labelled and the agent connected to the principal in the new mode, after
+// it was restarted with its updated bootstrap bundle. When the principal does not report the connection or the mode
+// of the agent, the migration completes once the Applications are labelled and the agent is connected, if known.
+func (r *ArgoCDAgentRegistrationReconciler) reconcileModeMigration(ctx context.Context, registration *argoproj.ArgoCDAgentRegistration, connection *argocdagent.AgentConnection, status *argoproj.ArgoCDAgentRegistrationStatus) error {
+	mode := getAgentMode(registration)
+	if status.Mode == "" {
+		// A new agent is registered in the mode of its spec.
+		status.Mode = mode
+		return nil
+	}
+
+	migration := status.ModeMigration
+	inProgress := migration != nil && migration.Phase != argoproj.AgentModeMigrationPhaseCompleted
+	if !inProgress && status.Mode == mode {
+		return nil
+	}
+	if !inProgress || migration.To != mode {
+		from := status.Mode
+		if inProgress {
+			// The Applications may already be labelled with the mode of the interrupted migration.
+			from = migration.To
+		}
+		migration = &argoproj.AgentModeMigrationStatus{
+			From:      from,
+			To:        mode,
+			StartTime: &metav1.Time{Time: time.Now().Truncate(time.Second)},
+		}
+		status.ModeMigration = migration
+		log.Info(fmt.Sprintf("migrating agent %s from %s to %s mode", status.AgentName, from, mode))
+	}
+
+	migration.Phase = argoproj.AgentModeMigrationPhaseMigratingApplications
+	namespaces := []string{status.AgentName}
+	if _, err := argocdagent.MigrateAgentApplications(ctx, r.Client, namespaces, mode); err != nil {
+		migration.Message = err.Error()
+		return err
+	}
+	total, migrated, err := argocdagent.CountAgentApplications(ctx, r.Client, namespaces, mode)
+	if err != nil {
+		migration.Message = err.Error()
+		return err
+	}
+	migration.Applications = total
+	migration.MigratedApplications = migrated
+	if migrated < total {
+		migration.Message = fmt.Sprintf("%d of %d applications of agent %s are labelled with the %s mode",
+			migrated, total, status.AgentName, mode)
+		return nil
+	}
+
+	switch {
+	case connection == nil:
+		migration.Message = fmt.Sprintf("the applications of agent %s are labelled with the %s mode, the principal does not report the connection of the agent",
+			status.AgentName, mode)
+	case !connection.Connected || (connection.Mode != "" && connection.Mode != string(mode)):
+		migration.Phase = argoproj.AgentModeMigrationPhaseWaitingForAgent
+		migration.Message = fmt.Sprintf("waiting for agent %s to connect to the principal in %s mode", status.AgentName, mode)
+		return nil
+	case connection.Mode == "":
+		migration.Message = fmt.Sprintf("the applications of agent %s are labelled with the %s mode, the principal does not report the mode of the agent",
+			status.AgentName, mode)
+	default:
+		migration.Message = ""
+	}
+	migration.Phase = argoproj.AgentModeMigrationPhaseCompleted
+	migration.CompletionTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+	log.Info(fmt.Sprintf("migrated agent %s from %s to %s mode", status.AgentName, migration.From, mode))
+	status.Mode = mode
+	return nil
+}
+
+func (r *ArgoCDAgentRegistrationReconciler) getSecret(ctx context.Context, namespace, name string) (*corev1.Secret, bool, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, false, err
+		}
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}, false, nil
+	}
+	return secret, true, nil
+}
+
+// writeSecret creates the given Secret, controlled by the given ArgoCDAgentRegistration, or updates it when its labels
+// or data changed.
+func (r *ArgoCDAgentRegistrationReconciler) writeSecret(ctx context.Context, registration *argoproj.ArgoCDAgentRegistration, secret *corev1.Secret, exists bool, secretType corev1.SecretType, labels map[string]string, data map[string][]byte) error {
+	if exists {
+		if reflect.DeepEqual(secret.Data, data) && (labels == nil || reflect.DeepEqual(secret.Labels, labels)) {
+			return nil
+		}
+		secret.Data = data
+		if labels != nil {
+			secret.Labels = labels
+		}
+		argoutil.LogResourceUpdate(log, secret, "updating agent registration secret")
+		return r.Update(ctx, secret)
+	}
+
+	secret.Type = secretType
+	secret.Labels = labels
+	secret.Data = data
+	if err := controllerutil.SetControllerReference(registration, secret, r.Scheme); err != nil {
+		return err
+	}
+	argoutil.LogResourceCreation(log, secret)
+	return r.Create(ctx, secret)
+}
+
+// updateStatus updates the status of the given ArgoCDAgentRegistration when it changed.
+func (r *ArgoCDAgentRegistrationReconciler) updateStatus(ctx context.Context, registration *argoproj.ArgoCDAgentRegistration, status *argoproj.ArgoCDAgentRegistrationStatus) error {
+	if reflect.DeepEqual(registration.Status, *status) {
+		return nil
+	}
+	registration.Status = *status
+	return r.Status().Update(ctx, registration)
+}
+
+// argoCDMapper maps a watch event on an ArgoCD, back to the ArgoCDAgentRegistrations of its namespace, since a change
+// to its principal may change the CA or the address of the principal.
+func (r *ArgoCDAgentRegistrationReconciler) argoCDMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result []reconcile.Request
+
+	registrations := &argoproj.ArgoCDAgentRegistrationList{}
+	if err := r.List(ctx, registrations, client.InNamespace(o.GetNamespace())); err != nil {
+		return result
+	}
+	for _, registration := range registrations.Items {
+		result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&registration)})
+	}
+	return result
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ArgoCDAgentRegistrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&argoproj.ArgoCDAgentRegistration{}).
+		Owns(&corev1.Secret{}).
+		Watches(&argoproj.ArgoCD{}, handler.EnqueueRequestsFromMapFunc(r.argoCDMapper))
+	if r.ConnectionEvents != nil {
+		bldr.WatchesRawSource(source.Channel(r.ConnectionEvents, handler.EnqueueRequestsFromMapFunc(r.argoCDMapper)))
+	}
+	return bldr.Complete(r)
+}
+
+// getIssuedCertificate returns the client certificate and key of the agent with the given name held by the given
+// bootstrap Secret, or nil if there is none signed by the given CA.
+func getIssuedCertificate(bootstrap *corev1.Secret, agentName string, caCert *x509.Certificate) (*x509.Certificate, []byte, []byte) {
+	certPEM, keyPEM := bootstrap.Data[corev1.TLSCertKey], bootstrap.Data[corev1.TLSPrivateKeyKey]
+	if len(keyPEM) == 0 {
+		return nil, nil, nil
+	}
+	cert, err := argoutil.ParsePEMEncodedCert(certPEM)
+	if err != nil || cert.Subject.CommonName != agentName || cert.CheckSignatureFrom(caCert) != nil {
+		return nil, nil, nil
+	}
+	return cert, certPEM, keyPEM
+}
+
+func getAgentName(registration *argoproj.ArgoCDAgentRegistration) string {
+	if registration.Spec.AgentName != "" {
+		return registration.Spec.AgentName
+	}
+	return registration.Name
+}
+
+// getAgentMode returns the mode of the agent of the given ArgoCDAgentRegistration, which is managed when not set.
+func getAgentMode(registration *argoproj.ArgoCDAgentRegistration) argoproj.AgentMode {
+	if registration.Spec.Mode != "" {
+		return registration.Spec.Mode
+	}
+	return argoproj.AgentModeManaged
+}
+
+func setReadyCondition(registration *argoproj.ArgoCDAgentRegistration, status *argoproj.ArgoCDAgentRegistrationStatus, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               argoproj.ArgoCDAgentRegistrationConditionReady,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: registration.Generation,
+	})
+}
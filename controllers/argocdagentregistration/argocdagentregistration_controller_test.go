@@ -0,0 +1,393 @@
+package argocdagentregistration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdagent"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const testNamespace = "argocd"
+
+type fakeConnectionReader struct {
+	connections *argocdagent.AgentConnections
+	err         error
+}
+
+func (f *fakeConnectionReader) GetAgentConnections(ctx context.Context, cr *argoproj.ArgoCD) (*argocdagent.AgentConnections, error) {
+	return f.connections, f.err
+}
+
+func makeTestArgoCD() *argoproj.ArgoCD {
+	return &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: testNamespace, UID: "argocd-uid"},
+		Spec: argoproj.ArgoCDSpec{
+			ArgoCDAgent: &argoproj.ArgoCDAgentSpec{
+				Principal: &argoproj.PrincipalSpec{Enabled: ptr.To(true)},
+			},
+		},
+	}
+}
+
+func makeTestRegistration() *argoproj.ArgoCDAgentRegistration {
+	return &argoproj.ArgoCDAgentRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-1", Namespace: testNamespace, UID: "registration-uid"},
+		Spec: argoproj.ArgoCDAgentRegistrationSpec{
+			Mode:   argoproj.AgentModeAutonomous,
+			Labels: map[string]string{"environment": "production"},
+		},
+	}
+}
+
+func makeTestCASecret(t *testing.T) *corev1.Secret {
+	key, err := argoutil.NewPrivateKey()
+	require.NoError(t, err)
+	cert, err := argoutil.NewSelfSignedCACertificate("argocd-agent-ca", key)
+	require.NoError(t, err)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-agent-ca", Namespace: testNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       argoutil.EncodeCertificatePEM(cert),
+			corev1.TLSPrivateKeyKey: argoutil.EncodePrivateKeyPEM(key),
+		},
+	}
+}
+
+func makeTestReconciler(t *testing.T, reader argocdagent.AgentConnectionReader, objs ...client.Object) *ArgoCDAgentRegistrationReconciler {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, argoproj.AddToScheme(s))
+	require.NoError(t, argocdv1alpha1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).
+		WithStatusSubresource(&argoproj.ArgoCDAgentRegistration{}).Build()
+	return &ArgoCDAgentRegistrationReconciler{Client: cl, Scheme: s, ConnectionReader: reader}
+}
+
+func TestArgoCDAgentRegistrationReconciler_Reconcile(t *testing.T) {
+	registration := makeTestRegistration()
+	caSecret := makeTestCASecret(t)
+	r := makeTestReconciler(t, nil, makeTestArgoCD(), registration, caSecret)
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(registration)}
+	ctx := context.TODO()
+
+	result, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, connectionPollInterval, result.RequeueAfter)
+
+	bootstrap := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "workload-1-bootstrap"}, bootstrap))
+	assert.True(t, metav1.IsControlledBy(bootstrap, registration))
+	assert.Equal(t, corev1.SecretTypeTLS, bootstrap.Type)
+	assert.Equal(t, caSecret.Data[corev1.TLSCertKey], bootstrap.Data[corev1.ServiceAccountRootCAKey])
+	assert.Equal(t, "argocd-agent-principal.argocd.svc", string(bootstrap.Data[BootstrapKeyPrincipalAddress]))
+	assert.Equal(t, "443", string(bootstrap.Data[BootstrapKeyPrincipalPort]))
+	assert.Equal(t, "workload-1", string(bootstrap.Data[BootstrapKeyAgentName]))
+	assert.Equal(t, "autonomous", string(bootstrap.Data[BootstrapKeyAgentMode]))
+	assert.Equal(t, "argocd", string(bootstrap.Data[BootstrapKeyAgentNamespace]))
+
+	cert, err := argoutil.ParsePEMEncodedCert(bootstrap.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	caCert, err := argoutil.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.Equal(t, "workload-1", cert.Subject.CommonName)
+
+	cluster := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "cluster-workload-1"}, cluster))
+	assert.True(t, metav1.IsControlledBy(cluster, registration))
+	assert.Equal(t, "cluster", cluster.Labels[common.ArgoCDSecretTypeLabel])
+	assert.Equal(t, "workload-1", cluster.Labels[common.ArgoCDAgentNameLabel])
+	assert.Equal(t, "production", cluster.Labels["environment"])
+	assert.Equal(t, "workload-1", string(cluster.Data["name"]))
+	assert.Equal(t, argocdagent.GetPrincipalResourceProxyServer(makeTestArgoCD(), "workload-1"), string(cluster.Data["server"]))
+	config := clusterConfig{}
+	require.NoError(t, json.Unmarshal(cluster.Data["config"], &config))
+	assert.Equal(t, bootstrap.Data[corev1.TLSCertKey], config.TLSClientConfig.CertData)
+	assert.Equal(t, bootstrap.Data[corev1.TLSPrivateKeyKey], config.TLSClientConfig.KeyData)
+	assert.Equal(t, caSecret.Data[corev1.TLSCertKey], config.TLSClientConfig.CAData)
+
+	require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+	assert.Equal(t, "workload-1", registration.Status.AgentName)
+	assert.Equal(t, "workload-1-bootstrap", registration.Status.BootstrapSecretName)
+	assert.Equal(t, "cluster-workload-1", registration.Status.ClusterSecretName)
+	assert.Equal(t, cert.NotAfter.Unix(), registration.Status.CertificateNotAfter.Unix())
+	assert.Equal(t, argoproj.AgentConnectionStateUnknown, registration.Status.ConnectionState)
+	assert.True(t, meta.IsStatusConditionTrue(registration.Status.Conditions, argoproj.ArgoCDAgentRegistrationConditionReady))
+
+	// A second reconciliation keeps the issued certificate.
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	updated := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(bootstrap), updated))
+	assert.Equal(t, bootstrap.Data[corev1.TLSCertKey], updated.Data[corev1.TLSCertKey])
+}
+
+func TestArgoCDAgentRegistrationReconciler_ReissuesCertificateOnCAChange(t *testing.T) {
+	registration := makeTestRegistration()
+	r := makeTestReconciler(t, nil, makeTestArgoCD(), registration, makeTestCASecret(t))
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(registration)}
+	ctx := context.TODO()
+
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	bootstrap := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "workload-1-bootstrap"}, bootstrap))
+
+	// Rotating the CA of the principal reissues the client certificate of the agent, which trusts both the new and the
+	// previous CA until the previous one is retired.
+	caSecret := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "argocd-agent-ca"}, caSecret))
+	previousCA := caSecret.Data[corev1.TLSCertKey]
+	caSecret.Data = makeTestCASecret(t).Data
+	caSecret.Data[corev1.ServiceAccountRootCAKey] = append(append([]byte{}, caSecret.Data[corev1.TLSCertKey]...), previousCA...)
+	require.NoError(t, r.Update(ctx, caSecret))
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	updated := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(bootstrap), updated))
+	assert.NotEqual(t, bootstrap.Data[corev1.TLSCertKey], updated.Data[corev1.TLSCertKey])
+	assert.Equal(t, caSecret.Data[corev1.ServiceAccountRootCAKey], updated.Data[corev1.ServiceAccountRootCAKey])
+}
+
+func TestArgoCDAgentRegistrationReconciler_PrincipalNotFound(t *testing.T) {
+	registration := makeTestRegistration()
+	argocd := makeTestArgoCD()
+	argocd.Spec.ArgoCDAgent.Principal.Enabled = ptr.To(false)
+	r := makeTestReconciler(t, nil, argocd, registration)
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(registration)}
+	ctx := context.TODO()
+
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+	condition := meta.FindStatusCondition(registration.Status.Conditions, argoproj.ArgoCDAgentRegistrationConditionReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "PrincipalNotFound", condition.Reason)
+}
+
+func TestArgoCDAgentRegistrationReconciler_SecretConflict(t *testing.T) {
+	registration := makeTestRegistration()
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-workload-1", Namespace: testNamespace},
+		Data:       map[string][]byte{"name": []byte("workload-1")},
+	}
+	r := makeTestReconciler(t, nil, makeTestArgoCD(), registration, makeTestCASecret(t), existing)
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(registration)}
+	ctx := context.TODO()
+
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(existing), secret))
+	assert.Equal(t, existing.Data, secret.Data)
+
+	require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+	condition := meta.FindStatusCondition(registration.Status.Conditions, argoproj.ArgoCDAgentRegistrationConditionReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "SecretConflict", condition.Reason)
+}
+
+func TestArgoCDAgentRegistrationReconciler_ConnectionState(t *testing.T) {
+	lastSeen := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	earlier := &metav1.Time{Time: lastSeen.Add(-time.Hour)}
+
+	tests := []struct {
+		name             string
+		reader           argocdagent.AgentConnectionReader
+		lastSeen         *metav1.Time
+		expectedState    string
+		expectedLastSeen *metav1.Time
+	}{
+		{
+			name: "agent connected",
+			reader: &fakeConnectionReader{connections: &argocdagent.AgentConnections{Connected: 1, Agents: map[string]argocdagent.AgentConnection{
+				"workload-1": {Connected: true, Mode: string(argoproj.AgentModeAutonomous), LastSeen: &lastSeen},
+			}}},
+			lastSeen:         earlier,
+			expectedState:    argoproj.AgentConnectionStateConnected,
+			expectedLastSeen: &metav1.Time{Time: lastSeen},
+		},
+		{
+			name: "agent disconnected while another agent is connected",
+			reader: &fakeConnectionReader{connections: &argocdagent.AgentConnections{Connected: 1, Agents: map[string]argocdagent.AgentConnection{
+				"workload-1": {LastSeen: &lastSeen},
+				"workload-2": {Connected: true, LastSeen: &lastSeen},
+			}}},
+			expectedState:    argoproj.AgentConnectionStateDisconnected,
+			expectedLastSeen: &metav1.Time{Time: lastSeen},
+		},
+		{
+			name: "agent not reported by the principal",
+			reader: &fakeConnectionReader{connections: &argocdagent.AgentConnections{Connected: 1, Agents: map[string]argocdagent.AgentConnection{
+				"workload-2": {Connected: true, LastSeen: &lastSeen},
+			}}},
+			lastSeen:         earlier,
+			expectedState:    argoproj.AgentConnectionStateDisconnected,
+			expectedLastSeen: earlier,
+		},
+		{
+			name:          "only the number of connected agents reported",
+			reader:        &fakeConnectionReader{connections: &argocdagent.AgentConnections{Connected: 1}},
+			expectedState: argoproj.AgentConnectionStateUnknown,
+		},
+		{
+			name:          "connections not reported",
+			reader:        &fakeConnectionReader{},
+			expectedState: argoproj.AgentConnectionStateUnknown,
+		},
+		{
+			name:             "principal unreachable",
+			reader:           &fakeConnectionReader{err: errors.New("connection refused")},
+			lastSeen:         earlier,
+			expectedState:    argoproj.AgentConnectionStateUnknown,
+			expectedLastSeen: earlier,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registration := makeTestRegistration()
+			registration.Status.LastSeen = test.lastSeen
+			r := makeTestReconciler(t, test.reader, makeTestArgoCD(), registration, makeTestCASecret(t))
+			request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(registration)}
+			ctx := context.TODO()
+
+			_, err := r.Reconcile(ctx, request)
+			require.NoError(t, err)
+
+			require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+			assert.Equal(t, test.expectedState, registration.Status.ConnectionState)
+			if test.expectedLastSeen == nil {
+				assert.Nil(t, registration.Status.LastSeen)
+			} else {
+				require.NotNil(t, registration.Status.LastSeen)
+				assert.True(t, test.expectedLastSeen.Equal(registration.Status.LastSeen))
+			}
+		})
+	}
+}
+
+func makeTestApplication(name, namespace string) *argocdv1alpha1.Application {
+	return &argocdv1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+}
+
+func makeTestAgentConnections(mode argoproj.AgentMode) *argocdagent.AgentConnections {
+	return &argocdagent.AgentConnections{Connected: 1, Agents: map[string]argocdagent.AgentConnection{
+		"workload-1": {Connected: true, Mode: string(mode)},
+	}}
+}
+
+func TestArgoCDAgentRegistrationReconciler_ModeMigration(t *testing.T) {
+	registration := makeTestRegistration()
+	reader := &fakeConnectionReader{connections: makeTestAgentConnections(argoproj.AgentModeAutonomous)}
+	r := makeTestReconciler(t, reader, makeTestArgoCD(), registration, makeTestCASecret(t),
+		makeTestApplication("guestbook", "workload-1"), makeTestApplication("payments", "workload-1"),
+		makeTestApplication("unrelated", "workload-2"))
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(registration)}
+	ctx := context.TODO()
+
+	// A new agent is registered in the mode of its spec.
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+	assert.Equal(t, argoproj.AgentModeAutonomous, registration.Status.Mode)
+	assert.Nil(t, registration.Status.ModeMigration)
+
+	// The Applications of the agent are labelled with the new mode, and the migration waits for the agent to connect
+	// in it.
+	registration.Spec.Mode = argoproj.AgentModeManaged
+	require.NoError(t, r.Update(ctx, registration))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+	assert.Equal(t, argoproj.AgentModeAutonomous, registration.Status.Mode)
+	migration := registration.Status.ModeMigration
+	require.NotNil(t, migration)
+	assert.Equal(t, argoproj.AgentModeAutonomous, migration.From)
+	assert.Equal(t, argoproj.AgentModeManaged, migration.To)
+	assert.Equal(t, argoproj.AgentModeMigrationPhaseWaitingForAgent, migration.Phase)
+	assert.Equal(t, int32(2), migration.Applications)
+	assert.Equal(t, int32(2), migration.MigratedApplications)
+	assert.NotNil(t, migration.StartTime)
+	assert.Nil(t, migration.CompletionTime)
+
+	for _, key := range []types.NamespacedName{{Namespace: "workload-1", Name: "guestbook"}, {Namespace: "workload-1", Name: "payments"}} {
+		app := &argocdv1alpha1.Application{}
+		require.NoError(t, r.Get(ctx, key, app))
+		assert.Equal(t, string(argoproj.AgentModeManaged), app.Labels[common.ArgoCDAgentModeLabel], key.String())
+	}
+	app := &argocdv1alpha1.Application{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "workload-2", Name: "unrelated"}, app))
+	assert.NotContains(t, app.Labels, common.ArgoCDAgentModeLabel)
+
+	bootstrap := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "workload-1-bootstrap"}, bootstrap))
+	assert.Equal(t, "managed", string(bootstrap.Data[BootstrapKeyAgentMode]))
+
+	// The migration completes once the agent connected in the new mode.
+	reader.connections = makeTestAgentConnections(argoproj.AgentModeManaged)
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+	assert.Equal(t, argoproj.AgentModeManaged, registration.Status.Mode)
+	migration = registration.Status.ModeMigration
+	assert.Equal(t, argoproj.AgentModeMigrationPhaseCompleted, migration.Phase)
+	assert.NotNil(t, migration.CompletionTime)
+
+	// The last migration is kept once the agent runs in the new mode.
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+	assert.Equal(t, migration.StartTime, registration.Status.ModeMigration.StartTime)
+	assert.Equal(t, migration.CompletionTime, registration.Status.ModeMigration.CompletionTime)
+}
+
+func TestArgoCDAgentRegistrationReconciler_ModeMigration_connectionNotReported(t *testing.T) {
+	registration := makeTestRegistration()
+	registration.Status.Mode = argoproj.AgentModeManaged
+	r := makeTestReconciler(t, &fakeConnectionReader{connections: &argocdagent.AgentConnections{Connected: 1}},
+		makeTestArgoCD(), registration, makeTestCASecret(t), makeTestApplication("guestbook", "workload-1"))
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(registration)}
+	ctx := context.TODO()
+
+	// The migration completes once the Applications are labelled, as the principal does not report which agents are
+	// connected.
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, request.NamespacedName, registration))
+	assert.Equal(t, argoproj.AgentModeAutonomous, registration.Status.Mode)
+	migration := registration.Status.ModeMigration
+	require.NotNil(t, migration)
+	assert.Equal(t, argoproj.AgentModeMigrationPhaseCompleted, migration.Phase)
+	assert.Equal(t, int32(1), migration.MigratedApplications)
+
+	app := &argocdv1alpha1.Application{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "workload-1", Name: "guestbook"}, app))
+	assert.Equal(t, string(argoproj.AgentModeAutonomous), app.Labels[common.ArgoCDAgentModeLabel])
+}
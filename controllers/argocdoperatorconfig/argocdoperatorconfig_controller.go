@@ -0,0 +1,119 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdoperatorconfig
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logr "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// blank assignment to verify that ArgoCDOperatorConfigReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ArgoCDOperatorConfigReconciler{}
+
+var log = logr.Log.WithName("controller_argocdoperatorconfig")
+
+// ArgoCDOperatorConfigReconciler reconciles the ArgoCDOperatorConfig singleton
+type ArgoCDOperatorConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=argoproj.io,resources=argocdoperatorconfigs;argocdoperatorconfigs/status,verbs=get;list;watch;update;patch
+
+// Reconcile applies the operator-wide settings of the ArgoCDOperatorConfig and reports their effective values in its
+// status. The ArgoCD instances are reconciled by the ArgoCD controller, which watches the ArgoCDOperatorConfig as well.
+func (r *ArgoCDOperatorConfigReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := logr.FromContext(ctx, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling ArgoCDOperatorConfig")
+
+	cfg := &argoproj.ArgoCDOperatorConfig{}
+	if err := r.Get(ctx, request.NamespacedName, cfg); err != nil {
+		if errors.IsNotFound(err) {
+			// The settings fall back to the environment of the operator once the ArgoCDOperatorConfig is deleted.
+			if request.Name == argoproj.ArgoCDOperatorConfigName {
+				_ = argoutil.ApplyOperatorConfig(nil)
+			}
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	status := cfg.Status.DeepCopy()
+	validCondition := metav1.Condition{
+		Type:               argoproj.ArgoCDOperatorConfigConditionValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Valid",
+		Message:            "the operator settings are applied",
+		ObservedGeneration: cfg.Generation,
+	}
+	if cfg.Name != argoproj.ArgoCDOperatorConfigName {
+		// Only the singleton is applied, other ArgoCDOperatorConfigs are reported as invalid and otherwise ignored.
+		validCondition.Status = metav1.ConditionFalse
+		validCondition.Reason = "InvalidName"
+		validCondition.Message = "the ArgoCDOperatorConfig must be named " + argoproj.ArgoCDOperatorConfigName
+	} else if err := argoutil.ApplyOperatorConfig(cfg); err != nil {
+		reqLogger.Error(err, "ignoring invalid ArgoCDOperatorConfig, the operator settings fall back to the environment")
+		validCondition.Status = metav1.ConditionFalse
+		validCondition.Reason = "InvalidSettings"
+		validCondition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, validCondition)
+
+	status.Settings = argoutil.GetEffectiveOperatorSettings()
+	restartCondition := metav1.Condition{
+		Type:               argoproj.ArgoCDOperatorConfigConditionRestartRequired,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Applied",
+		Message:            "all the operator settings are in effect",
+		ObservedGeneration: cfg.Generation,
+	}
+	for _, setting := range status.Settings {
+		if setting.RestartRequired {
+			restartCondition.Status = metav1.ConditionTrue
+			restartCondition.Reason = "RestartRequired"
+			restartCondition.Message = "the operator must be restarted for " + setting.Name + " to take effect"
+			break
+		}
+	}
+	meta.SetStatusCondition(&status.Conditions, restartCondition)
+
+	if !reflect.DeepEqual(cfg.Status, *status) {
+		cfg.Status = *status
+		if err := r.Status().Update(ctx, cfg); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ArgoCDOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&argoproj.ArgoCDOperatorConfig{}).
+		Complete(r)
+}
@@ -0,0 +1,61 @@
+package argocdoperatorconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func TestArgoCDOperatorConfigReconciler_Reconcile(t *testing.T) {
+	defer func() {
+		_ = argoutil.ApplyOperatorConfig(nil)
+	}()
+
+	cfg := &argoproj.ArgoCDOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: argoproj.ArgoCDOperatorConfigName},
+		Spec: argoproj.ArgoCDOperatorConfigSpec{
+			ClusterConfigNamespaces: []string{"argocd"},
+		},
+	}
+	s := runtime.NewScheme()
+	require.NoError(t, argoproj.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(cfg).WithStatusSubresource(cfg).Build()
+	r := &ArgoCDOperatorConfigReconciler{Client: cl, Scheme: s}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: argoproj.ArgoCDOperatorConfigName}}
+
+	_, err := r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	require.NoError(t, cl.Get(context.TODO(), request.NamespacedName, cfg))
+	assert.True(t, meta.IsStatusConditionTrue(cfg.Status.Conditions, argoproj.ArgoCDOperatorConfigConditionValid))
+	assert.True(t, meta.IsStatusConditionFalse(cfg.Status.Conditions, argoproj.ArgoCDOperatorConfigConditionRestartRequired))
+	assert.Contains(t, cfg.Status.Settings, argoproj.ArgoCDOperatorSetting{
+		Name:   common.ArgoCDClusterConfigNamespacesEnvName,
+		Value:  "argocd",
+		Source: argoproj.ArgoCDOperatorSettingSourceConfig,
+	})
+	assert.True(t, argoutil.IsNamespaceClusterConfigNamespace("argocd"))
+
+	// An invalid ArgoCDOperatorConfig is reported and the settings fall back to the environment.
+	cfg.Spec.ClusterConfigNamespaces = []string{"not a namespace"}
+	require.NoError(t, cl.Update(context.TODO(), cfg))
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	require.NoError(t, cl.Get(context.TODO(), request.NamespacedName, cfg))
+	valid := meta.FindStatusCondition(cfg.Status.Conditions, argoproj.ArgoCDOperatorConfigConditionValid)
+	require.NotNil(t, valid)
+	assert.Equal(t, metav1.ConditionFalse, valid.Status)
+	assert.Equal(t, "InvalidSettings", valid.Reason)
+	assert.False(t, argoutil.IsNamespaceClusterConfigNamespace("argocd"))
+}
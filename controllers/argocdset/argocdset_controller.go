@@ -0,0 +1,357 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdset
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logr "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// blank assignment to verify that ArgoCDSetReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ArgoCDSetReconciler{}
+
+var log = logr.Log.WithName("controller_argocdset")
+
+// ArgoCDSetReconciler reconciles the ArgoCD instances of an ArgoCDSet
+type ArgoCDSetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// argoCDSetInstance is the ArgoCD of an ArgoCDSet in a selected namespace.
+type argoCDSetInstance struct {
+	namespace *corev1.Namespace
+	existing  *argoproj.ArgoCD
+	rendered  *argoproj.ArgoCDSetTemplate
+	checksum  string
+	status    argoproj.ArgoCDSetInstanceStatus
+}
+
+//+kubebuilder:rbac:groups=argoproj.io,resources=argocdsets;argocdsets/finalizers;argocdsets/status,verbs=get;list;watch;update;patch
+
+// Reconcile creates, updates and deletes the ArgoCD instances of an ArgoCDSet so that there is one ArgoCD in each of the
+// selected namespaces. The ArgoCD instances are created or updated in batches, in the order of their namespace.
+func (r *ArgoCDSetReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := logr.FromContext(ctx, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling ArgoCDSet")
+
+	set := &argoproj.ArgoCDSet{}
+	if err := r.Get(ctx, request.NamespacedName, set); err != nil {
+		if errors.IsNotFound(err) {
+			// The ArgoCD instances are garbage collected through their owner reference.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if set.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	status := set.Status.DeepCopy()
+	instances, err := r.reconcileInstances(ctx, set)
+	if err != nil {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               argoproj.ArgoCDSetConditionRolloutComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ErrorOccurred",
+			Message:            err.Error(),
+			ObservedGeneration: set.Generation,
+		})
+		if updateErr := r.updateStatus(ctx, set, status); updateErr != nil {
+			reqLogger.Error(updateErr, "unable to update ArgoCDSet status")
+		}
+		return reconcile.Result{}, err
+	}
+
+	status.Instances = nil
+	status.Desired = int32(len(instances))
+	status.UpToDate = 0
+	status.Available = 0
+	for _, instance := range instances {
+		status.Instances = append(status.Instances, instance.status)
+		if instance.status.UpToDate {
+			status.UpToDate++
+			if isArgoCDAvailable(instance.existing) {
+				status.Available++
+			}
+		}
+	}
+
+	rolloutCondition := metav1.Condition{
+		Type:               argoproj.ArgoCDSetConditionRolloutComplete,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Complete",
+		Message:            "all the ArgoCD instances are up to date and available",
+		ObservedGeneration: set.Generation,
+	}
+	if status.Available < status.Desired {
+		rolloutCondition.Status = metav1.ConditionFalse
+		rolloutCondition.Reason = "InProgress"
+		rolloutCondition.Message = fmt.Sprintf("%d of %d ArgoCD instances are up to date and available", status.Available, status.Desired)
+	}
+	meta.SetStatusCondition(&status.Conditions, rolloutCondition)
+
+	return reconcile.Result{}, r.updateStatus(ctx, set, status)
+}
+
+// reconcileInstances deletes the ArgoCD instances of the ArgoCDSet in the namespaces that are no longer selected and
+// creates or updates the next batch of ArgoCD instances in the selected namespaces.
+func (r *ArgoCDSetReconciler) reconcileInstances(ctx context.Context, set *argoproj.ArgoCDSet) ([]*argoCDSetInstance, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&set.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	sort.Slice(namespaces.Items, func(i, j int) bool {
+		return namespaces.Items[i].Name < namespaces.Items[j].Name
+	})
+
+	existing := &argoproj.ArgoCDList{}
+	if err := r.List(ctx, existing, client.MatchingLabels{common.ArgoCDSetLabel: set.Name}); err != nil {
+		return nil, err
+	}
+
+	// Delete the ArgoCD instances in the namespaces that are no longer selected.
+	selected := map[string]bool{}
+	for _, ns := range namespaces.Items {
+		if ns.DeletionTimestamp == nil {
+			selected[ns.Name] = true
+		}
+	}
+	for i := range existing.Items {
+		argocd := &existing.Items[i]
+		if selected[argocd.Namespace] || !metav1.IsControlledBy(argocd, set) || argocd.DeletionTimestamp != nil {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting ArgoCD %s/%s of ArgoCDSet %s", argocd.Namespace, argocd.Name, set.Name))
+		if err := r.Delete(ctx, argocd); err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	var instances []*argoCDSetInstance
+	inProgress := int32(0)
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if !selected[ns.Name] {
+			continue
+		}
+		instance, err := r.getInstance(ctx, set, ns)
+		if err != nil {
+			return nil, err
+		}
+		if instance.status.UpToDate && !isArgoCDAvailable(instance.existing) {
+			inProgress++
+		}
+		instances = append(instances, instance)
+	}
+
+	// The ArgoCD instances of a batch count against the batch size until they are available.
+	batchSize := int32(len(instances))
+	if set.Spec.Rollout.BatchSize != nil {
+		batchSize = *set.Spec.Rollout.BatchSize
+	}
+	for _, instance := range instances {
+		if instance.status.UpToDate || instance.rendered == nil {
+			continue
+		}
+		if inProgress >= batchSize {
+			instance.status.Message = "waiting for the previous batch to become available"
+			continue
+		}
+		if err := r.applyInstance(ctx, set, instance); err != nil {
+			if errors.IsInvalid(err) || errors.IsForbidden(err) {
+				instance.status.Message = err.Error()
+				continue
+			}
+			return nil, err
+		}
+		inProgress++
+	}
+
+	return instances, nil
+}
+
+// getInstance renders the ArgoCD of the ArgoCDSet in the given namespace and compares it with the existing ArgoCD. The
+// namespace is skipped when it already has another ArgoCD, since only one ArgoCD per namespace is supported.
+func (r *ArgoCDSetReconciler) getInstance(ctx context.Context, set *argoproj.ArgoCDSet, ns *corev1.Namespace) (*argoCDSetInstance, error) {
+	instance := &argoCDSetInstance{
+		namespace: ns,
+		status:    argoproj.ArgoCDSetInstanceStatus{Namespace: ns.Name},
+	}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.List(ctx, argocds, client.InNamespace(ns.Name)); err != nil {
+		return nil, err
+	}
+	for i := range argocds.Items {
+		argocd := &argocds.Items[i]
+		switch {
+		case argocd.Name == set.Name && !metav1.IsControlledBy(argocd, set):
+			instance.status.Message = fmt.Sprintf("ArgoCD %s/%s already exists and is not managed by the ArgoCDSet", ns.Name, set.Name)
+			return instance, nil
+		case argocd.Name != set.Name:
+			instance.status.Message = fmt.Sprintf("namespace %s already has the ArgoCD %s, only one ArgoCD per namespace is supported", ns.Name, argocd.Name)
+			return instance, nil
+		}
+		instance.existing = argocd
+		instance.status.Phase = argocd.Status.Phase
+	}
+
+	rendered, err := renderTemplate(set, ns)
+	if err != nil {
+		instance.status.Message = err.Error()
+		return instance, nil
+	}
+	checksum, err := templateChecksum(rendered)
+	if err != nil {
+		return nil, err
+	}
+	instance.rendered = rendered
+	instance.checksum = checksum
+	if instance.existing == nil || instance.existing.Annotations[common.ArgoCDSetChecksum] != checksum {
+		return instance, nil
+	}
+
+	// The spec of the ArgoCD is compared with the one stored when it was last applied, rather than with the rendered
+	// spec which lacks the defaults set by the API server, so that changes made directly to it are reverted.
+	liveChecksum, err := specChecksum(&instance.existing.Spec)
+	if err != nil {
+		return nil, err
+	}
+	instance.status.UpToDate = instance.existing.Annotations[common.ArgoCDSetSpecChecksum] == liveChecksum
+	return instance, nil
+}
+
+// applyInstance creates or updates the ArgoCD of the given instance from its rendered template.
+func (r *ArgoCDSetReconciler) applyInstance(ctx context.Context, set *argoproj.ArgoCDSet, instance *argoCDSetInstance) error {
+	argocd := instance.existing
+	if argocd == nil {
+		argocd = &argoproj.ArgoCD{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      set.Name,
+				Namespace: instance.namespace.Name,
+			},
+		}
+	}
+
+	if argocd.Labels == nil {
+		argocd.Labels = map[string]string{}
+	}
+	for key, value := range instance.rendered.Labels {
+		argocd.Labels[key] = value
+	}
+	argocd.Labels[common.ArgoCDSetLabel] = set.Name
+
+	if argocd.Annotations == nil {
+		argocd.Annotations = map[string]string{}
+	}
+	for key, value := range instance.rendered.Annotations {
+		argocd.Annotations[key] = value
+	}
+	argocd.Annotations[common.ArgoCDSetChecksum] = instance.checksum
+	argocd.Spec = instance.rendered.Spec
+
+	if instance.existing == nil {
+		if err := controllerutil.SetControllerReference(set, argocd, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating ArgoCD %s/%s of ArgoCDSet %s", argocd.Namespace, argocd.Name, set.Name))
+		if err := r.Create(ctx, argocd); err != nil {
+			return err
+		}
+	} else {
+		log.Info(fmt.Sprintf("updating ArgoCD %s/%s of ArgoCDSet %s", argocd.Namespace, argocd.Name, set.Name))
+		if err := r.Update(ctx, argocd); err != nil {
+			return err
+		}
+	}
+
+	// The checksum of the spec is only known once the API server stored it with its defaults.
+	liveChecksum, err := specChecksum(&argocd.Spec)
+	if err != nil {
+		return err
+	}
+	if argocd.Annotations[common.ArgoCDSetSpecChecksum] != liveChecksum {
+		argocd.Annotations[common.ArgoCDSetSpecChecksum] = liveChecksum
+		if err := r.Update(ctx, argocd); err != nil {
+			return err
+		}
+	}
+
+	instance.existing = argocd
+	instance.status.UpToDate = true
+	instance.status.Phase = argocd.Status.Phase
+	return nil
+}
+
+// isArgoCDAvailable returns whether the given ArgoCD was reconciled since it was last changed and is available.
+func isArgoCDAvailable(argocd *argoproj.ArgoCD) bool {
+	return argocd != nil && argocd.Status.ObservedGeneration >= argocd.Generation && argocd.Status.Phase == "Available"
+}
+
+// updateStatus updates the status of the given ArgoCDSet when it changed.
+func (r *ArgoCDSetReconciler) updateStatus(ctx context.Context, set *argoproj.ArgoCDSet, status *argoproj.ArgoCDSetStatus) error {
+	if reflect.DeepEqual(set.Status, *status) {
+		return nil
+	}
+	set.Status = *status
+	return r.Status().Update(ctx, set)
+}
+
+// namespaceMapper maps a watch event on a namespace, back to all the ArgoCDSets, since a change to the labels or
+// annotations of the namespace may change its selection or the parameters of its ArgoCD.
+func (r *ArgoCDSetReconciler) namespaceMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result []reconcile.Request
+
+	sets := &argoproj.ArgoCDSetList{}
+	if err := r.List(ctx, sets); err != nil {
+		return result
+	}
+	for _, set := range sets.Items {
+		result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{Name: set.Name}})
+	}
+	return result
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ArgoCDSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&argoproj.ArgoCDSet{}).
+		Owns(&argoproj.ArgoCD{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.namespaceMapper)).
+		Complete(r)
+}
@@ -0,0 +1,210 @@
+package argocdset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func makeTestArgoCDSet() *argoproj.ArgoCDSet {
+	return &argoproj.ArgoCDSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-argocd", UID: "set-uid"},
+		Spec: argoproj.ArgoCDSetSpec{
+			NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"argocd": "true"}},
+			Template: argoproj.ArgoCDSetTemplate{
+				Labels: map[string]string{"team": "{{namespace.labels.team}}"},
+				Spec: argoproj.ArgoCDSpec{
+					RBAC: argoproj.ArgoCDRBACSpec{Policy: ptr.To("g, {{namespace}}-admins, role:admin")},
+				},
+			},
+			Rollout: argoproj.ArgoCDSetRolloutSpec{BatchSize: ptr.To(int32(1))},
+		},
+	}
+}
+
+func makeTestArgoCDSetReconciler(t *testing.T, objs ...client.Object) *ArgoCDSetReconciler {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, argoproj.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).
+		WithStatusSubresource(&argoproj.ArgoCDSet{}, &argoproj.ArgoCD{}).Build()
+	return &ArgoCDSetReconciler{Client: cl, Scheme: s}
+}
+
+func TestArgoCDSetReconciler_Reconcile(t *testing.T) {
+	set := makeTestArgoCDSet()
+	r := makeTestArgoCDSetReconciler(t, set,
+		makeTestNamespace("team-a", map[string]string{"argocd": "true", "team": "a"}),
+		makeTestNamespace("team-b", map[string]string{"argocd": "true", "team": "b"}),
+		makeTestNamespace("other", nil),
+	)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: set.Name}}
+	ctx := context.TODO()
+
+	// The first batch only creates the ArgoCD of the first namespace.
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	argocd := &argoproj.ArgoCD{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: set.Name}, argocd))
+	assert.Equal(t, "g, team-a-admins, role:admin", *argocd.Spec.RBAC.Policy)
+	assert.Equal(t, "a", argocd.Labels["team"])
+	assert.Equal(t, set.Name, argocd.Labels[common.ArgoCDSetLabel])
+	assert.NotEmpty(t, argocd.Annotations[common.ArgoCDSetChecksum])
+	assert.True(t, metav1.IsControlledBy(argocd, set))
+
+	err = r.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: set.Name}, &argoproj.ArgoCD{})
+	assert.True(t, errors.IsNotFound(err))
+
+	require.NoError(t, r.Get(ctx, request.NamespacedName, set))
+	assert.Equal(t, int32(2), set.Status.Desired)
+	assert.Equal(t, int32(1), set.Status.UpToDate)
+	assert.Equal(t, int32(0), set.Status.Available)
+	assert.True(t, meta.IsStatusConditionFalse(set.Status.Conditions, argoproj.ArgoCDSetConditionRolloutComplete))
+
+	// The next batch waits until the ArgoCD of the previous batch is available.
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	err = r.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: set.Name}, &argoproj.ArgoCD{})
+	assert.True(t, errors.IsNotFound(err))
+
+	argocd.Status.Phase = "Available"
+	argocd.Status.ObservedGeneration = argocd.Generation
+	require.NoError(t, r.Status().Update(ctx, argocd))
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: set.Name}, argocd))
+	assert.Equal(t, "g, team-b-admins, role:admin", *argocd.Spec.RBAC.Policy)
+
+	// The ArgoCD of a namespace that is no longer selected is deleted.
+	ns := &corev1.Namespace{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "team-b"}, ns))
+	delete(ns.Labels, "argocd")
+	require.NoError(t, r.Update(ctx, ns))
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	err = r.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: set.Name}, &argoproj.ArgoCD{})
+	assert.True(t, errors.IsNotFound(err))
+
+	require.NoError(t, r.Get(ctx, request.NamespacedName, set))
+	assert.Equal(t, int32(1), set.Status.Desired)
+	assert.Equal(t, int32(1), set.Status.Available)
+	assert.True(t, meta.IsStatusConditionTrue(set.Status.Conditions, argoproj.ArgoCDSetConditionRolloutComplete))
+}
+
+func TestArgoCDSetReconciler_Reconcile_existingArgoCD(t *testing.T) {
+	set := makeTestArgoCDSet()
+	existing := &argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: set.Name, Namespace: "team-a"}}
+	r := makeTestArgoCDSetReconciler(t, set, existing,
+		makeTestNamespace("team-a", map[string]string{"argocd": "true", "team": "a"}),
+	)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: set.Name}}
+
+	_, err := r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+
+	// An ArgoCD that is not managed by the ArgoCDSet is never adopted.
+	argocd := &argoproj.ArgoCD{}
+	require.NoError(t, r.Get(context.TODO(), client.ObjectKeyFromObject(existing), argocd))
+	assert.Nil(t, argocd.Spec.RBAC.Policy)
+
+	require.NoError(t, r.Get(context.TODO(), request.NamespacedName, set))
+	require.Len(t, set.Status.Instances, 1)
+	assert.Contains(t, set.Status.Instances[0].Message, "is not managed by the ArgoCDSet")
+}
+
+func TestArgoCDSetReconciler_Reconcile_otherArgoCD(t *testing.T) {
+	set := makeTestArgoCDSet()
+	other := &argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "team-a"}}
+	r := makeTestArgoCDSetReconciler(t, set, other,
+		makeTestNamespace("team-a", map[string]string{"argocd": "true", "team": "a"}),
+	)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: set.Name}}
+
+	_, err := r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+
+	// A namespace that already has another ArgoCD is skipped.
+	err = r.Get(context.TODO(), types.NamespacedName{Namespace: "team-a", Name: set.Name}, &argoproj.ArgoCD{})
+	assert.True(t, errors.IsNotFound(err))
+
+	require.NoError(t, r.Get(context.TODO(), request.NamespacedName, set))
+	require.Len(t, set.Status.Instances, 1)
+	assert.Contains(t, set.Status.Instances[0].Message, "already has the ArgoCD argocd")
+	assert.False(t, set.Status.Instances[0].UpToDate)
+}
+
+func TestArgoCDSetReconciler_Reconcile_revertsManualChanges(t *testing.T) {
+	set := makeTestArgoCDSet()
+	r := makeTestArgoCDSetReconciler(t, set,
+		makeTestNamespace("team-a", map[string]string{"argocd": "true", "team": "a"}),
+	)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: set.Name}}
+	key := types.NamespacedName{Namespace: "team-a", Name: set.Name}
+	ctx := context.TODO()
+
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	argocd := &argoproj.ArgoCD{}
+	require.NoError(t, r.Get(ctx, key, argocd))
+	assert.NotEmpty(t, argocd.Annotations[common.ArgoCDSetSpecChecksum])
+
+	// The ArgoCD is up to date until its spec is changed directly.
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, request.NamespacedName, set))
+	assert.Equal(t, int32(1), set.Status.UpToDate)
+
+	argocd.Spec.RBAC.Policy = ptr.To("g, everyone, role:admin")
+	require.NoError(t, r.Update(ctx, argocd))
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, key, argocd))
+	assert.Equal(t, "g, team-a-admins, role:admin", *argocd.Spec.RBAC.Policy)
+}
+
+func TestRenderTemplate(t *testing.T) {
+	set := makeTestArgoCDSet()
+	set.Spec.Template.Spec.Server.Host = "argocd.{{ namespace.annotations.example.com/domain }}"
+
+	ns := makeTestNamespace("team-a", map[string]string{"team": "a"})
+	ns.Annotations = map[string]string{"example.com/domain": "team-a.example.com"}
+
+	rendered, err := renderTemplate(set, ns)
+	require.NoError(t, err)
+	assert.Equal(t, "argocd.team-a.example.com", rendered.Spec.Server.Host)
+	assert.Equal(t, "g, team-a-admins, role:admin", *rendered.Spec.RBAC.Policy)
+	assert.Equal(t, "a", rendered.Labels["team"])
+
+	// The template of the ArgoCDSet is left unchanged.
+	assert.Equal(t, "g, {{namespace}}-admins, role:admin", *set.Spec.Template.Spec.RBAC.Policy)
+
+	// A parameter without a value for the namespace fails the rendering.
+	delete(ns.Labels, "team")
+	_, err = renderTemplate(set, ns)
+	assert.ErrorContains(t, err, "namespace.labels.team")
+}
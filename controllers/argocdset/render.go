@@ -0,0 +1,135 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdset
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+const (
+	namespaceParameter            = "namespace"
+	namespaceLabelsParameter      = "namespace.labels."
+	namespaceAnnotationsParameter = "namespace.annotations."
+)
+
+// placeholderRegexp matches the {{parameter}} placeholders of an ArgoCDSet template.
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// renderTemplate returns the template of the given ArgoCDSet with the placeholders substituted by the parameters of the
+// given namespace.
+func renderTemplate(set *argoproj.ArgoCDSet, ns *corev1.Namespace) (*argoproj.ArgoCDSetTemplate, error) {
+	data, err := json.Marshal(set.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	var template interface{}
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, err
+	}
+
+	template, err = substitute(template, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err = json.Marshal(template); err != nil {
+		return nil, err
+	}
+	rendered := &argoproj.ArgoCDSetTemplate{}
+	if err := json.Unmarshal(data, rendered); err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+// substitute replaces the placeholders in the string values of the given decoded JSON value.
+func substitute(value interface{}, ns *corev1.Namespace) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return substituteString(v, ns)
+	case map[string]interface{}:
+		for key, item := range v {
+			substituted, err := substitute(item, ns)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = substituted
+		}
+	case []interface{}:
+		for i, item := range v {
+			substituted, err := substitute(item, ns)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = substituted
+		}
+	}
+	return value, nil
+}
+
+// substituteString replaces the placeholders in the given string with the parameters of the given namespace.
+func substituteString(s string, ns *corev1.Namespace) (string, error) {
+	var err error
+	result := placeholderRegexp.ReplaceAllStringFunc(s, func(placeholder string) string {
+		parameter := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+		value, ok := namespaceParameterValue(parameter, ns)
+		if !ok && err == nil {
+			err = fmt.Errorf("namespace %s has no value for the parameter %s", ns.Name, parameter)
+		}
+		return value
+	})
+	return result, err
+}
+
+// namespaceParameterValue returns the value of the given template parameter for the given namespace.
+func namespaceParameterValue(parameter string, ns *corev1.Namespace) (string, bool) {
+	switch {
+	case parameter == namespaceParameter:
+		return ns.Name, true
+	case strings.HasPrefix(parameter, namespaceLabelsParameter):
+		value, ok := ns.Labels[strings.TrimPrefix(parameter, namespaceLabelsParameter)]
+		return value, ok
+	case strings.HasPrefix(parameter, namespaceAnnotationsParameter):
+		value, ok := ns.Annotations[strings.TrimPrefix(parameter, namespaceAnnotationsParameter)]
+		return value, ok
+	}
+	return "", false
+}
+
+// templateChecksum returns the checksum of the given rendered template.
+func templateChecksum(template *argoproj.ArgoCDSetTemplate) (string, error) {
+	return checksum(template)
+}
+
+// specChecksum returns the checksum of the given ArgoCD spec, as stored by the API server.
+func specChecksum(spec *argoproj.ArgoCDSpec) (string, error) {
+	return checksum(spec)
+}
+
+func checksum(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
@@ -15,12 +15,13 @@
 package argoutil
 
 import (
-	"os"
 	"strings"
+
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 func IsNamespaceClusterConfigNamespace(ns string) bool {
-	return allowedNamespace(ns, os.Getenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES"))
+	return allowedNamespace(ns, GetOperatorSetting(common.ArgoCDClusterConfigNamespacesEnvName))
 }
 
 func allowedNamespace(current string, namespaces string) bool {
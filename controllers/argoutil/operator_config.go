@@ -0,0 +1,263 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argoutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// operatorSetting is a setting of the operator that can be set in the ArgoCDOperatorConfig or in the environment.
+type operatorSetting struct {
+	// envName is the environment variable the setting falls back to.
+	envName string
+	// restartRequired is whether the setting is only read when the operator starts.
+	restartRequired bool
+	// value returns the value of the setting in the given ArgoCDOperatorConfig spec, or false when not set.
+	value func(spec *argoproj.ArgoCDOperatorConfigSpec) (string, bool)
+}
+
+func boolSetting(v *bool) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	return strconv.FormatBool(*v), true
+}
+
+func stringSetting(v string) (string, bool) {
+	return v, v != ""
+}
+
+// operatorSettings are the settings of the operator, in the order they are reported in the ArgoCDOperatorConfig status.
+var operatorSettings = []operatorSetting{
+	{envName: common.ArgoCDClusterConfigNamespacesEnvName, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		return stringSetting(strings.Join(s.ClusterConfigNamespaces, ","))
+	}},
+	{envName: common.ArgoCDMemoryOptimizationEnabledEnvName, restartRequired: true, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		return boolSetting(s.MemoryOptimizationEnabled)
+	}},
+	{envName: common.ArgoCDEnableConversionWebhookEnvName, restartRequired: true, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		return boolSetting(s.ConversionWebhookEnabled)
+	}},
+	{envName: common.ArgoCDRemoveManagedByLabelOnDeletionEnvName, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		return boolSetting(s.RemoveManagedByLabelOnArgoCDDeletion)
+	}},
+	{envName: common.EnableManagedNamespace, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		return boolSetting(s.NamespaceManagementEnabled)
+	}},
+	{envName: common.ArgoCDAllowInsecureMutationWebhooksEnvName, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		return boolSetting(s.InsecureMutationWebhooksAllowed)
+	}},
+	{envName: common.ArgoCDImagePullPolicyEnvName, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		return stringSetting(string(s.ImagePullPolicy))
+	}},
+	imageSetting(common.ArgoCDImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.ArgoCD }),
+	imageSetting(common.ArgoCDDexImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.Dex }),
+	imageSetting(common.ArgoCDRedisImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.Redis }),
+	imageSetting(common.ArgoCDRedisHAImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.RedisHA }),
+	imageSetting(common.ArgoCDRedisHAProxyImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.RedisHAProxy }),
+	imageSetting(common.ArgoCDExtensionImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.Extension }),
+	imageSetting(common.ArgoCDImageUpdaterImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.ImageUpdater }),
+	imageSetting(common.ArgoCDPrincipalImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.Principal }),
+	imageSetting(common.ArgoCDAgentImageEnvName, func(i *argoproj.ArgoCDOperatorConfigImages) string { return i.Agent }),
+	clusterRoleSetting(common.ArgoCDControllerClusterRoleEnvName, func(r *argoproj.ArgoCDOperatorConfigClusterRoles) string { return r.Controller }),
+	clusterRoleSetting(common.ArgoCDServerClusterRoleEnvName, func(r *argoproj.ArgoCDOperatorConfigClusterRoles) string { return r.Server }),
+	clusterRoleSetting(common.ArgoCDPrincipalClusterRoleEnvName, func(r *argoproj.ArgoCDOperatorConfigClusterRoles) string { return r.Principal }),
+	clusterRoleSetting(common.ArgoCDAgentClusterRoleEnvName, func(r *argoproj.ArgoCDOperatorConfigClusterRoles) string { return r.Agent }),
+}
+
+func imageSetting(envName string, image func(*argoproj.ArgoCDOperatorConfigImages) string) operatorSetting {
+	return operatorSetting{envName: envName, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		if s.Images == nil {
+			return "", false
+		}
+		return stringSetting(image(s.Images))
+	}}
+}
+
+func clusterRoleSetting(envName string, role func(*argoproj.ArgoCDOperatorConfigClusterRoles) string) operatorSetting {
+	return operatorSetting{envName: envName, value: func(s *argoproj.ArgoCDOperatorConfigSpec) (string, bool) {
+		if s.ClusterRoles == nil {
+			return "", false
+		}
+		return stringSetting(role(s.ClusterRoles))
+	}}
+}
+
+var (
+	operatorConfigMutex sync.RWMutex
+	// operatorConfigOverrides are the settings of the ArgoCDOperatorConfig, keyed by environment variable.
+	operatorConfigOverrides = map[string]string{}
+	// operatorStartupSettings are the settings read when the operator started, keyed by environment variable.
+	operatorStartupSettings map[string]string
+)
+
+// LookupOperatorSetting returns the value of the operator setting with the given environment variable name. The value
+// of the ArgoCDOperatorConfig takes precedence over the environment of the operator.
+func LookupOperatorSetting(envName string) (string, bool) {
+	operatorConfigMutex.RLock()
+	defer operatorConfigMutex.RUnlock()
+	if v, ok := operatorConfigOverrides[envName]; ok {
+		return v, true
+	}
+	return os.LookupEnv(envName)
+}
+
+// GetOperatorSetting returns the value of the operator setting with the given environment variable name, or an empty
+// string when not set.
+func GetOperatorSetting(envName string) string {
+	v, _ := LookupOperatorSetting(envName)
+	return v
+}
+
+// ValidateOperatorConfig validates the settings of the given ArgoCDOperatorConfig.
+func ValidateOperatorConfig(cfg *argoproj.ArgoCDOperatorConfig) error {
+	var errs []error
+	if cfg.Name != argoproj.ArgoCDOperatorConfigName {
+		errs = append(errs, fmt.Errorf("the ArgoCDOperatorConfig must be named %s", argoproj.ArgoCDOperatorConfigName))
+	}
+
+	for _, ns := range cfg.Spec.ClusterConfigNamespaces {
+		if ns == "*" {
+			if len(cfg.Spec.ClusterConfigNamespaces) > 1 {
+				errs = append(errs, errors.New("clusterConfigNamespaces cannot combine \"*\" with other namespaces"))
+			}
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			errs = append(errs, fmt.Errorf("clusterConfigNamespaces: invalid namespace %q: %s", ns, msg))
+		}
+	}
+
+	if images := cfg.Spec.Images; images != nil {
+		for _, image := range []string{images.ArgoCD, images.Dex, images.Redis, images.RedisHA, images.RedisHAProxy, images.Extension, images.ImageUpdater, images.Principal, images.Agent} {
+			if strings.ContainsAny(image, " \t\n") {
+				errs = append(errs, fmt.Errorf("images: invalid image %q", image))
+			}
+		}
+	}
+
+	if roles := cfg.Spec.ClusterRoles; roles != nil {
+		for _, role := range []string{roles.Controller, roles.Server, roles.Principal, roles.Agent} {
+			if role == "" {
+				continue
+			}
+			for _, msg := range validation.IsDNS1123Subdomain(role) {
+				errs = append(errs, fmt.Errorf("clusterRoles: invalid ClusterRole name %q: %s", role, msg))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ApplyOperatorConfig will make the settings of the given ArgoCDOperatorConfig take precedence over the environment of
+// the operator. The environment is used again when the ArgoCDOperatorConfig is nil or invalid.
+func ApplyOperatorConfig(cfg *argoproj.ArgoCDOperatorConfig) error {
+	overrides := map[string]string{}
+	var err error
+	if cfg != nil {
+		if err = ValidateOperatorConfig(cfg); err == nil {
+			for _, setting := range operatorSettings {
+				if v, ok := setting.value(&cfg.Spec); ok {
+					overrides[setting.envName] = v
+				}
+			}
+		}
+	}
+
+	operatorConfigMutex.Lock()
+	defer operatorConfigMutex.Unlock()
+	operatorConfigOverrides = overrides
+	return err
+}
+
+// GetOperatorConfig returns the ArgoCDOperatorConfig singleton, or nil when it does not exist or its API is not
+// installed.
+func GetOperatorConfig(ctx context.Context, c client.Client) (*argoproj.ArgoCDOperatorConfig, error) {
+	cfg := &argoproj.ArgoCDOperatorConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: argoproj.ArgoCDOperatorConfigName}, cfg); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// RefreshOperatorConfig will apply the current ArgoCDOperatorConfig singleton. An invalid ArgoCDOperatorConfig is
+// ignored and reported in its status.
+func RefreshOperatorConfig(ctx context.Context, c client.Client) error {
+	cfg, err := GetOperatorConfig(ctx, c)
+	if err != nil {
+		return err
+	}
+	if err := ApplyOperatorConfig(cfg); err != nil {
+		log.Error(err, "ignoring invalid ArgoCDOperatorConfig")
+	}
+	return nil
+}
+
+// RecordOperatorStartupSettings records the settings read when the operator starts, so that the settings only read at
+// startup can be reported as requiring a restart when they change.
+func RecordOperatorStartupSettings() {
+	startup := map[string]string{}
+	for _, setting := range operatorSettings {
+		if setting.restartRequired {
+			startup[setting.envName] = GetOperatorSetting(setting.envName)
+		}
+	}
+
+	operatorConfigMutex.Lock()
+	defer operatorConfigMutex.Unlock()
+	operatorStartupSettings = startup
+}
+
+// GetEffectiveOperatorSettings returns the effective value and the source of each operator setting.
+func GetEffectiveOperatorSettings() []argoproj.ArgoCDOperatorSetting {
+	operatorConfigMutex.RLock()
+	defer operatorConfigMutex.RUnlock()
+
+	settings := make([]argoproj.ArgoCDOperatorSetting, 0, len(operatorSettings))
+	for _, setting := range operatorSettings {
+		effective := argoproj.ArgoCDOperatorSetting{Name: setting.envName, Source: argoproj.ArgoCDOperatorSettingSourceDefault}
+		if v, ok := operatorConfigOverrides[setting.envName]; ok {
+			effective.Value = v
+			effective.Source = argoproj.ArgoCDOperatorSettingSourceConfig
+		} else if v, ok := os.LookupEnv(setting.envName); ok {
+			effective.Value = v
+			effective.Source = argoproj.ArgoCDOperatorSettingSourceEnvironment
+		}
+		if startup, ok := operatorStartupSettings[setting.envName]; ok && startup != effective.Value {
+			effective.RestartRequired = true
+		}
+		settings = append(settings, effective)
+	}
+	return settings
+}
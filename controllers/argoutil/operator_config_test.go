@@ -0,0 +1,110 @@
+package argoutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestOperatorSettings(t *testing.T) {
+	t.Setenv(common.ArgoCDClusterConfigNamespacesEnvName, "argocd")
+	t.Setenv(common.ArgoCDMemoryOptimizationEnabledEnvName, "true")
+	t.Setenv(common.ArgoCDDexImageEnvName, "dex:from-env")
+	defer func() {
+		_ = ApplyOperatorConfig(nil)
+		operatorStartupSettings = nil
+	}()
+
+	// Without an ArgoCDOperatorConfig, the environment is used.
+	require.NoError(t, ApplyOperatorConfig(nil))
+	RecordOperatorStartupSettings()
+	assert.True(t, IsNamespaceClusterConfigNamespace("argocd"))
+	assert.Equal(t, "dex:from-env", GetOperatorSetting(common.ArgoCDDexImageEnvName))
+
+	cfg := &argoproj.ArgoCDOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: argoproj.ArgoCDOperatorConfigName},
+		Spec: argoproj.ArgoCDOperatorConfigSpec{
+			ClusterConfigNamespaces:   []string{"gitops", "platform"},
+			MemoryOptimizationEnabled: ptr.To(false),
+			Images:                    &argoproj.ArgoCDOperatorConfigImages{Redis: "redis:from-config"},
+		},
+	}
+	require.NoError(t, ApplyOperatorConfig(cfg))
+	assert.False(t, IsNamespaceClusterConfigNamespace("argocd"))
+	assert.True(t, IsNamespaceClusterConfigNamespace("platform"))
+	assert.Equal(t, "redis:from-config", GetOperatorSetting(common.ArgoCDRedisImageEnvName))
+	assert.Equal(t, "dex:from-env", GetOperatorSetting(common.ArgoCDDexImageEnvName))
+
+	settings := map[string]argoproj.ArgoCDOperatorSetting{}
+	for _, s := range GetEffectiveOperatorSettings() {
+		settings[s.Name] = s
+	}
+	assert.Equal(t, argoproj.ArgoCDOperatorSetting{Name: common.ArgoCDClusterConfigNamespacesEnvName, Value: "gitops,platform", Source: argoproj.ArgoCDOperatorSettingSourceConfig}, settings[common.ArgoCDClusterConfigNamespacesEnvName])
+	assert.Equal(t, argoproj.ArgoCDOperatorSetting{Name: common.ArgoCDDexImageEnvName, Value: "dex:from-env", Source: argoproj.ArgoCDOperatorSettingSourceEnvironment}, settings[common.ArgoCDDexImageEnvName])
+	assert.Equal(t, argoproj.ArgoCDOperatorSettingSourceDefault, settings[common.ArgoCDAgentImageEnvName].Source)
+	assert.True(t, settings[common.ArgoCDMemoryOptimizationEnabledEnvName].RestartRequired)
+	assert.False(t, settings[common.ArgoCDEnableConversionWebhookEnvName].RestartRequired)
+
+	// An invalid ArgoCDOperatorConfig is ignored and the environment is used again.
+	cfg.Spec.ClusterConfigNamespaces = []string{"*", "Invalid_Namespace"}
+	assert.Error(t, ApplyOperatorConfig(cfg))
+	assert.True(t, IsNamespaceClusterConfigNamespace("argocd"))
+	assert.Equal(t, "", GetOperatorSetting(common.ArgoCDRedisImageEnvName))
+}
+
+func TestValidateOperatorConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *argoproj.ArgoCDOperatorConfig
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			cfg: &argoproj.ArgoCDOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: argoproj.ArgoCDOperatorConfigSpec{
+					ClusterConfigNamespaces: []string{"*"},
+					ClusterRoles:            &argoproj.ArgoCDOperatorConfigClusterRoles{Controller: "custom-controller-role"},
+				},
+			},
+		},
+		{
+			name:    "not the singleton",
+			cfg:     &argoproj.ArgoCDOperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+			wantErr: true,
+		},
+		{
+			name: "invalid image",
+			cfg: &argoproj.ArgoCDOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       argoproj.ArgoCDOperatorConfigSpec{Images: &argoproj.ArgoCDOperatorConfigImages{ArgoCD: "quay.io/argoproj/argocd v3"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid cluster role",
+			cfg: &argoproj.ArgoCDOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       argoproj.ArgoCDOperatorConfigSpec{ClusterRoles: &argoproj.ArgoCDOperatorConfigClusterRoles{Server: "Server Role"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateOperatorConfig(test.cfg)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
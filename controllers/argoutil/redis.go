@@ -9,6 +9,8 @@ import (
 	"text/template"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -18,6 +20,9 @@ import (
 const (
 	RedisAuthVolumeName = "redis-initial-pass"
 	RedisAuthMountPath  = "/app/config/redis-auth/"
+
+	RedisExternalTLSVolumeName = "redis-external-tls"
+	RedisExternalTLSMountPath  = "/app/config/redis-external-tls"
 )
 
 // MountRedisAuthToRedis mounts entire redis secret for consumption by redis components.
@@ -52,17 +57,121 @@ func MountRedisAuthToArgo(cr *argoproj.ArgoCD) (volume corev1.Volume, mount core
 	return volume, volumeMount
 }
 
-func GetRedisAuthEnv() []corev1.EnvVar {
-	return []corev1.EnvVar{{
-		Name:  "REDIS_CREDS_DIR_PATH",
-		Value: RedisAuthMountPath,
+// GetRedisAuthEnv returns the environment variables providing the Redis credentials to argocd components.
+// The credentials of an external Redis are provided through the REDIS_* variables, as Argo CD ignores them
+// when REDIS_CREDS_DIR_PATH is set.
+func GetRedisAuthEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	external := cr.Spec.Redis.External
+	if external == nil {
+		return []corev1.EnvVar{{
+			Name:  "REDIS_CREDS_DIR_PATH",
+			Value: RedisAuthMountPath,
+		}}
+	}
+
+	env := []corev1.EnvVar{}
+	if external.Username != "" {
+		env = append(env, corev1.EnvVar{Name: "REDIS_USERNAME", Value: external.Username})
+	}
+	if external.PasswordSecretRef != nil {
+		env = append(env, corev1.EnvVar{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: external.PasswordSecretRef}})
+	}
+	if sentinel := external.Sentinel; sentinel != nil {
+		if sentinel.Username != "" {
+			env = append(env, corev1.EnvVar{Name: "REDIS_SENTINEL_USERNAME", Value: sentinel.Username})
+		}
+		if sentinel.PasswordSecretRef != nil {
+			env = append(env, corev1.EnvVar{Name: "REDIS_SENTINEL_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: sentinel.PasswordSecretRef}})
+		}
+	}
+	return env
+}
+
+// MountRedisExternalTLSToArgo mounts the CA bundle and the client certificate of an external Redis for consumption
+// by argocd components. No volume is returned when the external Redis does not reference any TLS material.
+func MountRedisExternalTLSToArgo(cr *argoproj.ArgoCD) (volumes []corev1.Volume, mounts []corev1.VolumeMount) {
+	if cr.Spec.Redis.External == nil || cr.Spec.Redis.External.TLS == nil {
+		return nil, nil
+	}
+	tls := cr.Spec.Redis.External.TLS
+
+	var sources []corev1.VolumeProjection
+	if tls.CASecretRef != nil {
+		sources = append(sources, corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+			LocalObjectReference: tls.CASecretRef.LocalObjectReference,
+			Items:                []corev1.KeyToPath{{Key: tls.CASecretRef.Key, Path: "ca.crt"}},
+		}})
+	}
+	if tls.ClientCertificateSecretName != "" {
+		sources = append(sources, corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: tls.ClientCertificateSecretName},
+			Items: []corev1.KeyToPath{
+				{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey},
+				{Key: corev1.TLSPrivateKeyKey, Path: corev1.TLSPrivateKeyKey},
+			},
+		}})
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	volumes = []corev1.Volume{{
+		Name: RedisExternalTLSVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+		},
 	}}
+	mounts = []corev1.VolumeMount{{
+		Name:      RedisExternalTLSVolumeName,
+		MountPath: RedisExternalTLSMountPath,
+		ReadOnly:  true,
+	}}
+	return volumes, mounts
 }
 
-func GetRedisSecretData(redisInitialPassword []byte) map[string][]byte {
-	pw := strings.TrimRight(string(redisInitialPassword), "\n")
-	usersACL := fmt.Sprintf("user default on >%s allchannels allkeys allcommands\n", pw)
+// GetRedisExternalArgs returns the --redis* command arguments of argocd components connecting to an external Redis.
+func GetRedisExternalArgs(cr *argoproj.ArgoCD) []string {
+	external := cr.Spec.Redis.External
+	var args []string
+
+	if external.Sentinel != nil {
+		for _, address := range external.Sentinel.Addresses {
+			args = append(args, "--sentinel", address)
+		}
+		if external.Sentinel.MasterName != "" {
+			args = append(args, "--sentinelmaster", external.Sentinel.MasterName)
+		}
+	} else {
+		args = append(args, "--redis", external.Address)
+	}
+
+	if tls := external.TLS; tls != nil {
+		args = append(args, "--redis-use-tls")
+		if tls.InsecureSkipVerify {
+			args = append(args, "--redis-insecure-skip-tls-verify")
+		} else if tls.CASecretRef != nil {
+			args = append(args, "--redis-ca-certificate", RedisExternalTLSMountPath+"/ca.crt")
+		}
+		if tls.ClientCertificateSecretName != "" {
+			args = append(args,
+				"--redis-client-certificate", RedisExternalTLSMountPath+"/"+corev1.TLSCertKey,
+				"--redis-client-key", RedisExternalTLSMountPath+"/"+corev1.TLSPrivateKeyKey)
+		}
+	}
+	return args
+}
 
+// GetRedisUsersACL returns the users.acl content allowing the default Redis user to authenticate with any of the
+// given passwords.
+func GetRedisUsersACL(passwords ...[]byte) []byte {
+	var rules strings.Builder
+	for _, pw := range passwords {
+		fmt.Fprintf(&rules, ">%s ", strings.TrimRight(string(pw), "\n"))
+	}
+	return []byte(fmt.Sprintf("user default on %sallchannels allkeys allcommands\n", rules.String()))
+}
+
+func GetRedisSecretData(redisInitialPassword []byte) map[string][]byte {
 	return map[string][]byte{
 		"immutable": []byte("true"),
 		// Mapping the legacy key-name, the operator customers can depend on.
@@ -70,8 +179,30 @@ func GetRedisSecretData(redisInitialPassword []byte) map[string][]byte {
 		// Provide ACL file content so redis-server can use file-based ACLs
 		"auth":          redisInitialPassword,
 		"auth_username": []byte("default"),
-		"users.acl":     []byte(usersACL),
+		"users.acl":     GetRedisUsersACL(redisInitialPassword),
+	}
+}
+
+// SetRedisAuthRevision sets the revision of the Redis password on the pod template of an argocd component, so that
+// the component is rolled out once Redis accepts a rotated password. It returns whether the pod template changed.
+func SetRedisAuthRevision(c client.Client, cr *argoproj.ArgoCD, template *corev1.PodTemplateSpec) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := FetchObject(c, cr.Namespace, GetSecretNameWithSuffix(cr, "redis-initial-password"), secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	revision := secret.Annotations[common.AnnotationRedisAuthClientsRevision]
+	if revision == "" || template.Annotations[common.ArgoCDRedisAuthRevision] == revision {
+		return false, nil
 	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[common.ArgoCDRedisAuthRevision] = revision
+	return true, nil
 }
 
 func GetRedisHAReplicas() *int32 {
@@ -117,7 +248,7 @@ func GetRedisContainerImage(cr *argoproj.ArgoCD) string {
 		tag = common.ArgoCDDefaultRedisVersion
 		defaultTag = true
 	}
-	if e := os.Getenv(common.ArgoCDRedisImageEnvName); e != "" && (defaultTag && defaultImg) {
+	if e := GetOperatorSetting(common.ArgoCDRedisImageEnvName); e != "" && (defaultTag && defaultImg) {
 		return e
 	}
 	return CombineImageTag(img, tag)
@@ -136,7 +267,7 @@ func GetRedisHAContainerImage(cr *argoproj.ArgoCD) string {
 		tag = common.ArgoCDDefaultRedisVersionHA
 		defaultTag = true
 	}
-	if e := os.Getenv(common.ArgoCDRedisHAImageEnvName); e != "" && (defaultTag && defaultImg) {
+	if e := GetOperatorSetting(common.ArgoCDRedisHAImageEnvName); e != "" && (defaultTag && defaultImg) {
 		return e
 	}
 	return CombineImageTag(img, tag)
@@ -162,7 +293,7 @@ func GetRedisHAProxyContainerImage(cr *argoproj.ArgoCD) string {
 		defaultTag = true
 	}
 
-	if e := os.Getenv(common.ArgoCDRedisHAProxyImageEnvName); e != "" && (defaultTag && defaultImg) {
+	if e := GetOperatorSetting(common.ArgoCDRedisHAProxyImageEnvName); e != "" && (defaultTag && defaultImg) {
 		return e
 	}
 
@@ -317,6 +448,10 @@ func GetRedisServerAddress(cr *argoproj.ArgoCD) string {
 		return *cr.Spec.Redis.Remote
 	}
 
+	if cr.Spec.Redis.External != nil && cr.Spec.Redis.External.Address != "" {
+		return cr.Spec.Redis.External.Address
+	}
+
 	// If principal is enabled, then Argo CD server/repo server should be configured to use redis proxy from principal (argo cd agent)
 	if cr.Spec.ArgoCDAgent != nil && cr.Spec.ArgoCDAgent.Principal != nil && cr.Spec.ArgoCDAgent.Principal.IsEnabled() {
 		return GenerateAgentPrincipalRedisProxyServiceName(cr.Name) + "." + cr.Namespace + ".svc." + GetClusterDomain(cr) + ".:6379"
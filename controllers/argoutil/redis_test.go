@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -155,3 +156,67 @@ func TestGetRedisHAProxyConfigRenderedTLSValues(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRedisExternalConfiguration(t *testing.T) {
+	cr := &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"},
+		Spec: argoproj.ArgoCDSpec{Redis: argoproj.ArgoCDRedisSpec{External: &argoproj.ArgoCDRedisExternalSpec{
+			Address:  "redis.example.com:6380",
+			Username: "argocd",
+			PasswordSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "redis-auth"},
+				Key:                  "password",
+			},
+			TLS: &argoproj.ArgoCDRedisExternalTLSSpec{
+				CASecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"},
+					Key:                  "ca.crt",
+				},
+				ClientCertificateSecretName: "redis-client",
+			},
+		}}},
+	}
+
+	assert.Equal(t, "redis.example.com:6380", GetRedisServerAddress(cr))
+	assert.True(t, cr.Spec.Redis.IsRemote())
+	assert.Equal(t, []string{
+		"--redis", "redis.example.com:6380",
+		"--redis-use-tls",
+		"--redis-ca-certificate", "/app/config/redis-external-tls/ca.crt",
+		"--redis-client-certificate", "/app/config/redis-external-tls/tls.crt",
+		"--redis-client-key", "/app/config/redis-external-tls/tls.key",
+	}, GetRedisExternalArgs(cr))
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "REDIS_USERNAME", Value: "argocd"},
+		{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: cr.Spec.Redis.External.PasswordSecretRef}},
+	}, GetRedisAuthEnv(cr))
+
+	volumes, mounts := MountRedisExternalTLSToArgo(cr)
+	require.Len(t, volumes, 1)
+	require.Len(t, volumes[0].Projected.Sources, 2)
+	assert.Equal(t, "redis-ca", volumes[0].Projected.Sources[0].Secret.Name)
+	assert.Equal(t, "redis-client", volumes[0].Projected.Sources[1].Secret.Name)
+	assert.Equal(t, []corev1.VolumeMount{{Name: RedisExternalTLSVolumeName, MountPath: RedisExternalTLSMountPath, ReadOnly: true}}, mounts)
+
+	// Sentinel endpoints replace the Redis address.
+	cr.Spec.Redis.External.Address = ""
+	cr.Spec.Redis.External.TLS = &argoproj.ArgoCDRedisExternalTLSSpec{InsecureSkipVerify: true}
+	cr.Spec.Redis.External.Sentinel = &argoproj.ArgoCDRedisSentinelSpec{
+		Addresses:  []string{"sentinel-0:26379", "sentinel-1:26379"},
+		MasterName: "mymaster",
+	}
+	assert.Equal(t, []string{
+		"--sentinel", "sentinel-0:26379",
+		"--sentinel", "sentinel-1:26379",
+		"--sentinelmaster", "mymaster",
+		"--redis-use-tls",
+		"--redis-insecure-skip-tls-verify",
+	}, GetRedisExternalArgs(cr))
+	volumes, mounts = MountRedisExternalTLSToArgo(cr)
+	assert.Empty(t, volumes)
+	assert.Empty(t, mounts)
+
+	// The operator-managed Redis credentials are read from the mounted secret.
+	cr.Spec.Redis.External = nil
+	assert.Equal(t, []corev1.EnvVar{{Name: "REDIS_CREDS_DIR_PATH", Value: RedisAuthMountPath}}, GetRedisAuthEnv(cr))
+}
@@ -18,7 +18,6 @@ import (
 	"context"
 	"crypto/sha1" // #nosec G505 - SHA1 used for non-cryptographic name hashing only
 	"fmt"
-	"os"
 	"reflect"
 	"strings"
 
@@ -337,7 +336,7 @@ func GetImagePullPolicy(policy corev1.PullPolicy) corev1.PullPolicy {
 		return policy
 	}
 
-	envValue := os.Getenv(common.ArgoCDImagePullPolicyEnvName)
+	envValue := GetOperatorSetting(common.ArgoCDImagePullPolicyEnvName)
 
 	switch envValue {
 	case "Always":
@@ -0,0 +1,286 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagemigration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logr "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// ArgoCDCRDName is the name of the ArgoCD CustomResourceDefinition.
+const ArgoCDCRDName = "argocds.argoproj.io"
+
+var log = logr.Log.WithName("storage_migration")
+
+var (
+	_ manager.Runnable               = &StorageVersionMigrator{}
+	_ manager.LeaderElectionRunnable = &StorageVersionMigrator{}
+)
+
+// StorageVersionMigrator rewrites the ArgoCD objects in the storage version of the ArgoCD CRD, so that the older
+// versions can be removed from the versions it stores and eventually from the CRD.
+type StorageVersionMigrator struct {
+	// Client must not be backed by the cache of the manager, as the operator can only get the ArgoCD CRD.
+	Client client.Client
+}
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions;customresourcedefinitions/status,resourceNames=argocds.argoproj.io,verbs=get;update;patch
+
+// Start migrates the ArgoCD objects when the ArgoCD CRD may still store some of them in an older version, e.g. once
+// the operator is upgraded. A failed migration is logged and does not stop the operator.
+func (m *StorageVersionMigrator) Start(ctx context.Context) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: ArgoCDCRDName}, crd); err != nil {
+		log.Error(err, "unable to get the ArgoCD CRD, skipping the storage version migration")
+		return nil
+	}
+	if storedVersions := crd.Status.StoredVersions; len(storedVersions) == 1 && storedVersions[0] == getStorageVersion(crd) {
+		log.Info("the ArgoCD objects are stored in the storage version " + storedVersions[0])
+		return nil
+	}
+
+	if _, err := m.Migrate(ctx); err != nil {
+		log.Error(err, "unable to migrate the ArgoCD objects to the storage version")
+	}
+	return nil
+}
+
+// NeedLeaderElection ensures that only the leader migrates the ArgoCD objects.
+func (m *StorageVersionMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Migrate rewrites the ArgoCD objects in the storage version of the ArgoCD CRD and returns what it migrated. The
+// ArgoCD objects whose v1alpha1 fields do not round-trip losslessly through the storage version are skipped. The
+// stored versions of the CRD are only reduced to the storage version once all the ArgoCD objects are migrated. The
+// migration is reported in the status of the ArgoCDOperatorConfig when it exists.
+func (m *StorageVersionMigrator) Migrate(ctx context.Context) (*argoproj.ArgoCDStorageMigrationStatus, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: ArgoCDCRDName}, crd); err != nil {
+		return nil, fmt.Errorf("failed to get the ArgoCD CRD: %w", err)
+	}
+	storageVersion := getStorageVersion(crd)
+	if storageVersion != argoproj.GroupVersion.Version {
+		return nil, fmt.Errorf("the storage version of the ArgoCD CRD is %q, only %s is supported", storageVersion, argoproj.GroupVersion.Version)
+	}
+
+	status := &argoproj.ArgoCDStorageMigrationStatus{
+		StorageVersion: storageVersion,
+		StartTime:      &metav1.Time{Time: time.Now().Truncate(time.Second)},
+	}
+	log.Info(fmt.Sprintf("migrating the ArgoCD objects stored in %v to %s", crd.Status.StoredVersions, storageVersion))
+
+	list := &argoproj.ArgoCDList{}
+	if err := m.Client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list the ArgoCD objects: %w", err)
+	}
+	for i := range list.Items {
+		cr := &list.Items[i]
+		migrated, skipped := m.migrateArgoCD(ctx, cr)
+		if skipped != nil {
+			log.Info(fmt.Sprintf("skipping the storage version migration of ArgoCD %s/%s: %s %v", cr.Namespace, cr.Name, skipped.Message, skipped.LossyFields))
+			status.Skipped = append(status.Skipped, *skipped)
+		} else if migrated {
+			status.Migrated = append(status.Migrated, cr.Namespace+"/"+cr.Name)
+		}
+	}
+
+	if len(status.Skipped) == 0 && !reflect.DeepEqual(crd.Status.StoredVersions, []string{storageVersion}) {
+		crd.Status.StoredVersions = []string{storageVersion}
+		argoutil.LogResourceUpdate(log, crd, "reducing the stored versions to", storageVersion)
+		if err := m.Client.Status().Update(ctx, crd); err != nil {
+			return nil, fmt.Errorf("failed to update the stored versions of the ArgoCD CRD: %w", err)
+		}
+	}
+	status.StoredVersions = crd.Status.StoredVersions
+	status.CompletionTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+	log.Info(fmt.Sprintf("migrated %d ArgoCD objects to %s, skipped %d, stored versions are %v", len(status.Migrated), storageVersion, len(status.Skipped), status.StoredVersions))
+
+	if err := m.reportStatus(ctx, status); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+// migrateArgoCD rewrites the given ArgoCD in the storage version. It returns whether the ArgoCD was migrated, or why
+// it was skipped. An ArgoCD deleted in the meantime is neither migrated nor skipped.
+func (m *StorageVersionMigrator) migrateArgoCD(ctx context.Context, cr *argoproj.ArgoCD) (bool, *argoproj.ArgoCDStorageMigrationObject) {
+	skipped := &argoproj.ArgoCDStorageMigrationObject{Name: cr.Name, Namespace: cr.Namespace}
+
+	lossyFields, err := m.getLossyFields(ctx, cr)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		skipped.Message = fmt.Sprintf("failed to read the ArgoCD as v1alpha1: %v", err)
+		return false, skipped
+	}
+	if len(lossyFields) > 0 {
+		skipped.LossyFields = lossyFields
+		skipped.Message = "the fields of the v1alpha1 ArgoCD do not round-trip losslessly through " + argoproj.GroupVersion.Version
+		message := fmt.Sprintf("the ArgoCD is not migrated to %s, the fields %v are lost once converted, set them in %s and remove them from the ArgoCD", argoproj.GroupVersion.Version, lossyFields, argoproj.GroupVersion.Version)
+		if err := argoutil.CreateEvent(m.Client, corev1.EventTypeWarning, "Skipped", message, "StorageMigrationSkipped", cr.ObjectMeta, cr.TypeMeta); err != nil {
+			log.Error(err, "unable to create the storage version migration event")
+		}
+		return false, skipped
+	}
+
+	// An update without changes makes the API server rewrite the ArgoCD in the storage version.
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &argoproj.ArgoCD{}
+		if err := m.Client.Get(ctx, client.ObjectKeyFromObject(cr), latest); err != nil {
+			return err
+		}
+		return m.Client.Update(ctx, latest)
+	})
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		skipped.Message = fmt.Sprintf("failed to rewrite the ArgoCD: %v", err)
+		return false, skipped
+	}
+	return true, nil
+}
+
+// getLossyFields returns the paths of the fields of the given ArgoCD, as served in v1alpha1, that differ once it is
+// converted to v1beta1 and back, i.e. the v1alpha1 fields lost by rewriting the ArgoCD in v1beta1. The deprecated
+// fields the conversion renames are compared at their new paths, so that they are not reported as lost.
+func (m *StorageVersionMigrator) getLossyFields(ctx context.Context, cr *argoproj.ArgoCD) ([]string, error) {
+	alpha := &v1alpha1.ArgoCD{}
+	if err := m.Client.Get(ctx, client.ObjectKeyFromObject(cr), alpha); err != nil {
+		return nil, err
+	}
+	renameDeprecatedFields(alpha)
+	roundTripped := &v1alpha1.ArgoCD{}
+	if err := roundTripped.ConvertFrom(cr.DeepCopy()); err != nil {
+		return nil, err
+	}
+
+	original, err := toJSONValue(alpha.Spec)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := toJSONValue(roundTripped.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return diffJSONFields("spec", original, converted), nil
+}
+
+// renameDeprecatedFields moves the deprecated fields of the given v1alpha1 ArgoCD to the fields the conversion to
+// v1beta1 renames them to, overriding these fields as the conversion does.
+func renameDeprecatedFields(cr *v1alpha1.ArgoCD) {
+	//nolint:staticcheck // SA1019: We must rename deprecated fields.
+	if sso := cr.Spec.SSO; sso != nil && (sso.Image != "" || sso.Version != "" || sso.VerifyTLS != nil || sso.Resources != nil) {
+		if sso.Keycloak == nil {
+			sso.Keycloak = &v1alpha1.ArgoCDKeycloakSpec{}
+		}
+		sso.Keycloak.Image, sso.Image = sso.Image, ""
+		sso.Keycloak.Version, sso.Version = sso.Version, ""
+		sso.Keycloak.VerifyTLS, sso.VerifyTLS = sso.VerifyTLS, nil
+		sso.Keycloak.Resources, sso.Resources = sso.Resources, nil
+	}
+
+	//nolint:staticcheck // SA1019: We must rename deprecated fields.
+	if dex := cr.Spec.Dex; dex != nil && (dex.Config != "" || dex.OpenShiftOAuth) {
+		if cr.Spec.SSO == nil {
+			cr.Spec.SSO = &v1alpha1.ArgoCDSSOSpec{}
+		}
+		cr.Spec.SSO.Provider = v1alpha1.SSOProviderTypeDex
+		cr.Spec.SSO.Dex, cr.Spec.Dex = dex, nil
+	}
+}
+
+// reportStatus reports the given migration in the status of the ArgoCDOperatorConfig, when it exists.
+func (m *StorageVersionMigrator) reportStatus(ctx context.Context, status *argoproj.ArgoCDStorageMigrationStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cfg := &argoproj.ArgoCDOperatorConfig{}
+		if err := m.Client.Get(ctx, client.ObjectKey{Name: argoproj.ArgoCDOperatorConfigName}, cfg); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		cfg.Status.StorageMigration = status
+		return m.Client.Status().Update(ctx, cfg)
+	})
+}
+
+// getStorageVersion returns the version the given CRD stores its objects in.
+func getStorageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return ""
+}
+
+// toJSONValue returns the given value as decoded from its JSON encoding.
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// diffJSONFields returns the paths, below the given path, of the fields that differ between the given JSON values.
+func diffJSONFields(path string, a, b interface{}) []string {
+	aMap, aOk := a.(map[string]interface{})
+	bMap, bOk := b.(map[string]interface{})
+	if !aOk || !bOk {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+		return []string{path}
+	}
+
+	keys := map[string]bool{}
+	for key := range aMap {
+		keys[key] = true
+	}
+	for key := range bMap {
+		keys[key] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	var fields []string
+	for _, key := range sortedKeys {
+		fields = append(fields, diffJSONFields(path+"."+key, aMap[key], bMap[key])...)
+	}
+	return fields
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagemigration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestCRD(storedVersions ...string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: ArgoCDCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true},
+				{Name: "v1beta1", Served: true, Storage: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+	}
+}
+
+// makeTestArgoCDs returns the given v1alpha1 ArgoCD and its v1beta1 conversion, as served by the API server.
+func makeTestArgoCDs(t *testing.T, name string, opts ...func(*v1alpha1.ArgoCD)) (*v1alpha1.ArgoCD, *argoproj.ArgoCD) {
+	alpha := &v1alpha1.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argocd"}}
+	for _, o := range opts {
+		o(alpha)
+	}
+	beta := &argoproj.ArgoCD{}
+	require.NoError(t, alpha.ConvertTo(beta))
+	return alpha, beta
+}
+
+func makeTestClient(t *testing.T, objs ...client.Object) client.Client {
+	sch := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(sch))
+	require.NoError(t, apiextensionsv1.AddToScheme(sch))
+	require.NoError(t, v1alpha1.AddToScheme(sch))
+	require.NoError(t, argoproj.AddToScheme(sch))
+	return fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(objs...).
+		WithStatusSubresource(&apiextensionsv1.CustomResourceDefinition{}, &argoproj.ArgoCDOperatorConfig{}).
+		Build()
+}
+
+func TestStorageVersionMigrator_Migrate(t *testing.T) {
+	alpha, beta := makeTestArgoCDs(t, "argocd")
+	cfg := &argoproj.ArgoCDOperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: argoproj.ArgoCDOperatorConfigName}}
+	cl := makeTestClient(t, makeTestCRD("v1alpha1", "v1beta1"), alpha, beta, cfg)
+
+	status, err := (&StorageVersionMigrator{Client: cl}).Migrate(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "v1beta1", status.StorageVersion)
+	assert.Equal(t, []string{"argocd/argocd"}, status.Migrated)
+	assert.Empty(t, status.Skipped)
+	assert.Equal(t, []string{"v1beta1"}, status.StoredVersions)
+	assert.NotNil(t, status.CompletionTime)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: ArgoCDCRDName}, crd))
+	assert.Equal(t, []string{"v1beta1"}, crd.Status.StoredVersions)
+
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(cfg), cfg))
+	require.NotNil(t, cfg.Status.StorageMigration)
+	assert.Equal(t, []string{"argocd/argocd"}, cfg.Status.StorageMigration.Migrated)
+}
+
+func TestStorageVersionMigrator_Migrate_lossy(t *testing.T) {
+	// The deprecated .spec.dex of v1alpha1 is renamed to .spec.sso.dex, unless it configures no connector.
+	renamedAlpha, renamedBeta := makeTestArgoCDs(t, "argocd", func(a *v1alpha1.ArgoCD) {
+		a.Spec.Dex = &v1alpha1.ArgoCDDexSpec{OpenShiftOAuth: true} //nolint:staticcheck // SA1019: We must test deprecated fields.
+	})
+	lossyAlpha, lossyBeta := makeTestArgoCDs(t, "dex", func(a *v1alpha1.ArgoCD) {
+		a.Spec.Dex = &v1alpha1.ArgoCDDexSpec{Image: "dex"} //nolint:staticcheck // SA1019: We must test deprecated fields.
+	})
+	cl := makeTestClient(t, makeTestCRD("v1alpha1", "v1beta1"), renamedAlpha, renamedBeta, lossyAlpha, lossyBeta)
+
+	status, err := (&StorageVersionMigrator{Client: cl}).Migrate(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"argocd/argocd"}, status.Migrated)
+	require.Len(t, status.Skipped, 1)
+	assert.Equal(t, "dex", status.Skipped[0].Name)
+	assert.Equal(t, []string{"spec.dex"}, status.Skipped[0].LossyFields)
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, status.StoredVersions, "the stored versions are kept until every ArgoCD is migrated")
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: ArgoCDCRDName}, crd))
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, crd.Status.StoredVersions)
+
+	events := &corev1.EventList{}
+	require.NoError(t, cl.List(context.TODO(), events))
+	require.Len(t, events.Items, 1)
+	assert.Equal(t, "StorageMigrationSkipped", events.Items[0].Reason)
+	assert.Contains(t, events.Items[0].Message, "[spec.dex]")
+}
+
+func TestStorageVersionMigrator_Migrate_renamedFields(t *testing.T) {
+	// The deprecated Keycloak fields of .spec.sso and .spec.dex of v1alpha1 are renamed by the conversion.
+	alpha, beta := makeTestArgoCDs(t, "argocd", func(a *v1alpha1.ArgoCD) {
+		a.Spec.SSO = &v1alpha1.ArgoCDSSOSpec{Image: "keycloak", VerifyTLS: ptr.To(false)}          //nolint:staticcheck // SA1019: We must test deprecated fields.
+		a.Spec.Dex = &v1alpha1.ArgoCDDexSpec{Config: "connectors: []", Groups: []string{"admins"}} //nolint:staticcheck // SA1019: We must test deprecated fields.
+	})
+	cl := makeTestClient(t, makeTestCRD("v1alpha1", "v1beta1"), alpha, beta)
+
+	status, err := (&StorageVersionMigrator{Client: cl}).Migrate(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"argocd/argocd"}, status.Migrated)
+	assert.Empty(t, status.Skipped)
+	assert.Equal(t, []string{"v1beta1"}, status.StoredVersions)
+}
+
+func TestStorageVersionMigrator_Migrate_unsupportedStorageVersion(t *testing.T) {
+	crd := makeTestCRD("v1alpha1")
+	crd.Spec.Versions[0].Storage, crd.Spec.Versions[1].Storage = true, false
+	cl := makeTestClient(t, crd)
+
+	_, err := (&StorageVersionMigrator{Client: cl}).Migrate(context.TODO())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `the storage version of the ArgoCD CRD is "v1alpha1"`)
+}
+
+func TestStorageVersionMigrator_Start(t *testing.T) {
+	alpha, beta := makeTestArgoCDs(t, "argocd")
+	cl := makeTestClient(t, makeTestCRD("v1beta1"), alpha, beta)
+
+	// The ArgoCD objects are already stored in the storage version.
+	require.NoError(t, (&StorageVersionMigrator{Client: cl}).Start(context.TODO()))
+	latest := &argoproj.ArgoCD{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(beta), latest))
+	assert.Equal(t, beta.ResourceVersion, latest.ResourceVersion)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: ArgoCDCRDName}, crd))
+	crd.Status.StoredVersions = []string{"v1alpha1", "v1beta1"}
+	require.NoError(t, cl.Status().Update(context.TODO(), crd))
+
+	require.NoError(t, (&StorageVersionMigrator{Client: cl}).Start(context.TODO()))
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(beta), latest))
+	assert.NotEqual(t, beta.ResourceVersion, latest.ResourceVersion)
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: ArgoCDCRDName}, crd))
+	assert.Equal(t, []string{"v1beta1"}, crd.Status.StoredVersions)
+}